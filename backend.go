@@ -12,3 +12,11 @@ type Backend interface {
 
 	GraceTime(Container) time.Duration
 }
+
+// SelectorBackend is implemented by Backends that can select containers with
+// a Selector themselves. For other Backends the server lists the containers
+// with the properties the selector requires to equal a value, and evaluates
+// the rest of the selector itself.
+type SelectorBackend interface {
+	ContainersMatching(selector Selector) ([]Container, error)
+}
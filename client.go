@@ -143,6 +143,16 @@ type ContainerSpec struct {
 
 	// Limits to be applied to the newly created container.
 	Limits Limits `json:"limits,omitempty"`
+
+	// IdempotencyKey, if specified, identifies the create request, so that it
+	// can be repeated safely, e.g. after timing out. The server returns the
+	// handle of the container it created for the key rather than creating
+	// another, for as long as it remembers the key. Repeating the create with
+	// a different spec is a ConflictError. Keys are scoped to the caller, as
+	// identified by its credentials. Keys of creates that failed, and of
+	// containers that have since been destroyed, are forgotten, so that the
+	// create can be tried again.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 type Limits struct {
@@ -184,6 +194,54 @@ type Capacity struct {
 	MaxContainers uint64 `json:"max_containers,omitempty"`
 }
 
+// PendingReap describes a container the server will destroy once it has not
+// been referenced by any request for its grace time.
+type PendingReap struct {
+	Handle    string        //
+	GraceTime time.Duration //
+	Pauses    int           // The number of requests referencing the container in flight, which hold off its grace time.
+	ReapAt    time.Time     // When the container will be destroyed if it is not referenced again. Zero while Pauses is non-zero.
+}
+
+// ListOptions selects a page of containers, and the fields of their info to
+// return with them. Empty fields select every container, on a single page,
+// without their info.
+type ListOptions struct {
+	Properties Properties           // Properties the containers must have.
+	Selector   Selector             // A selector the containers' properties must match.
+	Limit      int                  // The most containers to return. Zero returns every container.
+	Continue   string               // The Continue token of the previous page, to return the page after it.
+	Fields     []ContainerInfoField // The fields of ContainerInfo to return for each container.
+}
+
+// ContainerInfoField names fields of ContainerInfo that can be returned when
+// listing containers.
+type ContainerInfoField string
+
+const (
+	ContainerInfoFieldState         ContainerInfoField = "state"
+	ContainerInfoFieldEvents        ContainerInfoField = "events"
+	ContainerInfoFieldIPs           ContainerInfoField = "ips" // HostIP, ContainerIP and ExternalIP.
+	ContainerInfoFieldContainerPath ContainerInfoField = "container_path"
+	ContainerInfoFieldProcessIDs    ContainerInfoField = "process_ids"
+	ContainerInfoFieldProperties    ContainerInfoField = "properties"
+	ContainerInfoFieldMappedPorts   ContainerInfoField = "mapped_ports"
+)
+
+// ListPage is a page of container handles, ordered by handle.
+type ListPage struct {
+	Handles  []string                 //
+	Infos    map[string]ContainerInfo // The requested fields of each container's info, by handle. Nil if no fields were requested. Containers whose info the caller may not get have no entry.
+	Continue string                   // The token for the next page. Empty on the last page.
+}
+
+// ContainerPage is a page of containers, ordered by handle.
+type ContainerPage struct {
+	Containers []Container              //
+	Infos      map[string]ContainerInfo // The requested fields of each container's info, by handle. Nil if no fields were requested. Containers whose info the caller may not get have no entry.
+	Continue   string                   // The token for the next page. Empty on the last page.
+}
+
 type Properties map[string]string
 
 type BindMountMode uint8
@@ -1,12 +1,52 @@
 package client
 
 import (
+	"context"
+
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/cloudfoundry-incubator/garden/client/connection"
 )
 
 type Client interface {
 	garden.Client
+	garden.ContextClient
+
+	// Events streams the container lifecycle events matching filter as the
+	// server observes them. Events that happened before the call are not
+	// replayed. The stream must be closed by the caller.
+	Events(filter garden.EventFilter) (garden.EventStream, error)
+
+	// PendingReaps describes each container the server will destroy once its
+	// grace time has passed, without destroying any, so that operators can tell
+	// when and why containers will be reaped.
+	PendingReaps() ([]garden.PendingReap, error)
+
+	// ContainersMatching is like Containers, but selects the containers whose
+	// properties match the selector.
+	ContainersMatching(selector garden.Selector) ([]garden.Container, error)
+
+	// ContainersPage is like Containers, but returns the page of containers
+	// the options select, with the requested fields of their info, so that
+	// hosts with many containers can be listed without a BulkInfo of every
+	// container.
+	ContainersPage(options garden.ListOptions) (garden.ContainerPage, error)
+
+	// BulkDestroy destroys each of the containers, as Destroy does, returning
+	// the outcome for each of them. The server destroys several at once.
+	BulkDestroy(handles []string) (map[string]garden.ContainerResultEntry, error)
+
+	// BulkStop stops each of the containers, as Container.Stop does,
+	// returning the outcome for each of them. The server stops several at
+	// once.
+	BulkStop(handles []string, kill bool) (map[string]garden.ContainerResultEntry, error)
+
+	// DestroyAsync starts destroying a container, or joins the destroy already
+	// in flight, without waiting for it to finish. While it is destroyed its
+	// ContainerInfo.State is "destroying". WaitForDestroy waits for the destroy
+	// to finish and returns its outcome, which the server keeps for some time
+	// after it finishes.
+	DestroyAsync(handle string) error
+	WaitForDestroy(handle string) error
 }
 
 type client struct {
@@ -42,12 +82,38 @@ func (client *client) Containers(properties garden.Properties) ([]garden.Contain
 		return nil, err
 	}
 
+	return client.containers(handles), nil
+}
+
+func (client *client) ContainersMatching(selector garden.Selector) ([]garden.Container, error) {
+	handles, err := client.connection.ListMatching(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.containers(handles), nil
+}
+
+func (client *client) ContainersPage(options garden.ListOptions) (garden.ContainerPage, error) {
+	page, err := client.connection.ListPage(options)
+	if err != nil {
+		return garden.ContainerPage{}, err
+	}
+
+	return garden.ContainerPage{
+		Containers: client.containers(page.Handles),
+		Infos:      page.Infos,
+		Continue:   page.Continue,
+	}, nil
+}
+
+func (client *client) containers(handles []string) []garden.Container {
 	containers := []garden.Container{}
 	for _, handle := range handles {
 		containers = append(containers, newContainer(handle, client.connection))
 	}
 
-	return containers, nil
+	return containers
 }
 
 func (client *client) Destroy(handle string) error {
@@ -64,6 +130,22 @@ func (client *client) BulkMetrics(handles []string) (map[string]garden.Container
 	return client.connection.BulkMetrics(handles)
 }
 
+func (client *client) DestroyAsync(handle string) error {
+	return client.connection.DestroyAsync(handle)
+}
+
+func (client *client) WaitForDestroy(handle string) error {
+	return client.connection.WaitForDestroy(handle)
+}
+
+func (client *client) BulkDestroy(handles []string) (map[string]garden.ContainerResultEntry, error) {
+	return client.connection.BulkDestroy(handles)
+}
+
+func (client *client) BulkStop(handles []string, kill bool) (map[string]garden.ContainerResultEntry, error) {
+	return client.connection.BulkStop(handles, kill)
+}
+
 func (client *client) Lookup(handle string) (garden.Container, error) {
 	handles, err := client.connection.List(nil)
 	if err != nil {
@@ -78,3 +160,64 @@ func (client *client) Lookup(handle string) (garden.Container, error) {
 
 	return nil, garden.ContainerNotFoundError{Handle: handle}
 }
+
+func (client *client) Events(filter garden.EventFilter) (garden.EventStream, error) {
+	return client.connection.Events(filter)
+}
+
+func (client *client) PendingReaps() ([]garden.PendingReap, error) {
+	return client.connection.PendingReaps()
+}
+
+func (client *client) PingContext(ctx context.Context) error {
+	return client.connection.WithContext(ctx).Ping()
+}
+
+func (client *client) CapacityContext(ctx context.Context) (garden.Capacity, error) {
+	return client.connection.WithContext(ctx).Capacity()
+}
+
+func (client *client) CreateContext(ctx context.Context, spec garden.ContainerSpec) (garden.Container, error) {
+	handle, err := client.connection.WithContext(ctx).Create(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return newContainer(handle, client.connection), nil
+}
+
+func (client *client) ContainersContext(ctx context.Context, properties garden.Properties) ([]garden.Container, error) {
+	handles, err := client.connection.WithContext(ctx).List(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.containers(handles), nil
+}
+
+func (client *client) DestroyContext(ctx context.Context, handle string) error {
+	return client.connection.WithContext(ctx).Destroy(handle)
+}
+
+func (client *client) BulkInfoContext(ctx context.Context, handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	return client.connection.WithContext(ctx).BulkInfo(handles)
+}
+
+func (client *client) BulkMetricsContext(ctx context.Context, handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	return client.connection.WithContext(ctx).BulkMetrics(handles)
+}
+
+func (client *client) LookupContext(ctx context.Context, handle string) (garden.Container, error) {
+	handles, err := client.connection.WithContext(ctx).List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range handles {
+		if h == handle {
+			return newContainer(handle, client.connection), nil
+		}
+	}
+
+	return nil, garden.ContainerNotFoundError{Handle: handle}
+}
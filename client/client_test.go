@@ -1,6 +1,7 @@
 package client_test
 
 import (
+	"context"
 	"errors"
 
 	. "github.com/onsi/ginkgo"
@@ -57,6 +58,34 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Events", func() {
+		It("subscribes to events with the given filter", func() {
+			fakeStream := new(fakeEventStream)
+			fakeConnection.EventsReturns(fakeStream, nil)
+
+			filter := garden.EventFilter{Handles: []string{"some-handle"}}
+
+			stream, err := client.Events(filter)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(stream).Should(Equal(fakeStream))
+
+			Ω(fakeConnection.EventsArgsForCall(0)).Should(Equal(filter))
+		})
+
+		Context("when subscribing fails", func() {
+			disaster := errors.New("oh no!")
+
+			BeforeEach(func() {
+				fakeConnection.EventsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				_, err := client.Events(garden.EventFilter{})
+				Ω(err).Should(Equal(disaster))
+			})
+		})
+	})
+
 	Describe("BulkInfo", func() {
 		expectedBulkInfo := map[string]garden.ContainerInfoEntry{
 			"handle1": garden.ContainerInfoEntry{
@@ -208,6 +237,76 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("ContainersPage", func() {
+		It("sends a list request and returns the page of containers", func() {
+			fakeConnection.ListPageReturns(garden.ListPage{
+				Handles:  []string{"handle-a", "handle-b"},
+				Infos:    map[string]garden.ContainerInfo{"handle-a": {State: "active"}, "handle-b": {State: "stopped"}},
+				Continue: "some-token",
+			}, nil)
+
+			options := garden.ListOptions{
+				Limit:  2,
+				Fields: []garden.ContainerInfoField{garden.ContainerInfoFieldState},
+			}
+
+			page, err := client.ContainersPage(options)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(fakeConnection.ListPageArgsForCall(0)).Should(Equal(options))
+
+			Ω(page.Containers).Should(HaveLen(2))
+			Ω(page.Containers[0].Handle()).Should(Equal("handle-a"))
+			Ω(page.Containers[1].Handle()).Should(Equal("handle-b"))
+			Ω(page.Infos["handle-b"].State).Should(Equal("stopped"))
+			Ω(page.Continue).Should(Equal("some-token"))
+		})
+
+		Context("when there is a connection error", func() {
+			disaster := errors.New("oh no!")
+
+			BeforeEach(func() {
+				fakeConnection.ListPageReturns(garden.ListPage{}, disaster)
+			})
+
+			It("returns it", func() {
+				_, err := client.ContainersPage(garden.ListOptions{})
+				Ω(err).Should(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("BulkDestroy", func() {
+		It("sends a bulk destroy request and returns the outcomes", func() {
+			results := map[string]garden.ContainerResultEntry{
+				"handle-a": {},
+				"handle-b": {Err: &garden.Error{Err: garden.ContainerNotFoundError{Handle: "handle-b"}}},
+			}
+			fakeConnection.BulkDestroyReturns(results, nil)
+
+			destroyed, err := client.BulkDestroy([]string{"handle-a", "handle-b"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(destroyed).Should(Equal(results))
+
+			Ω(fakeConnection.BulkDestroyArgsForCall(0)).Should(Equal([]string{"handle-a", "handle-b"}))
+		})
+	})
+
+	Describe("BulkStop", func() {
+		It("sends a bulk stop request and returns the outcomes", func() {
+			results := map[string]garden.ContainerResultEntry{"handle-a": {}}
+			fakeConnection.BulkStopReturns(results, nil)
+
+			stopped, err := client.BulkStop([]string{"handle-a"}, true)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(stopped).Should(Equal(results))
+
+			handles, kill := fakeConnection.BulkStopArgsForCall(0)
+			Ω(handles).Should(Equal([]string{"handle-a"}))
+			Ω(kill).Should(BeTrue())
+		})
+	})
+
 	Describe("Destroy", func() {
 		It("sends a destroy request", func() {
 			err := client.Destroy("some-handle")
@@ -265,3 +364,99 @@ var _ = Describe("Client", func() {
 		})
 	})
 })
+
+var _ = Describe("Client with a context", func() {
+	var (
+		client Client
+
+		fakeConnection  *fakes.FakeConnection
+		boundConnection *fakes.FakeConnection
+
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		fakeConnection = new(fakes.FakeConnection)
+		boundConnection = new(fakes.FakeConnection)
+		fakeConnection.WithContextReturns(boundConnection)
+
+		ctx = context.WithValue(context.Background(), "some-key", "some-value")
+
+		client = New(fakeConnection)
+	})
+
+	Describe("CreateContext", func() {
+		It("sends the create request bound to the context", func() {
+			boundConnection.CreateReturns("some-handle", nil)
+
+			container, err := client.CreateContext(ctx, garden.ContainerSpec{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(fakeConnection.WithContextArgsForCall(0)).Should(Equal(ctx))
+			Ω(boundConnection.CreateCallCount()).Should(Equal(1))
+			Ω(fakeConnection.CreateCallCount()).Should(BeZero())
+
+			Ω(container.Handle()).Should(Equal("some-handle"))
+		})
+
+		It("returns a container that is not bound to the context", func() {
+			boundConnection.CreateReturns("some-handle", nil)
+
+			container, err := client.CreateContext(ctx, garden.ContainerSpec{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(container.Stop(false)).Should(Succeed())
+			Ω(fakeConnection.StopCallCount()).Should(Equal(1))
+			Ω(boundConnection.StopCallCount()).Should(BeZero())
+		})
+	})
+
+	Describe("DestroyContext", func() {
+		It("sends the destroy request bound to the context", func() {
+			disaster := errors.New("oh no!")
+			boundConnection.DestroyReturns(disaster)
+
+			Ω(client.DestroyContext(ctx, "some-handle")).Should(Equal(disaster))
+
+			Ω(fakeConnection.WithContextArgsForCall(0)).Should(Equal(ctx))
+			Ω(boundConnection.DestroyArgsForCall(0)).Should(Equal("some-handle"))
+		})
+	})
+
+	Describe("LookupContext", func() {
+		It("sends the list request bound to the context", func() {
+			boundConnection.ListReturns([]string{"some-handle"}, nil)
+
+			container, err := client.LookupContext(ctx, "some-handle")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(container.Handle()).Should(Equal("some-handle"))
+
+			Ω(fakeConnection.WithContextArgsForCall(0)).Should(Equal(ctx))
+		})
+	})
+
+	Describe("a container's StreamInContext", func() {
+		It("sends the stream in request bound to the context", func() {
+			fakeConnection.ListReturns([]string{"some-handle"}, nil)
+
+			gardenContainer, err := client.Lookup("some-handle")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			container := gardenContainer.(Container)
+
+			spec := garden.StreamInSpec{Path: "/some/path"}
+			Ω(container.StreamInContext(ctx, spec)).Should(Succeed())
+
+			Ω(fakeConnection.WithContextArgsForCall(0)).Should(Equal(ctx))
+
+			handle, boundSpec := boundConnection.StreamInArgsForCall(0)
+			Ω(handle).Should(Equal("some-handle"))
+			Ω(boundSpec).Should(Equal(spec))
+		})
+	})
+})
+
+type fakeEventStream struct{}
+
+func (fakeEventStream) Next() (garden.Event, error) { return garden.Event{}, nil }
+func (fakeEventStream) Close() error                { return nil }
@@ -3,12 +3,15 @@ package connection
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +25,10 @@ import (
 var ErrDisconnected = errors.New("disconnected")
 var ErrInvalidMessage = errors.New("invalid message payload")
 
+// ErrUnsupportedSignal is returned when signalling a process with a signal
+// that the server is too old to forward.
+var ErrUnsupportedSignal = errors.New("signal not supported by server")
+
 //go:generate counterfeiter . Connection
 type Connection interface {
 	Ping() error
@@ -31,17 +38,37 @@ type Connection interface {
 	Create(spec garden.ContainerSpec) (string, error)
 	List(properties garden.Properties) ([]string, error)
 
+	// ListMatching lists the handles of the containers whose properties
+	// match the selector.
+	ListMatching(selector garden.Selector) ([]string, error)
+
+	// ListPage lists the page of handles the options select, with the
+	// requested fields of the containers' info.
+	ListPage(options garden.ListOptions) (garden.ListPage, error)
+
 	// Destroys the container with the given handle. If the container cannot be
 	// found, garden.ContainerNotFoundError is returned. If deletion fails for another
 	// reason, another error type is returned.
 	Destroy(handle string) error
 
+	// DestroyAsync starts destroying the container with the given handle, or
+	// joins the destroy already in flight, and returns without waiting for it
+	// to finish. WaitForDestroy waits for the destroy to finish and returns
+	// its outcome.
+	DestroyAsync(handle string) error
+	WaitForDestroy(handle string) error
+
 	Stop(handle string, kill bool) error
 
 	Info(handle string) (garden.ContainerInfo, error)
 	BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error)
 	BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error)
 
+	// BulkDestroy and BulkStop act on each of the containers, returning the
+	// outcome for each of them.
+	BulkDestroy(handles []string) (map[string]garden.ContainerResultEntry, error)
+	BulkStop(handles []string, kill bool) (map[string]garden.ContainerResultEntry, error)
+
 	StreamIn(handle string, spec garden.StreamInSpec) error
 	StreamOut(handle string, spec garden.StreamOutSpec) (io.ReadCloser, error)
 
@@ -50,9 +77,30 @@ type Connection interface {
 	CurrentDiskLimits(handle string) (garden.DiskLimits, error)
 	CurrentMemoryLimits(handle string) (garden.MemoryLimits, error)
 
+	SetBandwidthLimits(handle string, limits garden.BandwidthLimits) error
+	SetCPULimits(handle string, limits garden.CPULimits) error
+	SetDiskLimits(handle string, limits garden.DiskLimits) error
+	SetMemoryLimits(handle string, limits garden.MemoryLimits) error
+
 	Run(handle string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
+
+	// RunDetached is like Run, but the server keeps the process's exit status
+	// and the end of its output for a while after it exits, for ProcessStatus
+	// and for Wait on a later Attach.
+	RunDetached(handle string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
 	Attach(handle string, processID string, io garden.ProcessIO) (garden.Process, error)
 
+	// AttachFrom is like Attach, but streams the process's stdout and stderr
+	// from the given offsets, e.g. those reported by an earlier attachment's
+	// OutputOffsets. Output from before the offsets is not streamed again.
+	AttachFrom(handle string, processID string, offsets OutputOffsets, io garden.ProcessIO) (garden.Process, error)
+
+	Processes(handle string) ([]garden.ProcessInfo, error)
+	Process(handle string, processID string) (garden.ProcessInfo, error)
+
+	// ProcessStatus returns the status of a process run with RunDetached.
+	ProcessStatus(handle string, processID string) (garden.ProcessStatus, error)
+
 	NetIn(handle string, hostPort, containerPort uint32) (uint32, uint32, error)
 	NetOut(handle string, rule garden.NetOutRule) error
 
@@ -64,12 +112,34 @@ type Connection interface {
 
 	Metrics(handle string) (garden.Metrics, error)
 	RemoveProperty(handle string, name string) error
+
+	// VersionedProperties returns the properties along with their version.
+	// UpdateProperties sets and removes properties in one atomic change,
+	// returning their new version. If expectedVersion is not empty and the
+	// properties are no longer at that version it fails with a
+	// garden.ConflictError, changing nothing.
+	VersionedProperties(handle string) (garden.VersionedProperties, error)
+	UpdateProperties(handle string, set garden.Properties, remove []string, expectedVersion string) (string, error)
+
+	Events(filter garden.EventFilter) (garden.EventStream, error)
+
+	PendingReaps() ([]garden.PendingReap, error)
+
+	// WithContext returns a Connection whose requests are cancelled along with
+	// ctx. Streams and processes it returns are only bound to ctx until they
+	// have been set up, except for StreamOut and Events streams.
+	WithContext(ctx context.Context) Connection
 }
 
 //go:generate counterfeiter . HijackStreamer
 type HijackStreamer interface {
 	Stream(handler string, body io.Reader, params rata.Params, query url.Values, contentType string) (io.ReadCloser, error)
 	Hijack(handler string, body io.Reader, params rata.Params, query url.Values, contentType string) (net.Conn, *bufio.Reader, error)
+
+	// WithContext returns a HijackStreamer whose requests are cancelled along
+	// with ctx. Hijacked connections outlive ctx once the server has
+	// responded; streamed response bodies do not.
+	WithContext(ctx context.Context) HijackStreamer
 }
 
 type connection struct {
@@ -95,6 +165,15 @@ func NewWithLogger(network, address string, logger lager.Logger) Connection {
 	return NewWithHijacker(hijacker, logger)
 }
 
+func NewWithTLS(network, address string, tlsConfig *tls.Config) Connection {
+	return NewWithTLSAndLogger(network, address, tlsConfig, lager.NewLogger("garden-connection"))
+}
+
+func NewWithTLSAndLogger(network, address string, tlsConfig *tls.Config, logger lager.Logger) Connection {
+	hijacker := NewHijackStreamerWithTLS(network, address, tlsConfig)
+	return NewWithHijacker(hijacker, logger)
+}
+
 func NewWithDialerAndLogger(dialer DialerFunc, log lager.Logger) Connection {
 	hijacker := NewHijackStreamerWithDialer(dialer)
 	return NewWithHijacker(hijacker, log)
@@ -107,6 +186,13 @@ func NewWithHijacker(hijacker HijackStreamer, log lager.Logger) Connection {
 	}
 }
 
+func (c *connection) WithContext(ctx context.Context) Connection {
+	return &connection{
+		hijacker: c.hijacker.WithContext(ctx),
+		log:      c.log,
+	}
+}
+
 func (c *connection) Ping() error {
 	return c.do(routes.Ping, nil, &struct{}{}, nil, nil)
 }
@@ -160,7 +246,41 @@ func (c *connection) Destroy(handle string) error {
 	)
 }
 
+func (c *connection) DestroyAsync(handle string) error {
+	return c.do(
+		routes.Destroy,
+		nil,
+		&struct{}{},
+		rata.Params{
+			"handle": handle,
+		},
+		url.Values{
+			"async": []string{"true"},
+		},
+	)
+}
+
+func (c *connection) WaitForDestroy(handle string) error {
+	return c.do(
+		routes.WaitForDestroy,
+		nil,
+		&struct{}{},
+		rata.Params{
+			"handle": handle,
+		},
+		nil,
+	)
+}
+
 func (c *connection) Run(handle string, spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	return c.run(handle, spec, processIO, nil)
+}
+
+func (c *connection) RunDetached(handle string, spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	return c.run(handle, spec, processIO, url.Values{"detached": []string{"true"}})
+}
+
+func (c *connection) run(handle string, spec garden.ProcessSpec, processIO garden.ProcessIO, query url.Values) (garden.Process, error) {
 	reqBody := new(bytes.Buffer)
 
 	err := transport.WriteMessage(reqBody, spec)
@@ -174,17 +294,25 @@ func (c *connection) Run(handle string, spec garden.ProcessSpec, processIO garde
 		rata.Params{
 			"handle": handle,
 		},
-		nil,
+		query,
 		"application/json",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("hijack: %s", err)
 	}
 
-	return c.streamProcess(handle, processIO, hijackedConn, hijackedResponseReader)
+	return c.streamProcess(handle, processIO, hijackedConn, hijackedResponseReader, nil)
 }
 
 func (c *connection) Attach(handle string, processID string, processIO garden.ProcessIO) (garden.Process, error) {
+	return c.attach(handle, processID, processIO, nil)
+}
+
+func (c *connection) AttachFrom(handle string, processID string, offsets OutputOffsets, processIO garden.ProcessIO) (garden.Process, error) {
+	return c.attach(handle, processID, processIO, &offsets)
+}
+
+func (c *connection) attach(handle string, processID string, processIO garden.ProcessIO, offsets *OutputOffsets) (garden.Process, error) {
 	reqBody := new(bytes.Buffer)
 
 	hijackedConn, hijackedResponseReader, err := c.hijacker.Hijack(
@@ -201,10 +329,12 @@ func (c *connection) Attach(handle string, processID string, processIO garden.Pr
 		return nil, err
 	}
 
-	return c.streamProcess(handle, processIO, hijackedConn, hijackedResponseReader)
+	return c.streamProcess(handle, processIO, hijackedConn, hijackedResponseReader, offsets)
 }
 
-func (c *connection) streamProcess(handle string, processIO garden.ProcessIO, hijackedConn net.Conn, hijackedResponseReader *bufio.Reader) (garden.Process, error) {
+// streamProcess streams the process's output from offsets, or from where the
+// server says to if offsets is nil.
+func (c *connection) streamProcess(handle string, processIO garden.ProcessIO, hijackedConn net.Conn, hijackedResponseReader *bufio.Reader, offsets *OutputOffsets) (garden.Process, error) {
 	decoder := json.NewDecoder(hijackedResponseReader)
 
 	payload := &transport.ProcessPayload{}
@@ -212,45 +342,63 @@ func (c *connection) streamProcess(handle string, processIO garden.ProcessIO, hi
 		return nil, err
 	}
 
+	if offsets == nil {
+		offsets = &OutputOffsets{
+			Stdout: payload.StdoutOffset,
+			Stderr: payload.StderrOffset,
+		}
+	}
+
 	processPipeline := &processStream{
-		processID: payload.ProcessID,
-		conn:      hijackedConn,
+		processID:  payload.ProcessID,
+		allSignals: payload.AllSignals,
+		conn:       hijackedConn,
 	}
 
-	hijack := func(streamType string) (net.Conn, io.Reader, error) {
+	hijack := func(streamType string, offset uint64) (net.Conn, io.Reader, error) {
 		params := rata.Params{
 			"handle":   handle,
 			"pid":      processPipeline.ProcessID(),
 			"streamid": payload.StreamID,
 		}
 
+		query := url.Values{
+			"offset": []string{fmt.Sprintf("%d", offset)},
+		}
+
 		return c.hijacker.Hijack(
 			streamType,
 			nil,
 			params,
-			nil,
+			query,
 			"application/json",
 		)
 	}
 
-	process := newProcess(payload.ProcessID, processPipeline)
+	process := newProcess(payload.ProcessID, processPipeline, *offsets)
 	streamHandler := newStreamHandler(c.log)
 	streamHandler.streamIn(processPipeline, processIO.Stdin)
 
+	// there is no output to stream from a process that has already exited
+	if payload.StreamID == "" {
+		processIO.Stdout = nil
+		processIO.Stderr = nil
+	}
+
 	var stdoutConn net.Conn
 	if processIO.Stdout != nil {
 		var (
 			stdout io.Reader
 			err    error
 		)
-		stdoutConn, stdout, err = hijack(routes.Stdout)
+		stdoutConn, stdout, err = hijack(routes.Stdout, offsets.Stdout)
 		if err != nil {
 			werr := fmt.Errorf("connection: failed to hijack stream %s: %s", routes.Stdout, err)
 			process.exited(0, werr)
 			hijackedConn.Close()
 			return process, nil
 		}
-		streamHandler.streamOut(processIO.Stdout, stdout)
+		streamHandler.streamOut(processIO.Stdout, stdout, &process.stdoutOffset)
 	}
 
 	var stderrConn net.Conn
@@ -259,14 +407,14 @@ func (c *connection) streamProcess(handle string, processIO garden.ProcessIO, hi
 			stderr io.Reader
 			err    error
 		)
-		stderrConn, stderr, err = hijack(routes.Stderr)
+		stderrConn, stderr, err = hijack(routes.Stderr, offsets.Stderr)
 		if err != nil {
 			werr := fmt.Errorf("connection: failed to hijack stream %s: %s", routes.Stderr, err)
 			process.exited(0, werr)
 			hijackedConn.Close()
 			return process, nil
 		}
-		streamHandler.streamOut(processIO.Stderr, stderr)
+		streamHandler.streamOut(processIO.Stderr, stderr, &process.stderrOffset)
 	}
 
 	go func() {
@@ -278,7 +426,16 @@ func (c *connection) streamProcess(handle string, processIO garden.ProcessIO, hi
 			defer stderrConn.Close()
 		}
 
-		exitCode, err := streamHandler.wait(decoder)
+		exitCode, dropped, err := streamHandler.wait(decoder)
+		if dropped > 0 {
+			c.log.Info("process-output-dropped", lager.Data{
+				"handle":  handle,
+				"id":      process.ID(),
+				"dropped": dropped,
+			})
+		}
+
+		process.droppedOutput(dropped)
 		process.exited(exitCode, err)
 	}()
 
@@ -380,6 +537,46 @@ func (c *connection) RemoveProperty(handle string, name string) error {
 	return nil
 }
 
+func (c *connection) VersionedProperties(handle string) (garden.VersionedProperties, error) {
+	res := garden.VersionedProperties{}
+
+	err := c.do(
+		routes.Properties,
+		nil,
+		&res,
+		rata.Params{
+			"handle": handle,
+		},
+		url.Values{
+			"versioned": []string{"true"},
+		},
+	)
+
+	return res, err
+}
+
+func (c *connection) UpdateProperties(handle string, set garden.Properties, remove []string, expectedVersion string) (string, error) {
+	res := struct {
+		Version string
+	}{}
+
+	err := c.do(
+		routes.UpdateProperties,
+		map[string]interface{}{
+			"set":              set,
+			"remove":           remove,
+			"expected_version": expectedVersion,
+		},
+		&res,
+		rata.Params{
+			"handle": handle,
+		},
+		nil,
+	)
+
+	return res.Version, err
+}
+
 func (c *connection) CurrentBandwidthLimits(handle string) (garden.BandwidthLimits, error) {
 	res := garden.BandwidthLimits{}
 
@@ -480,26 +677,70 @@ func (c *connection) StreamOut(handle string, spec garden.StreamOutSpec) (io.Rea
 }
 
 func (c *connection) List(filterProperties garden.Properties) ([]string, error) {
+	page, err := c.ListPage(garden.ListOptions{Properties: filterProperties})
+	return page.Handles, err
+}
+
+func (c *connection) ListMatching(selector garden.Selector) ([]string, error) {
+	page, err := c.ListPage(garden.ListOptions{Selector: selector})
+	return page.Handles, err
+}
+
+func (c *connection) ListPage(options garden.ListOptions) (garden.ListPage, error) {
 	values := url.Values{}
-	for name, val := range filterProperties {
+	for name, val := range options.Properties {
 		values[name] = []string{val}
 	}
 
-	res := &struct {
-		Handles []string
-	}{}
+	if len(options.Selector) > 0 {
+		values.Set("garden.selector", options.Selector.String())
+	}
+
+	if options.Limit > 0 {
+		values.Set("garden.limit", strconv.Itoa(options.Limit))
+	}
+
+	if options.Continue != "" {
+		values.Set("garden.continue", options.Continue)
+	}
 
+	if len(options.Fields) > 0 {
+		fields := make([]string, len(options.Fields))
+		for i, field := range options.Fields {
+			fields[i] = string(field)
+		}
+
+		values.Set("garden.fields", strings.Join(fields, ","))
+	}
+
+	var page garden.ListPage
 	if err := c.do(
 		routes.List,
 		nil,
-		&res,
+		&page,
 		nil,
 		values,
 	); err != nil {
-		return nil, err
+		return garden.ListPage{}, err
 	}
 
-	return res.Handles, nil
+	return page, nil
+}
+
+func (c *connection) SetBandwidthLimits(handle string, limits garden.BandwidthLimits) error {
+	return c.do(routes.SetBandwidthLimits, limits, &struct{}{}, rata.Params{"handle": handle}, nil)
+}
+
+func (c *connection) SetCPULimits(handle string, limits garden.CPULimits) error {
+	return c.do(routes.SetCPULimits, limits, &struct{}{}, rata.Params{"handle": handle}, nil)
+}
+
+func (c *connection) SetDiskLimits(handle string, limits garden.DiskLimits) error {
+	return c.do(routes.SetDiskLimits, limits, &struct{}{}, rata.Params{"handle": handle}, nil)
+}
+
+func (c *connection) SetMemoryLimits(handle string, limits garden.MemoryLimits) error {
+	return c.do(routes.SetMemoryLimits, limits, &struct{}{}, rata.Params{"handle": handle}, nil)
 }
 
 func (c *connection) SetGraceTime(handle string, graceTime time.Duration) error {
@@ -512,6 +753,24 @@ func (c *connection) Properties(handle string) (garden.Properties, error) {
 	return res, err
 }
 
+func (c *connection) Processes(handle string) ([]garden.ProcessInfo, error) {
+	res := []garden.ProcessInfo{}
+	err := c.do(routes.Processes, nil, &res, rata.Params{"handle": handle}, nil)
+	return res, err
+}
+
+func (c *connection) Process(handle string, processID string) (garden.ProcessInfo, error) {
+	res := garden.ProcessInfo{}
+	err := c.do(routes.Process, nil, &res, rata.Params{"handle": handle, "pid": processID}, nil)
+	return res, err
+}
+
+func (c *connection) ProcessStatus(handle string, processID string) (garden.ProcessStatus, error) {
+	res := garden.ProcessStatus{}
+	err := c.do(routes.ProcessStatus, nil, &res, rata.Params{"handle": handle, "pid": processID}, nil)
+	return res, err
+}
+
 func (c *connection) Metrics(handle string) (garden.Metrics, error) {
 	res := garden.Metrics{}
 	err := c.do(routes.Metrics, nil, &res, rata.Params{"handle": handle}, nil)
@@ -547,6 +806,53 @@ func (c *connection) BulkMetrics(handles []string) (map[string]garden.ContainerM
 	return res, err
 }
 
+func (c *connection) BulkDestroy(handles []string) (map[string]garden.ContainerResultEntry, error) {
+	res := make(map[string]garden.ContainerResultEntry)
+	queryParams := url.Values{
+		"handles": []string{strings.Join(handles, ",")},
+	}
+	err := c.do(routes.BulkDestroy, nil, &res, nil, queryParams)
+	return res, err
+}
+
+func (c *connection) BulkStop(handles []string, kill bool) (map[string]garden.ContainerResultEntry, error) {
+	res := make(map[string]garden.ContainerResultEntry)
+	queryParams := url.Values{
+		"handles": []string{strings.Join(handles, ",")},
+	}
+	err := c.do(routes.BulkStop, map[string]bool{"kill": kill}, &res, nil, queryParams)
+	return res, err
+}
+
+func (c *connection) Events(filter garden.EventFilter) (garden.EventStream, error) {
+	kinds := []string{}
+	for _, kind := range filter.Kinds {
+		kinds = append(kinds, string(kind))
+	}
+
+	body, err := c.hijacker.Stream(
+		routes.Events,
+		nil,
+		nil,
+		url.Values{
+			"handles": []string{strings.Join(filter.Handles, ",")},
+			"kinds":   []string{strings.Join(kinds, ",")},
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEventStream(body), nil
+}
+
+func (c *connection) PendingReaps() ([]garden.PendingReap, error) {
+	var res []garden.PendingReap
+	err := c.do(routes.PendingReaps, nil, &res, nil, nil)
+	return res, err
+}
+
 func (c *connection) do(
 	handler string,
 	req, res interface{},
@@ -2,6 +2,8 @@ package connection
 
 import (
 	"bufio"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,6 +25,7 @@ type hijackable struct {
 	req               *rata.RequestGenerator
 	noKeepaliveClient *http.Client
 	dialer            DialerFunc
+	ctx               context.Context
 }
 
 func NewHijackStreamer(network, address string) HijackStreamer {
@@ -31,10 +34,31 @@ func NewHijackStreamer(network, address string) HijackStreamer {
 	})
 }
 
+// NewHijackStreamerWithTLS dials the server with TLS, for both regular and
+// hijacked requests. If the server requires mutual TLS, tlsConfig must carry
+// a client certificate.
+func NewHijackStreamerWithTLS(network, address string, tlsConfig *tls.Config) HijackStreamer {
+	return NewHijackStreamerWithDialer(func(string, string) (net.Conn, error) {
+		return tls.DialWithDialer(&net.Dialer{Timeout: 2 * time.Second}, network, address, tlsConfig)
+	})
+}
+
 func NewHijackStreamerWithDialer(dialFunc DialerFunc) HijackStreamer {
+	return NewHijackStreamerWithDialerAndHeader(dialFunc, nil)
+}
+
+// NewHijackStreamerWithDialerAndHeader sends the given header with every
+// request, e.g. an Authorization header carrying a bearer token.
+func NewHijackStreamerWithDialerAndHeader(dialFunc DialerFunc, header http.Header) HijackStreamer {
+	req := rata.NewRequestGenerator("http://api", routes.Routes)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
 	return &hijackable{
-		req:    rata.NewRequestGenerator("http://api", routes.Routes),
+		req:    req,
 		dialer: dialFunc,
+		ctx:    context.Background(),
 		noKeepaliveClient: &http.Client{
 			Transport: &http.Transport{
 				Dial:              dialFunc,
@@ -44,12 +68,22 @@ func NewHijackStreamerWithDialer(dialFunc DialerFunc) HijackStreamer {
 	}
 }
 
+func (h *hijackable) WithContext(ctx context.Context) HijackStreamer {
+	bound := *h
+	bound.ctx = ctx
+	return &bound
+}
+
 func (h *hijackable) Hijack(handler string, body io.Reader, params rata.Params, query url.Values, contentType string) (net.Conn, *bufio.Reader, error) {
 	request, err := h.req.CreateRequest(handler, params, body)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if err := h.ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
 	if contentType != "" {
 		request.Header.Set("Content-Type", contentType)
 	}
@@ -63,9 +97,26 @@ func (h *hijackable) Hijack(handler string, body io.Reader, params rata.Params,
 		return nil, nil, err
 	}
 
+	// the context bounds the request, not the hijacked connection
+	responded := make(chan struct{})
+	go func() {
+		select {
+		case <-h.ctx.Done():
+			conn.Close()
+		case <-responded:
+		}
+	}()
+
 	client := httputil.NewClientConn(conn, nil)
 
 	httpResp, err := client.Do(request)
+	close(responded)
+
+	if ctxErr := h.ctx.Err(); ctxErr != nil {
+		conn.Close()
+		return nil, nil, ctxErr
+	}
+
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,6 +129,11 @@ func (h *hijackable) Hijack(handler string, body io.Reader, params rata.Params,
 			return nil, nil, fmt.Errorf("Backend error: Exit status: %d, error reading response body: %s", httpResp.StatusCode, err)
 		}
 
+		var result garden.Error
+		if err := json.Unmarshal(errRespBytes, &result); err == nil {
+			return nil, nil, result.Err
+		}
+
 		return nil, nil, fmt.Errorf("Backend error: Exit status: %d, message: %s", httpResp.StatusCode, errRespBytes)
 	}
 
@@ -92,6 +148,8 @@ func (c *hijackable) Stream(handler string, body io.Reader, params rata.Params,
 		return nil, err
 	}
 
+	request = request.WithContext(c.ctx)
+
 	if contentType != "" {
 		request.Header.Set("Content-Type", contentType)
 	}
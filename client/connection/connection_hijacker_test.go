@@ -2,14 +2,21 @@ package connection_test
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"errors"
+	"io/ioutil"
 	"net"
+	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/cloudfoundry-incubator/garden"
 	"github.com/cloudfoundry-incubator/garden/client/connection"
 	"github.com/cloudfoundry-incubator/garden/routes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 	"github.com/tedsuo/rata"
 )
 
@@ -58,4 +65,126 @@ var _ = Describe("ConnectionHijacker", func() {
 		})
 	})
 
+	Describe("constructing hijacker with TLS", func() {
+		var server *ghttp.Server
+		var hijackStreamer connection.HijackStreamer
+
+		BeforeEach(func() {
+			server = ghttp.NewTLSServer()
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/ping"),
+					ghttp.RespondWith(200, "{}"),
+				),
+			)
+
+			hijackStreamer = connection.NewHijackStreamerWithTLS(
+				"tcp",
+				server.HTTPTestServer.Listener.Addr().String(),
+				&tls.Config{InsecureSkipVerify: true},
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("streams over TLS", func() {
+			body, err := hijackStreamer.Stream(routes.Ping, nil, nil, nil, "")
+			Expect(err).NotTo(HaveOccurred())
+			defer body.Close()
+
+			Expect(ioutil.ReadAll(body)).To(Equal([]byte("{}")))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+			Expect(server.ReceivedRequests()[0].TLS).NotTo(BeNil())
+		})
+
+		It("hijacks over TLS", func() {
+			conn, _, err := hijackStreamer.Hijack(routes.Ping, nil, nil, nil, "")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, isTLS := conn.(*tls.Conn)
+			Expect(isTLS).To(BeTrue())
+		})
+	})
+
+	Describe("binding to a context", func() {
+		var (
+			server         *ghttp.Server
+			hijackStreamer connection.HijackStreamer
+			release        chan struct{}
+
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+
+			server = ghttp.NewServer()
+			server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+				<-release
+			})
+
+			ctx, cancel = context.WithCancel(context.Background())
+
+			hijackStreamer = connection.NewHijackStreamer(
+				"tcp",
+				server.HTTPTestServer.Listener.Addr().String(),
+			).WithContext(ctx)
+
+			time.AfterFunc(100*time.Millisecond, cancel)
+		})
+
+		AfterEach(func() {
+			cancel()
+			close(release)
+			server.Close()
+		})
+
+		It("abandons streams when the context is cancelled", func() {
+			_, err := hijackStreamer.Stream(routes.Ping, nil, nil, nil, "")
+			Expect(err).To(MatchError(ContainSubstring(context.Canceled.Error())))
+		})
+
+		It("abandons hijacks when the context is cancelled", func() {
+			_, _, err := hijackStreamer.Hijack(routes.Ping, nil, nil, nil, "")
+			Expect(err).To(Equal(context.Canceled))
+		})
+	})
+
+	Describe("when a hijack fails", func() {
+		var (
+			server         *ghttp.Server
+			hijackStreamer connection.HijackStreamer
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			hijackStreamer = connection.NewHijackStreamer(
+				"tcp",
+				server.HTTPTestServer.Listener.Addr().String(),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("returns the typed error sent by the server", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound,
+				`{"Type":"ProcessNotFoundError","Message":"unknown process: some-process","Handle":"some-handle","ProcessID":"some-process"}`))
+
+			_, _, err := hijackStreamer.Hijack(routes.Attach, nil, rata.Params{"handle": "some-handle", "pid": "some-process"}, nil, "")
+			Expect(err).To(Equal(garden.ProcessNotFoundError{Handle: "some-handle", ProcessID: "some-process"}))
+		})
+
+		It("describes responses that are not errors", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, "no."))
+
+			_, _, err := hijackStreamer.Hijack(routes.Ping, nil, nil, nil, "")
+			Expect(err).To(MatchError("Backend error: Exit status: 502, message: no."))
+		})
+	})
 })
@@ -289,6 +289,50 @@ var _ = Describe("Connection", func() {
 		})
 	})
 
+	Describe("Destroying asynchronously", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/containers/foo", "async=true"),
+					ghttp.RespondWith(202, "{}")))
+		})
+
+		It("should start destroying the container", func() {
+			err := connection.DestroyAsync("foo")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Describe("Waiting for a destroy", func() {
+		Context("when the destroy succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/containers/foo/destroy"),
+						ghttp.RespondWith(200, "{}")))
+			})
+
+			It("should return once it finishes", func() {
+				err := connection.WaitForDestroy("foo")
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("when the destroy fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/containers/foo/destroy"),
+						ghttp.RespondWith(500, `{"Type": "", "Message": "o no"}`)))
+			})
+
+			It("should return its error", func() {
+				err := connection.WaitForDestroy("foo")
+				Ω(err).Should(MatchError("o no"))
+			})
+		})
+	})
+
 	Describe("Stopping", func() {
 		BeforeEach(func() {
 			server.AppendHandlers(
@@ -369,6 +413,96 @@ var _ = Describe("Connection", func() {
 		})
 	})
 
+	Describe("setting limits", func() {
+		Describe("setting memory limits", func() {
+			limits := garden.MemoryLimits{LimitInBytes: 40}
+
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/containers/foo/limits/memory"),
+						ghttp.VerifyJSONRepresenting(limits),
+						ghttp.RespondWith(200, "{}"),
+					),
+				)
+			})
+
+			It("sets the memory limit", func() {
+				Ω(connection.SetMemoryLimits("foo", limits)).Should(Succeed())
+			})
+		})
+
+		Describe("setting cpu limits", func() {
+			limits := garden.CPULimits{LimitInShares: 40}
+
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/containers/foo/limits/cpu"),
+						ghttp.VerifyJSONRepresenting(limits),
+						ghttp.RespondWith(200, "{}"),
+					),
+				)
+			})
+
+			It("sets the cpu limit", func() {
+				Ω(connection.SetCPULimits("foo", limits)).Should(Succeed())
+			})
+		})
+
+		Describe("setting disk limits", func() {
+			limits := garden.DiskLimits{ByteSoft: 1, ByteHard: 2}
+
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/containers/foo/limits/disk"),
+						ghttp.VerifyJSONRepresenting(limits),
+						ghttp.RespondWith(200, "{}"),
+					),
+				)
+			})
+
+			It("sets the disk limit", func() {
+				Ω(connection.SetDiskLimits("foo", limits)).Should(Succeed())
+			})
+		})
+
+		Describe("setting bandwidth limits", func() {
+			limits := garden.BandwidthLimits{RateInBytesPerSecond: 1, BurstRateInBytesPerSecond: 2}
+
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/containers/foo/limits/bandwidth"),
+						ghttp.VerifyJSONRepresenting(limits),
+						ghttp.RespondWith(200, "{}"),
+					),
+				)
+			})
+
+			It("sets the bandwidth limit", func() {
+				Ω(connection.SetBandwidthLimits("foo", limits)).Should(Succeed())
+			})
+		})
+
+		Context("when the server fails to set a limit", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/containers/foo/limits/memory"),
+						ghttp.RespondWith(500, marshalProto(garden.Error{Err: errors.New("usage exceeds limit")})),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				err := connection.SetMemoryLimits("foo", garden.MemoryLimits{LimitInBytes: 1})
+				Ω(err).Should(MatchError("usage exceeds limit"))
+			})
+		})
+	})
+
 	Describe("NetIn", func() {
 		BeforeEach(func() {
 			server.AppendHandlers(
@@ -448,6 +582,69 @@ var _ = Describe("Connection", func() {
 		})
 	})
 
+	Describe("Listing containers matching a selector", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/containers", "garden.selector=foo%3Dbar%2Cbaz+in+%281%2C2%29"),
+					ghttp.RespondWith(200, marshalProto(&struct {
+						Handles []string `json:"handles"`
+					}{
+						[]string{"container1"},
+					}))))
+		})
+
+		It("should return the list of containers", func() {
+			handles, err := connection.ListMatching(garden.Selector{
+				{Key: "foo", Operator: garden.SelectorEquals, Values: []string{"bar"}},
+				{Key: "baz", Operator: garden.SelectorIn, Values: []string{"1", "2"}},
+			})
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(handles).Should(Equal([]string{"container1"}))
+		})
+	})
+
+	Describe("Listing a page of containers", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/containers"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Ω(r.URL.Query()).Should(Equal(url.Values{
+							"foo":             []string{"bar"},
+							"garden.limit":    []string{"2"},
+							"garden.continue": []string{"some-token"},
+							"garden.fields":   []string{"state,properties"},
+						}))
+					},
+					ghttp.RespondWith(200, `{
+						"Handles": ["container1", "container2"],
+						"Infos": {"container1": {"State": "active"}, "container2": {"State": "stopped"}},
+						"Continue": "another-token"
+					}`)))
+		})
+
+		It("should return the page of containers", func() {
+			page, err := connection.ListPage(garden.ListOptions{
+				Properties: garden.Properties{"foo": "bar"},
+				Limit:      2,
+				Continue:   "some-token",
+				Fields:     []garden.ContainerInfoField{garden.ContainerInfoFieldState, garden.ContainerInfoFieldProperties},
+			})
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(page).Should(Equal(garden.ListPage{
+				Handles: []string{"container1", "container2"},
+				Infos: map[string]garden.ContainerInfo{
+					"container1": {State: "active"},
+					"container2": {State: "stopped"},
+				},
+				Continue: "another-token",
+			}))
+		})
+	})
+
 	Describe("Getting container properties", func() {
 		handle := "container-handle"
 		var status int
@@ -524,6 +721,65 @@ var _ = Describe("Connection", func() {
 
 	})
 
+	Describe("Getting versioned container properties", func() {
+		handle := "container-handle"
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", fmt.Sprintf("/containers/%s/properties", handle), "versioned=true"),
+					ghttp.RespondWith(200, `{"Properties": {"foo": "bar"}, "Version": "some-version"}`)))
+		})
+
+		It("returns the properties with their version", func() {
+			versioned, err := connection.VersionedProperties(handle)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(versioned).Should(Equal(garden.VersionedProperties{
+				Properties: garden.Properties{"foo": "bar"},
+				Version:    "some-version",
+			}))
+		})
+	})
+
+	Describe("Updating container properties", func() {
+		handle := "container-handle"
+
+		Context("when the update succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", fmt.Sprintf("/containers/%s/properties", handle)),
+						verifyRequestBody(map[string]interface{}{
+							"set":              map[string]interface{}{"foo": "bar"},
+							"remove":           []interface{}{"baz"},
+							"expected_version": "some-version",
+						}, make(map[string]interface{})),
+						ghttp.RespondWith(200, `{"Version": "new-version"}`)))
+			})
+
+			It("returns the new version", func() {
+				version, err := connection.UpdateProperties(handle, garden.Properties{"foo": "bar"}, []string{"baz"}, "some-version")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(version).Should(Equal("new-version"))
+			})
+		})
+
+		Context("when the version is stale", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", fmt.Sprintf("/containers/%s/properties", handle)),
+						ghttp.RespondWith(409, `{"Type": "ConflictError", "Message": "stale"}`)))
+			})
+
+			It("returns a ConflictError", func() {
+				_, err := connection.UpdateProperties(handle, garden.Properties{"foo": "bar"}, nil, "some-version")
+				Ω(err).Should(Equal(garden.ConflictError{Message: "stale"}))
+			})
+		})
+	})
+
 	Describe("Getting container metrics", func() {
 		handle := "container-handle"
 		metrics := garden.Metrics{
@@ -642,6 +898,30 @@ var _ = Describe("Connection", func() {
 		})
 	})
 
+	Describe("Listing pending reaps", func() {
+		It("returns the pending reaps", func() {
+			reapAt := time.Unix(1234, 0).UTC()
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/reaps"),
+					ghttp.RespondWithJSONEncoded(200, []garden.PendingReap{
+						{Handle: "idle", GraceTime: time.Minute, ReapAt: reapAt},
+						{Handle: "busy", GraceTime: time.Minute, Pauses: 2},
+					}),
+				),
+			)
+
+			reaps, err := connection.PendingReaps()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(reaps).Should(Equal([]garden.PendingReap{
+				{Handle: "idle", GraceTime: time.Minute, ReapAt: reapAt},
+				{Handle: "busy", GraceTime: time.Minute, Pauses: 2},
+			}))
+		})
+	})
+
 	Describe("Getting container info", func() {
 		var infoResponse garden.ContainerInfo
 
@@ -679,6 +959,118 @@ var _ = Describe("Connection", func() {
 		})
 	})
 
+	Describe("Listing processes", func() {
+		processes := []garden.ProcessInfo{
+			{
+				ID:        "process-1",
+				Spec:      garden.ProcessSpec{Path: "sleep", Args: []string{"100"}},
+				StartedAt: time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+				State:     "running",
+				TTY:       true,
+
+				AttachedClients: 2,
+			},
+		}
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/containers/some-handle/processes"),
+					ghttp.RespondWith(200, marshalProto(processes))))
+		})
+
+		It("should return the container's processes", func() {
+			Ω(connection.Processes("some-handle")).Should(Equal(processes))
+		})
+	})
+
+	Describe("Getting a process", func() {
+		process := garden.ProcessInfo{
+			ID:         "process-1",
+			Spec:       garden.ProcessSpec{Path: "false"},
+			StartedAt:  time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+			State:      "exited",
+			ExitStatus: 1,
+		}
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/containers/some-handle/processes/process-1/info"),
+					ghttp.RespondWith(200, marshalProto(process))))
+		})
+
+		It("should return the process", func() {
+			Ω(connection.Process("some-handle", "process-1")).Should(Equal(process))
+		})
+	})
+
+	Describe("Getting a process's status", func() {
+		status := garden.ProcessStatus{
+			ID:         "process-1",
+			State:      "exited",
+			ExitStatus: 42,
+			ExitedAt:   time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+			Stdout:     "hello",
+			Stderr:     "oops",
+		}
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/containers/some-handle/processes/process-1/status"),
+					ghttp.RespondWith(200, marshalProto(status))))
+		})
+
+		It("should return the status", func() {
+			Ω(connection.ProcessStatus("some-handle", "process-1")).Should(Equal(status))
+		})
+	})
+
+	Describe("BulkDestroy", func() {
+		handles := []string{"handle1", "handle2"}
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/containers/bulk_destroy", "handles=handle1%2Chandle2"),
+					ghttp.RespondWith(200, `{
+						"handle1": {},
+						"handle2": {"Err": {"Type": "ContainerNotFoundError", "Message": "unknown handle: handle2", "Handle": "handle2"}}
+					}`)))
+		})
+
+		It("returns the outcome for each container", func() {
+			results, err := connection.BulkDestroy(handles)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(results).Should(Equal(map[string]garden.ContainerResultEntry{
+				"handle1": {},
+				"handle2": {Err: &garden.Error{Err: garden.ContainerNotFoundError{Handle: "handle2"}}},
+			}))
+		})
+	})
+
+	Describe("BulkStop", func() {
+		handles := []string{"handle1", "handle2"}
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/containers/bulk_stop", "handles=handle1%2Chandle2"),
+					ghttp.VerifyJSON(`{"kill":true}`),
+					ghttp.RespondWith(200, `{"handle1": {}, "handle2": {}}`)))
+		})
+
+		It("returns the outcome for each container", func() {
+			results, err := connection.BulkStop(handles, true)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(results).Should(Equal(map[string]garden.ContainerResultEntry{
+				"handle1": {},
+				"handle2": {},
+			}))
+		})
+	})
+
 	Describe("BulkInfo", func() {
 
 		expectedBulkInfo := map[string]garden.ContainerInfoEntry{
@@ -841,6 +1233,62 @@ var _ = Describe("Connection", func() {
 		})
 	})
 
+	Describe("Events", func() {
+		Context("when the server streams events", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/events", "handles=a%2Cb&kinds=container-created%2Ccontainer-destroyed"),
+						func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Content-Type", "text/event-stream")
+
+							transport.WriteEvent(w, garden.Event{Kind: garden.EventContainerCreated, Handle: "a"})
+							transport.WriteEvent(w, garden.Event{Kind: garden.EventContainerDestroyed, Handle: "b"})
+						},
+					),
+				)
+			})
+
+			It("returns a stream of the events", func() {
+				stream, err := connection.Events(garden.EventFilter{
+					Handles: []string{"a", "b"},
+					Kinds:   []garden.EventKind{garden.EventContainerCreated, garden.EventContainerDestroyed},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				defer stream.Close()
+
+				event, err := stream.Next()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(event.Kind).Should(Equal(garden.EventContainerCreated))
+				Ω(event.Handle).Should(Equal("a"))
+
+				event, err = stream.Next()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(event.Kind).Should(Equal(garden.EventContainerDestroyed))
+				Ω(event.Handle).Should(Equal("b"))
+
+				_, err = stream.Next()
+				Ω(err).Should(Equal(io.EOF))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/events"),
+						ghttp.RespondWith(403, marshalProto(&garden.Error{Err: garden.NewForbiddenError("nope")})),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, err := connection.Events(garden.EventFilter{})
+				Ω(err).Should(Equal(garden.NewForbiddenError("nope")))
+			})
+		})
+	})
+
 	Describe("Streaming in", func() {
 		Context("when streaming in succeeds", func() {
 			BeforeEach(func() {
@@ -1210,6 +1658,123 @@ var _ = Describe("Connection", func() {
 			})
 		})
 
+		Context("when running detached", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/containers/foo-handle/processes", "detached=true"),
+						func(w http.ResponseWriter, r *http.Request) {
+							w.WriteHeader(http.StatusOK)
+
+							conn, _, err := w.(http.Hijacker).Hijack()
+							Ω(err).ShouldNot(HaveOccurred())
+
+							defer conn.Close()
+
+							// no stream ID, as for a process that has already exited
+							transport.WriteMessage(conn, map[string]interface{}{
+								"process_id": "process-handle",
+							})
+
+							transport.WriteMessage(conn, map[string]interface{}{
+								"process_id":  "process-handle",
+								"exit_status": 42,
+							})
+						},
+					),
+				)
+			})
+
+			It("asks the server to keep the process's status", func() {
+				process, err := connection.RunDetached("foo-handle", garden.ProcessSpec{}, garden.ProcessIO{
+					Stdout: gbytes.NewBuffer(),
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(process.Wait()).Should(Equal(42))
+			})
+		})
+
+		Context("when the process is sent a POSIX signal", func() {
+			var allSignals bool
+
+			BeforeEach(func() {
+				allSignals = true
+			})
+
+			JustBeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/containers/foo-handle/processes"),
+						func(w http.ResponseWriter, r *http.Request) {
+							w.WriteHeader(http.StatusOK)
+
+							conn, br, err := w.(http.Hijacker).Hijack()
+							Ω(err).ShouldNot(HaveOccurred())
+
+							defer conn.Close()
+
+							decoder := json.NewDecoder(br)
+
+							transport.WriteMessage(conn, &transport.ProcessPayload{
+								ProcessID:  "process-handle",
+								StreamID:   "123",
+								AllSignals: allSignals,
+							})
+
+							if allSignals {
+								var payload map[string]interface{}
+								err = decoder.Decode(&payload)
+								Ω(err).ShouldNot(HaveOccurred())
+
+								Ω(payload).Should(Equal(map[string]interface{}{
+									"process_id": "process-handle",
+									"signal":     float64(garden.SignalHangup),
+								}))
+							}
+
+							transport.WriteMessage(conn, map[string]interface{}{
+								"process_id":  "process-handle",
+								"exit_status": 3,
+							})
+						},
+					),
+					emptyStdoutStream("foo-handle", "process-handle", 123),
+					emptyStderrStream("foo-handle", "process-handle", 123),
+				)
+			})
+
+			It("sends the appropriate protocol message", func() {
+				process, err := connection.Run("foo-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = process.Signal(garden.SignalHangup)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				status, err := process.Wait()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(status).Should(Equal(3))
+			})
+
+			Context("when the server only supports terminating and killing", func() {
+				BeforeEach(func() {
+					allSignals = false
+				})
+
+				It("returns an error without sending the signal", func() {
+					process, err := connection.Run("foo-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					err = process.Signal(garden.SignalHangup)
+					Ω(err).Should(Equal(ErrUnsupportedSignal))
+
+					status, err := process.Wait()
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(status).Should(Equal(3))
+				})
+			})
+		})
+
 		Context("when the process's window is resized", func() {
 			var spec garden.ProcessSpec
 			BeforeEach(func() {
@@ -1683,6 +2248,95 @@ var _ = Describe("Connection", func() {
 				})
 			})
 		})
+
+		Describe("output offsets", func() {
+			var expectedStdoutQuery, expectedStderrQuery string
+
+			BeforeEach(func() {
+				expectedStdoutQuery = "offset=3"
+				expectedStderrQuery = "offset=5"
+			})
+
+			JustBeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/containers/foo-handle/processes/process-handle"),
+						func(w http.ResponseWriter, r *http.Request) {
+							w.WriteHeader(http.StatusOK)
+
+							conn, _, err := w.(http.Hijacker).Hijack()
+							Ω(err).ShouldNot(HaveOccurred())
+
+							defer conn.Close()
+
+							transport.WriteMessage(conn, map[string]interface{}{
+								"process_id":    "process-handle",
+								"stream_id":     "123",
+								"stdout_offset": 3,
+								"stderr_offset": 5,
+							})
+
+							transport.WriteMessage(conn, map[string]interface{}{
+								"process_id":  "process-handle",
+								"exit_status": 0,
+							})
+						},
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/containers/foo-handle/processes/process-handle/attaches/123/stdout", expectedStdoutQuery),
+						stdoutStream("foo-handle", "process-handle", 123, func(conn net.Conn) {
+							conn.Write([]byte("stdout data"))
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/containers/foo-handle/processes/process-handle/attaches/123/stderr", expectedStderrQuery),
+						stderrStream("foo-handle", "process-handle", 123, func(conn net.Conn) {
+							conn.Write([]byte("stderr data"))
+						}),
+					),
+				)
+			})
+
+			It("streams from the offsets the server gives", func() {
+				process, err := connection.Attach("foo-handle", "process-handle", garden.ProcessIO{
+					Stdout: gbytes.NewBuffer(),
+					Stderr: gbytes.NewBuffer(),
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(process.Wait()).Should(Equal(0))
+
+				Ω(process.(OutputOffsetReporter).OutputOffsets()).Should(Equal(OutputOffsets{
+					Stdout: 3 + uint64(len("stdout data")),
+					Stderr: 5 + uint64(len("stderr data")),
+				}))
+			})
+
+			Context("when attaching from offsets", func() {
+				BeforeEach(func() {
+					expectedStdoutQuery = "offset=42"
+					expectedStderrQuery = "offset=43"
+				})
+
+				It("streams from them instead", func() {
+					process, err := connection.AttachFrom("foo-handle", "process-handle", OutputOffsets{
+						Stdout: 42,
+						Stderr: 43,
+					}, garden.ProcessIO{
+						Stdout: gbytes.NewBuffer(),
+						Stderr: gbytes.NewBuffer(),
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(process.Wait()).Should(Equal(0))
+
+					Ω(process.(OutputOffsetReporter).OutputOffsets()).Should(Equal(OutputOffsets{
+						Stdout: 42 + uint64(len("stdout data")),
+						Stderr: 43 + uint64(len("stderr data")),
+					}))
+				})
+			})
+		})
 	})
 })
 
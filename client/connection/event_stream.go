@@ -0,0 +1,29 @@
+package connection
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/transport"
+)
+
+type eventStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+}
+
+func newEventStream(body io.ReadCloser) *eventStream {
+	return &eventStream{
+		body:   body,
+		reader: bufio.NewReader(body),
+	}
+}
+
+func (s *eventStream) Next() (garden.Event, error) {
+	return transport.ReadEvent(s.reader)
+}
+
+func (s *eventStream) Close() error {
+	return s.body.Close()
+}
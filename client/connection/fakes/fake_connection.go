@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"io"
 	"sync"
 	"time"
@@ -42,6 +43,24 @@ type FakeConnection struct {
 		result1 []string
 		result2 error
 	}
+	ListMatchingStub        func(selector garden.Selector) ([]string, error)
+	listMatchingMutex       sync.RWMutex
+	listMatchingArgsForCall []struct {
+		selector garden.Selector
+	}
+	listMatchingReturns struct {
+		result1 []string
+		result2 error
+	}
+	ListPageStub        func(options garden.ListOptions) (garden.ListPage, error)
+	listPageMutex       sync.RWMutex
+	listPageArgsForCall []struct {
+		options garden.ListOptions
+	}
+	listPageReturns struct {
+		result1 garden.ListPage
+		result2 error
+	}
 	DestroyStub        func(handle string) error
 	destroyMutex       sync.RWMutex
 	destroyArgsForCall []struct {
@@ -50,6 +69,22 @@ type FakeConnection struct {
 	destroyReturns struct {
 		result1 error
 	}
+	DestroyAsyncStub        func(handle string) error
+	destroyAsyncMutex       sync.RWMutex
+	destroyAsyncArgsForCall []struct {
+		handle string
+	}
+	destroyAsyncReturns struct {
+		result1 error
+	}
+	WaitForDestroyStub        func(handle string) error
+	waitForDestroyMutex       sync.RWMutex
+	waitForDestroyArgsForCall []struct {
+		handle string
+	}
+	waitForDestroyReturns struct {
+		result1 error
+	}
 	StopStub        func(handle string, kill bool) error
 	stopMutex       sync.RWMutex
 	stopArgsForCall []struct {
@@ -86,6 +121,25 @@ type FakeConnection struct {
 		result1 map[string]garden.ContainerMetricsEntry
 		result2 error
 	}
+	BulkDestroyStub        func(handles []string) (map[string]garden.ContainerResultEntry, error)
+	bulkDestroyMutex       sync.RWMutex
+	bulkDestroyArgsForCall []struct {
+		handles []string
+	}
+	bulkDestroyReturns struct {
+		result1 map[string]garden.ContainerResultEntry
+		result2 error
+	}
+	BulkStopStub        func(handles []string, kill bool) (map[string]garden.ContainerResultEntry, error)
+	bulkStopMutex       sync.RWMutex
+	bulkStopArgsForCall []struct {
+		handles []string
+		kill    bool
+	}
+	bulkStopReturns struct {
+		result1 map[string]garden.ContainerResultEntry
+		result2 error
+	}
 	StreamInStub        func(handle string, spec garden.StreamInSpec) error
 	streamInMutex       sync.RWMutex
 	streamInArgsForCall []struct {
@@ -105,36 +159,6 @@ type FakeConnection struct {
 		result1 io.ReadCloser
 		result2 error
 	}
-	LimitBandwidthStub        func(handle string, limits garden.BandwidthLimits) (garden.BandwidthLimits, error)
-	limitBandwidthMutex       sync.RWMutex
-	limitBandwidthArgsForCall []struct {
-		handle string
-		limits garden.BandwidthLimits
-	}
-	limitBandwidthReturns struct {
-		result1 garden.BandwidthLimits
-		result2 error
-	}
-	LimitCPUStub        func(handle string, limits garden.CPULimits) (garden.CPULimits, error)
-	limitCPUMutex       sync.RWMutex
-	limitCPUArgsForCall []struct {
-		handle string
-		limits garden.CPULimits
-	}
-	limitCPUReturns struct {
-		result1 garden.CPULimits
-		result2 error
-	}
-	LimitMemoryStub        func(handle string, limit garden.MemoryLimits) (garden.MemoryLimits, error)
-	limitMemoryMutex       sync.RWMutex
-	limitMemoryArgsForCall []struct {
-		handle string
-		limit  garden.MemoryLimits
-	}
-	limitMemoryReturns struct {
-		result1 garden.MemoryLimits
-		result2 error
-	}
 	CurrentBandwidthLimitsStub        func(handle string) (garden.BandwidthLimits, error)
 	currentBandwidthLimitsMutex       sync.RWMutex
 	currentBandwidthLimitsArgsForCall []struct {
@@ -171,6 +195,42 @@ type FakeConnection struct {
 		result1 garden.MemoryLimits
 		result2 error
 	}
+	SetBandwidthLimitsStub        func(handle string, limits garden.BandwidthLimits) error
+	setBandwidthLimitsMutex       sync.RWMutex
+	setBandwidthLimitsArgsForCall []struct {
+		handle string
+		limits garden.BandwidthLimits
+	}
+	setBandwidthLimitsReturns struct {
+		result1 error
+	}
+	SetCPULimitsStub        func(handle string, limits garden.CPULimits) error
+	setCPULimitsMutex       sync.RWMutex
+	setCPULimitsArgsForCall []struct {
+		handle string
+		limits garden.CPULimits
+	}
+	setCPULimitsReturns struct {
+		result1 error
+	}
+	SetDiskLimitsStub        func(handle string, limits garden.DiskLimits) error
+	setDiskLimitsMutex       sync.RWMutex
+	setDiskLimitsArgsForCall []struct {
+		handle string
+		limits garden.DiskLimits
+	}
+	setDiskLimitsReturns struct {
+		result1 error
+	}
+	SetMemoryLimitsStub        func(handle string, limits garden.MemoryLimits) error
+	setMemoryLimitsMutex       sync.RWMutex
+	setMemoryLimitsArgsForCall []struct {
+		handle string
+		limits garden.MemoryLimits
+	}
+	setMemoryLimitsReturns struct {
+		result1 error
+	}
 	RunStub        func(handle string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
 	runMutex       sync.RWMutex
 	runArgsForCall []struct {
@@ -182,6 +242,17 @@ type FakeConnection struct {
 		result1 garden.Process
 		result2 error
 	}
+	RunDetachedStub        func(handle string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
+	runDetachedMutex       sync.RWMutex
+	runDetachedArgsForCall []struct {
+		handle string
+		spec   garden.ProcessSpec
+		io     garden.ProcessIO
+	}
+	runDetachedReturns struct {
+		result1 garden.Process
+		result2 error
+	}
 	AttachStub        func(handle string, processID string, io garden.ProcessIO) (garden.Process, error)
 	attachMutex       sync.RWMutex
 	attachArgsForCall []struct {
@@ -193,6 +264,47 @@ type FakeConnection struct {
 		result1 garden.Process
 		result2 error
 	}
+	AttachFromStub        func(handle string, processID string, offsets connection.OutputOffsets, io garden.ProcessIO) (garden.Process, error)
+	attachFromMutex       sync.RWMutex
+	attachFromArgsForCall []struct {
+		handle    string
+		processID string
+		offsets   connection.OutputOffsets
+		io        garden.ProcessIO
+	}
+	attachFromReturns struct {
+		result1 garden.Process
+		result2 error
+	}
+	ProcessesStub        func(handle string) ([]garden.ProcessInfo, error)
+	processesMutex       sync.RWMutex
+	processesArgsForCall []struct {
+		handle string
+	}
+	processesReturns struct {
+		result1 []garden.ProcessInfo
+		result2 error
+	}
+	ProcessStub        func(handle string, processID string) (garden.ProcessInfo, error)
+	processMutex       sync.RWMutex
+	processArgsForCall []struct {
+		handle    string
+		processID string
+	}
+	processReturns struct {
+		result1 garden.ProcessInfo
+		result2 error
+	}
+	ProcessStatusStub        func(handle string, processID string) (garden.ProcessStatus, error)
+	processStatusMutex       sync.RWMutex
+	processStatusArgsForCall []struct {
+		handle    string
+		processID string
+	}
+	processStatusReturns struct {
+		result1 garden.ProcessStatus
+		result2 error
+	}
 	NetInStub        func(handle string, hostPort, containerPort uint32) (uint32, uint32, error)
 	netInMutex       sync.RWMutex
 	netInArgsForCall []struct {
@@ -270,6 +382,51 @@ type FakeConnection struct {
 	removePropertyReturns struct {
 		result1 error
 	}
+	VersionedPropertiesStub        func(handle string) (garden.VersionedProperties, error)
+	versionedPropertiesMutex       sync.RWMutex
+	versionedPropertiesArgsForCall []struct {
+		handle string
+	}
+	versionedPropertiesReturns struct {
+		result1 garden.VersionedProperties
+		result2 error
+	}
+	UpdatePropertiesStub        func(handle string, set garden.Properties, remove []string, expectedVersion string) (string, error)
+	updatePropertiesMutex       sync.RWMutex
+	updatePropertiesArgsForCall []struct {
+		handle          string
+		set             garden.Properties
+		remove          []string
+		expectedVersion string
+	}
+	updatePropertiesReturns struct {
+		result1 string
+		result2 error
+	}
+	EventsStub        func(filter garden.EventFilter) (garden.EventStream, error)
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		filter garden.EventFilter
+	}
+	eventsReturns struct {
+		result1 garden.EventStream
+		result2 error
+	}
+	PendingReapsStub        func() ([]garden.PendingReap, error)
+	pendingReapsMutex       sync.RWMutex
+	pendingReapsArgsForCall []struct{}
+	pendingReapsReturns     struct {
+		result1 []garden.PendingReap
+		result2 error
+	}
+	WithContextStub        func(ctx context.Context) connection.Connection
+	withContextMutex       sync.RWMutex
+	withContextArgsForCall []struct {
+		ctx context.Context
+	}
+	withContextReturns struct {
+		result1 connection.Connection
+	}
 }
 
 func (fake *FakeConnection) Ping() error {
@@ -387,6 +544,72 @@ func (fake *FakeConnection) ListReturns(result1 []string, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeConnection) ListMatching(selector garden.Selector) ([]string, error) {
+	fake.listMatchingMutex.Lock()
+	fake.listMatchingArgsForCall = append(fake.listMatchingArgsForCall, struct {
+		selector garden.Selector
+	}{selector})
+	fake.listMatchingMutex.Unlock()
+	if fake.ListMatchingStub != nil {
+		return fake.ListMatchingStub(selector)
+	} else {
+		return fake.listMatchingReturns.result1, fake.listMatchingReturns.result2
+	}
+}
+
+func (fake *FakeConnection) ListMatchingCallCount() int {
+	fake.listMatchingMutex.RLock()
+	defer fake.listMatchingMutex.RUnlock()
+	return len(fake.listMatchingArgsForCall)
+}
+
+func (fake *FakeConnection) ListMatchingArgsForCall(i int) garden.Selector {
+	fake.listMatchingMutex.RLock()
+	defer fake.listMatchingMutex.RUnlock()
+	return fake.listMatchingArgsForCall[i].selector
+}
+
+func (fake *FakeConnection) ListMatchingReturns(result1 []string, result2 error) {
+	fake.ListMatchingStub = nil
+	fake.listMatchingReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) ListPage(options garden.ListOptions) (garden.ListPage, error) {
+	fake.listPageMutex.Lock()
+	fake.listPageArgsForCall = append(fake.listPageArgsForCall, struct {
+		options garden.ListOptions
+	}{options})
+	fake.listPageMutex.Unlock()
+	if fake.ListPageStub != nil {
+		return fake.ListPageStub(options)
+	} else {
+		return fake.listPageReturns.result1, fake.listPageReturns.result2
+	}
+}
+
+func (fake *FakeConnection) ListPageCallCount() int {
+	fake.listPageMutex.RLock()
+	defer fake.listPageMutex.RUnlock()
+	return len(fake.listPageArgsForCall)
+}
+
+func (fake *FakeConnection) ListPageArgsForCall(i int) garden.ListOptions {
+	fake.listPageMutex.RLock()
+	defer fake.listPageMutex.RUnlock()
+	return fake.listPageArgsForCall[i].options
+}
+
+func (fake *FakeConnection) ListPageReturns(result1 garden.ListPage, result2 error) {
+	fake.ListPageStub = nil
+	fake.listPageReturns = struct {
+		result1 garden.ListPage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeConnection) Destroy(handle string) error {
 	fake.destroyMutex.Lock()
 	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct {
@@ -419,6 +642,70 @@ func (fake *FakeConnection) DestroyReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeConnection) DestroyAsync(handle string) error {
+	fake.destroyAsyncMutex.Lock()
+	fake.destroyAsyncArgsForCall = append(fake.destroyAsyncArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.destroyAsyncMutex.Unlock()
+	if fake.DestroyAsyncStub != nil {
+		return fake.DestroyAsyncStub(handle)
+	} else {
+		return fake.destroyAsyncReturns.result1
+	}
+}
+
+func (fake *FakeConnection) DestroyAsyncCallCount() int {
+	fake.destroyAsyncMutex.RLock()
+	defer fake.destroyAsyncMutex.RUnlock()
+	return len(fake.destroyAsyncArgsForCall)
+}
+
+func (fake *FakeConnection) DestroyAsyncArgsForCall(i int) string {
+	fake.destroyAsyncMutex.RLock()
+	defer fake.destroyAsyncMutex.RUnlock()
+	return fake.destroyAsyncArgsForCall[i].handle
+}
+
+func (fake *FakeConnection) DestroyAsyncReturns(result1 error) {
+	fake.DestroyAsyncStub = nil
+	fake.destroyAsyncReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeConnection) WaitForDestroy(handle string) error {
+	fake.waitForDestroyMutex.Lock()
+	fake.waitForDestroyArgsForCall = append(fake.waitForDestroyArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.waitForDestroyMutex.Unlock()
+	if fake.WaitForDestroyStub != nil {
+		return fake.WaitForDestroyStub(handle)
+	} else {
+		return fake.waitForDestroyReturns.result1
+	}
+}
+
+func (fake *FakeConnection) WaitForDestroyCallCount() int {
+	fake.waitForDestroyMutex.RLock()
+	defer fake.waitForDestroyMutex.RUnlock()
+	return len(fake.waitForDestroyArgsForCall)
+}
+
+func (fake *FakeConnection) WaitForDestroyArgsForCall(i int) string {
+	fake.waitForDestroyMutex.RLock()
+	defer fake.waitForDestroyMutex.RUnlock()
+	return fake.waitForDestroyArgsForCall[i].handle
+}
+
+func (fake *FakeConnection) WaitForDestroyReturns(result1 error) {
+	fake.WaitForDestroyStub = nil
+	fake.waitForDestroyReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConnection) Stop(handle string, kill bool) error {
 	fake.stopMutex.Lock()
 	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
@@ -551,6 +838,73 @@ func (fake *FakeConnection) BulkMetricsReturns(result1 map[string]garden.Contain
 	}{result1, result2}
 }
 
+func (fake *FakeConnection) BulkDestroy(handles []string) (map[string]garden.ContainerResultEntry, error) {
+	fake.bulkDestroyMutex.Lock()
+	fake.bulkDestroyArgsForCall = append(fake.bulkDestroyArgsForCall, struct {
+		handles []string
+	}{handles})
+	fake.bulkDestroyMutex.Unlock()
+	if fake.BulkDestroyStub != nil {
+		return fake.BulkDestroyStub(handles)
+	} else {
+		return fake.bulkDestroyReturns.result1, fake.bulkDestroyReturns.result2
+	}
+}
+
+func (fake *FakeConnection) BulkDestroyCallCount() int {
+	fake.bulkDestroyMutex.RLock()
+	defer fake.bulkDestroyMutex.RUnlock()
+	return len(fake.bulkDestroyArgsForCall)
+}
+
+func (fake *FakeConnection) BulkDestroyArgsForCall(i int) []string {
+	fake.bulkDestroyMutex.RLock()
+	defer fake.bulkDestroyMutex.RUnlock()
+	return fake.bulkDestroyArgsForCall[i].handles
+}
+
+func (fake *FakeConnection) BulkDestroyReturns(result1 map[string]garden.ContainerResultEntry, result2 error) {
+	fake.BulkDestroyStub = nil
+	fake.bulkDestroyReturns = struct {
+		result1 map[string]garden.ContainerResultEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) BulkStop(handles []string, kill bool) (map[string]garden.ContainerResultEntry, error) {
+	fake.bulkStopMutex.Lock()
+	fake.bulkStopArgsForCall = append(fake.bulkStopArgsForCall, struct {
+		handles []string
+		kill    bool
+	}{handles, kill})
+	fake.bulkStopMutex.Unlock()
+	if fake.BulkStopStub != nil {
+		return fake.BulkStopStub(handles, kill)
+	} else {
+		return fake.bulkStopReturns.result1, fake.bulkStopReturns.result2
+	}
+}
+
+func (fake *FakeConnection) BulkStopCallCount() int {
+	fake.bulkStopMutex.RLock()
+	defer fake.bulkStopMutex.RUnlock()
+	return len(fake.bulkStopArgsForCall)
+}
+
+func (fake *FakeConnection) BulkStopArgsForCall(i int) ([]string, bool) {
+	fake.bulkStopMutex.RLock()
+	defer fake.bulkStopMutex.RUnlock()
+	return fake.bulkStopArgsForCall[i].handles, fake.bulkStopArgsForCall[i].kill
+}
+
+func (fake *FakeConnection) BulkStopReturns(result1 map[string]garden.ContainerResultEntry, result2 error) {
+	fake.BulkStopStub = nil
+	fake.bulkStopReturns = struct {
+		result1 map[string]garden.ContainerResultEntry
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeConnection) StreamIn(handle string, spec garden.StreamInSpec) error {
 	fake.streamInMutex.Lock()
 	fake.streamInArgsForCall = append(fake.streamInArgsForCall, struct {
@@ -618,108 +972,6 @@ func (fake *FakeConnection) StreamOutReturns(result1 io.ReadCloser, result2 erro
 	}{result1, result2}
 }
 
-func (fake *FakeConnection) LimitBandwidth(handle string, limits garden.BandwidthLimits) (garden.BandwidthLimits, error) {
-	fake.limitBandwidthMutex.Lock()
-	fake.limitBandwidthArgsForCall = append(fake.limitBandwidthArgsForCall, struct {
-		handle string
-		limits garden.BandwidthLimits
-	}{handle, limits})
-	fake.limitBandwidthMutex.Unlock()
-	if fake.LimitBandwidthStub != nil {
-		return fake.LimitBandwidthStub(handle, limits)
-	} else {
-		return fake.limitBandwidthReturns.result1, fake.limitBandwidthReturns.result2
-	}
-}
-
-func (fake *FakeConnection) LimitBandwidthCallCount() int {
-	fake.limitBandwidthMutex.RLock()
-	defer fake.limitBandwidthMutex.RUnlock()
-	return len(fake.limitBandwidthArgsForCall)
-}
-
-func (fake *FakeConnection) LimitBandwidthArgsForCall(i int) (string, garden.BandwidthLimits) {
-	fake.limitBandwidthMutex.RLock()
-	defer fake.limitBandwidthMutex.RUnlock()
-	return fake.limitBandwidthArgsForCall[i].handle, fake.limitBandwidthArgsForCall[i].limits
-}
-
-func (fake *FakeConnection) LimitBandwidthReturns(result1 garden.BandwidthLimits, result2 error) {
-	fake.LimitBandwidthStub = nil
-	fake.limitBandwidthReturns = struct {
-		result1 garden.BandwidthLimits
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeConnection) LimitCPU(handle string, limits garden.CPULimits) (garden.CPULimits, error) {
-	fake.limitCPUMutex.Lock()
-	fake.limitCPUArgsForCall = append(fake.limitCPUArgsForCall, struct {
-		handle string
-		limits garden.CPULimits
-	}{handle, limits})
-	fake.limitCPUMutex.Unlock()
-	if fake.LimitCPUStub != nil {
-		return fake.LimitCPUStub(handle, limits)
-	} else {
-		return fake.limitCPUReturns.result1, fake.limitCPUReturns.result2
-	}
-}
-
-func (fake *FakeConnection) LimitCPUCallCount() int {
-	fake.limitCPUMutex.RLock()
-	defer fake.limitCPUMutex.RUnlock()
-	return len(fake.limitCPUArgsForCall)
-}
-
-func (fake *FakeConnection) LimitCPUArgsForCall(i int) (string, garden.CPULimits) {
-	fake.limitCPUMutex.RLock()
-	defer fake.limitCPUMutex.RUnlock()
-	return fake.limitCPUArgsForCall[i].handle, fake.limitCPUArgsForCall[i].limits
-}
-
-func (fake *FakeConnection) LimitCPUReturns(result1 garden.CPULimits, result2 error) {
-	fake.LimitCPUStub = nil
-	fake.limitCPUReturns = struct {
-		result1 garden.CPULimits
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeConnection) LimitMemory(handle string, limit garden.MemoryLimits) (garden.MemoryLimits, error) {
-	fake.limitMemoryMutex.Lock()
-	fake.limitMemoryArgsForCall = append(fake.limitMemoryArgsForCall, struct {
-		handle string
-		limit  garden.MemoryLimits
-	}{handle, limit})
-	fake.limitMemoryMutex.Unlock()
-	if fake.LimitMemoryStub != nil {
-		return fake.LimitMemoryStub(handle, limit)
-	} else {
-		return fake.limitMemoryReturns.result1, fake.limitMemoryReturns.result2
-	}
-}
-
-func (fake *FakeConnection) LimitMemoryCallCount() int {
-	fake.limitMemoryMutex.RLock()
-	defer fake.limitMemoryMutex.RUnlock()
-	return len(fake.limitMemoryArgsForCall)
-}
-
-func (fake *FakeConnection) LimitMemoryArgsForCall(i int) (string, garden.MemoryLimits) {
-	fake.limitMemoryMutex.RLock()
-	defer fake.limitMemoryMutex.RUnlock()
-	return fake.limitMemoryArgsForCall[i].handle, fake.limitMemoryArgsForCall[i].limit
-}
-
-func (fake *FakeConnection) LimitMemoryReturns(result1 garden.MemoryLimits, result2 error) {
-	fake.LimitMemoryStub = nil
-	fake.limitMemoryReturns = struct {
-		result1 garden.MemoryLimits
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeConnection) CurrentBandwidthLimits(handle string) (garden.BandwidthLimits, error) {
 	fake.currentBandwidthLimitsMutex.Lock()
 	fake.currentBandwidthLimitsArgsForCall = append(fake.currentBandwidthLimitsArgsForCall, struct {
@@ -852,6 +1104,138 @@ func (fake *FakeConnection) CurrentMemoryLimitsReturns(result1 garden.MemoryLimi
 	}{result1, result2}
 }
 
+func (fake *FakeConnection) SetBandwidthLimits(handle string, limits garden.BandwidthLimits) error {
+	fake.setBandwidthLimitsMutex.Lock()
+	fake.setBandwidthLimitsArgsForCall = append(fake.setBandwidthLimitsArgsForCall, struct {
+		handle string
+		limits garden.BandwidthLimits
+	}{handle, limits})
+	fake.setBandwidthLimitsMutex.Unlock()
+	if fake.SetBandwidthLimitsStub != nil {
+		return fake.SetBandwidthLimitsStub(handle, limits)
+	} else {
+		return fake.setBandwidthLimitsReturns.result1
+	}
+}
+
+func (fake *FakeConnection) SetBandwidthLimitsCallCount() int {
+	fake.setBandwidthLimitsMutex.RLock()
+	defer fake.setBandwidthLimitsMutex.RUnlock()
+	return len(fake.setBandwidthLimitsArgsForCall)
+}
+
+func (fake *FakeConnection) SetBandwidthLimitsArgsForCall(i int) (string, garden.BandwidthLimits) {
+	fake.setBandwidthLimitsMutex.RLock()
+	defer fake.setBandwidthLimitsMutex.RUnlock()
+	return fake.setBandwidthLimitsArgsForCall[i].handle, fake.setBandwidthLimitsArgsForCall[i].limits
+}
+
+func (fake *FakeConnection) SetBandwidthLimitsReturns(result1 error) {
+	fake.SetBandwidthLimitsStub = nil
+	fake.setBandwidthLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeConnection) SetCPULimits(handle string, limits garden.CPULimits) error {
+	fake.setCPULimitsMutex.Lock()
+	fake.setCPULimitsArgsForCall = append(fake.setCPULimitsArgsForCall, struct {
+		handle string
+		limits garden.CPULimits
+	}{handle, limits})
+	fake.setCPULimitsMutex.Unlock()
+	if fake.SetCPULimitsStub != nil {
+		return fake.SetCPULimitsStub(handle, limits)
+	} else {
+		return fake.setCPULimitsReturns.result1
+	}
+}
+
+func (fake *FakeConnection) SetCPULimitsCallCount() int {
+	fake.setCPULimitsMutex.RLock()
+	defer fake.setCPULimitsMutex.RUnlock()
+	return len(fake.setCPULimitsArgsForCall)
+}
+
+func (fake *FakeConnection) SetCPULimitsArgsForCall(i int) (string, garden.CPULimits) {
+	fake.setCPULimitsMutex.RLock()
+	defer fake.setCPULimitsMutex.RUnlock()
+	return fake.setCPULimitsArgsForCall[i].handle, fake.setCPULimitsArgsForCall[i].limits
+}
+
+func (fake *FakeConnection) SetCPULimitsReturns(result1 error) {
+	fake.SetCPULimitsStub = nil
+	fake.setCPULimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeConnection) SetDiskLimits(handle string, limits garden.DiskLimits) error {
+	fake.setDiskLimitsMutex.Lock()
+	fake.setDiskLimitsArgsForCall = append(fake.setDiskLimitsArgsForCall, struct {
+		handle string
+		limits garden.DiskLimits
+	}{handle, limits})
+	fake.setDiskLimitsMutex.Unlock()
+	if fake.SetDiskLimitsStub != nil {
+		return fake.SetDiskLimitsStub(handle, limits)
+	} else {
+		return fake.setDiskLimitsReturns.result1
+	}
+}
+
+func (fake *FakeConnection) SetDiskLimitsCallCount() int {
+	fake.setDiskLimitsMutex.RLock()
+	defer fake.setDiskLimitsMutex.RUnlock()
+	return len(fake.setDiskLimitsArgsForCall)
+}
+
+func (fake *FakeConnection) SetDiskLimitsArgsForCall(i int) (string, garden.DiskLimits) {
+	fake.setDiskLimitsMutex.RLock()
+	defer fake.setDiskLimitsMutex.RUnlock()
+	return fake.setDiskLimitsArgsForCall[i].handle, fake.setDiskLimitsArgsForCall[i].limits
+}
+
+func (fake *FakeConnection) SetDiskLimitsReturns(result1 error) {
+	fake.SetDiskLimitsStub = nil
+	fake.setDiskLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeConnection) SetMemoryLimits(handle string, limits garden.MemoryLimits) error {
+	fake.setMemoryLimitsMutex.Lock()
+	fake.setMemoryLimitsArgsForCall = append(fake.setMemoryLimitsArgsForCall, struct {
+		handle string
+		limits garden.MemoryLimits
+	}{handle, limits})
+	fake.setMemoryLimitsMutex.Unlock()
+	if fake.SetMemoryLimitsStub != nil {
+		return fake.SetMemoryLimitsStub(handle, limits)
+	} else {
+		return fake.setMemoryLimitsReturns.result1
+	}
+}
+
+func (fake *FakeConnection) SetMemoryLimitsCallCount() int {
+	fake.setMemoryLimitsMutex.RLock()
+	defer fake.setMemoryLimitsMutex.RUnlock()
+	return len(fake.setMemoryLimitsArgsForCall)
+}
+
+func (fake *FakeConnection) SetMemoryLimitsArgsForCall(i int) (string, garden.MemoryLimits) {
+	fake.setMemoryLimitsMutex.RLock()
+	defer fake.setMemoryLimitsMutex.RUnlock()
+	return fake.setMemoryLimitsArgsForCall[i].handle, fake.setMemoryLimitsArgsForCall[i].limits
+}
+
+func (fake *FakeConnection) SetMemoryLimitsReturns(result1 error) {
+	fake.SetMemoryLimitsStub = nil
+	fake.setMemoryLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConnection) Run(handle string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
 	fake.runMutex.Lock()
 	fake.runArgsForCall = append(fake.runArgsForCall, struct {
@@ -887,6 +1271,41 @@ func (fake *FakeConnection) RunReturns(result1 garden.Process, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeConnection) RunDetached(handle string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	fake.runDetachedMutex.Lock()
+	fake.runDetachedArgsForCall = append(fake.runDetachedArgsForCall, struct {
+		handle string
+		spec   garden.ProcessSpec
+		io     garden.ProcessIO
+	}{handle, spec, io})
+	fake.runDetachedMutex.Unlock()
+	if fake.RunDetachedStub != nil {
+		return fake.RunDetachedStub(handle, spec, io)
+	} else {
+		return fake.runDetachedReturns.result1, fake.runDetachedReturns.result2
+	}
+}
+
+func (fake *FakeConnection) RunDetachedCallCount() int {
+	fake.runDetachedMutex.RLock()
+	defer fake.runDetachedMutex.RUnlock()
+	return len(fake.runDetachedArgsForCall)
+}
+
+func (fake *FakeConnection) RunDetachedArgsForCall(i int) (string, garden.ProcessSpec, garden.ProcessIO) {
+	fake.runDetachedMutex.RLock()
+	defer fake.runDetachedMutex.RUnlock()
+	return fake.runDetachedArgsForCall[i].handle, fake.runDetachedArgsForCall[i].spec, fake.runDetachedArgsForCall[i].io
+}
+
+func (fake *FakeConnection) RunDetachedReturns(result1 garden.Process, result2 error) {
+	fake.RunDetachedStub = nil
+	fake.runDetachedReturns = struct {
+		result1 garden.Process
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeConnection) Attach(handle string, processID string, io garden.ProcessIO) (garden.Process, error) {
 	fake.attachMutex.Lock()
 	fake.attachArgsForCall = append(fake.attachArgsForCall, struct {
@@ -922,6 +1341,143 @@ func (fake *FakeConnection) AttachReturns(result1 garden.Process, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeConnection) AttachFrom(handle string, processID string, offsets connection.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
+	fake.attachFromMutex.Lock()
+	fake.attachFromArgsForCall = append(fake.attachFromArgsForCall, struct {
+		handle    string
+		processID string
+		offsets   connection.OutputOffsets
+		io        garden.ProcessIO
+	}{handle, processID, offsets, io})
+	fake.attachFromMutex.Unlock()
+	if fake.AttachFromStub != nil {
+		return fake.AttachFromStub(handle, processID, offsets, io)
+	} else {
+		return fake.attachFromReturns.result1, fake.attachFromReturns.result2
+	}
+}
+
+func (fake *FakeConnection) AttachFromCallCount() int {
+	fake.attachFromMutex.RLock()
+	defer fake.attachFromMutex.RUnlock()
+	return len(fake.attachFromArgsForCall)
+}
+
+func (fake *FakeConnection) AttachFromArgsForCall(i int) (string, string, connection.OutputOffsets, garden.ProcessIO) {
+	fake.attachFromMutex.RLock()
+	defer fake.attachFromMutex.RUnlock()
+	return fake.attachFromArgsForCall[i].handle, fake.attachFromArgsForCall[i].processID, fake.attachFromArgsForCall[i].offsets, fake.attachFromArgsForCall[i].io
+}
+
+func (fake *FakeConnection) AttachFromReturns(result1 garden.Process, result2 error) {
+	fake.AttachFromStub = nil
+	fake.attachFromReturns = struct {
+		result1 garden.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) Processes(handle string) ([]garden.ProcessInfo, error) {
+	fake.processesMutex.Lock()
+	fake.processesArgsForCall = append(fake.processesArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.processesMutex.Unlock()
+	if fake.ProcessesStub != nil {
+		return fake.ProcessesStub(handle)
+	} else {
+		return fake.processesReturns.result1, fake.processesReturns.result2
+	}
+}
+
+func (fake *FakeConnection) ProcessesCallCount() int {
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	return len(fake.processesArgsForCall)
+}
+
+func (fake *FakeConnection) ProcessesArgsForCall(i int) string {
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	return fake.processesArgsForCall[i].handle
+}
+
+func (fake *FakeConnection) ProcessesReturns(result1 []garden.ProcessInfo, result2 error) {
+	fake.ProcessesStub = nil
+	fake.processesReturns = struct {
+		result1 []garden.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) Process(handle string, processID string) (garden.ProcessInfo, error) {
+	fake.processMutex.Lock()
+	fake.processArgsForCall = append(fake.processArgsForCall, struct {
+		handle    string
+		processID string
+	}{handle, processID})
+	fake.processMutex.Unlock()
+	if fake.ProcessStub != nil {
+		return fake.ProcessStub(handle, processID)
+	} else {
+		return fake.processReturns.result1, fake.processReturns.result2
+	}
+}
+
+func (fake *FakeConnection) ProcessCallCount() int {
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
+	return len(fake.processArgsForCall)
+}
+
+func (fake *FakeConnection) ProcessArgsForCall(i int) (string, string) {
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
+	return fake.processArgsForCall[i].handle, fake.processArgsForCall[i].processID
+}
+
+func (fake *FakeConnection) ProcessReturns(result1 garden.ProcessInfo, result2 error) {
+	fake.ProcessStub = nil
+	fake.processReturns = struct {
+		result1 garden.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) ProcessStatus(handle string, processID string) (garden.ProcessStatus, error) {
+	fake.processStatusMutex.Lock()
+	fake.processStatusArgsForCall = append(fake.processStatusArgsForCall, struct {
+		handle    string
+		processID string
+	}{handle, processID})
+	fake.processStatusMutex.Unlock()
+	if fake.ProcessStatusStub != nil {
+		return fake.ProcessStatusStub(handle, processID)
+	} else {
+		return fake.processStatusReturns.result1, fake.processStatusReturns.result2
+	}
+}
+
+func (fake *FakeConnection) ProcessStatusCallCount() int {
+	fake.processStatusMutex.RLock()
+	defer fake.processStatusMutex.RUnlock()
+	return len(fake.processStatusArgsForCall)
+}
+
+func (fake *FakeConnection) ProcessStatusArgsForCall(i int) (string, string) {
+	fake.processStatusMutex.RLock()
+	defer fake.processStatusMutex.RUnlock()
+	return fake.processStatusArgsForCall[i].handle, fake.processStatusArgsForCall[i].processID
+}
+
+func (fake *FakeConnection) ProcessStatusReturns(result1 garden.ProcessStatus, result2 error) {
+	fake.ProcessStatusStub = nil
+	fake.processStatusReturns = struct {
+		result1 garden.ProcessStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeConnection) NetIn(handle string, hostPort uint32, containerPort uint32) (uint32, uint32, error) {
 	fake.netInMutex.Lock()
 	fake.netInArgsForCall = append(fake.netInArgsForCall, struct {
@@ -1191,4 +1747,163 @@ func (fake *FakeConnection) RemovePropertyReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeConnection) VersionedProperties(handle string) (garden.VersionedProperties, error) {
+	fake.versionedPropertiesMutex.Lock()
+	fake.versionedPropertiesArgsForCall = append(fake.versionedPropertiesArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.versionedPropertiesMutex.Unlock()
+	if fake.VersionedPropertiesStub != nil {
+		return fake.VersionedPropertiesStub(handle)
+	} else {
+		return fake.versionedPropertiesReturns.result1, fake.versionedPropertiesReturns.result2
+	}
+}
+
+func (fake *FakeConnection) VersionedPropertiesCallCount() int {
+	fake.versionedPropertiesMutex.RLock()
+	defer fake.versionedPropertiesMutex.RUnlock()
+	return len(fake.versionedPropertiesArgsForCall)
+}
+
+func (fake *FakeConnection) VersionedPropertiesArgsForCall(i int) string {
+	fake.versionedPropertiesMutex.RLock()
+	defer fake.versionedPropertiesMutex.RUnlock()
+	return fake.versionedPropertiesArgsForCall[i].handle
+}
+
+func (fake *FakeConnection) VersionedPropertiesReturns(result1 garden.VersionedProperties, result2 error) {
+	fake.VersionedPropertiesStub = nil
+	fake.versionedPropertiesReturns = struct {
+		result1 garden.VersionedProperties
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) UpdateProperties(handle string, set garden.Properties, remove []string, expectedVersion string) (string, error) {
+	fake.updatePropertiesMutex.Lock()
+	fake.updatePropertiesArgsForCall = append(fake.updatePropertiesArgsForCall, struct {
+		handle          string
+		set             garden.Properties
+		remove          []string
+		expectedVersion string
+	}{handle, set, remove, expectedVersion})
+	fake.updatePropertiesMutex.Unlock()
+	if fake.UpdatePropertiesStub != nil {
+		return fake.UpdatePropertiesStub(handle, set, remove, expectedVersion)
+	} else {
+		return fake.updatePropertiesReturns.result1, fake.updatePropertiesReturns.result2
+	}
+}
+
+func (fake *FakeConnection) UpdatePropertiesCallCount() int {
+	fake.updatePropertiesMutex.RLock()
+	defer fake.updatePropertiesMutex.RUnlock()
+	return len(fake.updatePropertiesArgsForCall)
+}
+
+func (fake *FakeConnection) UpdatePropertiesArgsForCall(i int) (string, garden.Properties, []string, string) {
+	fake.updatePropertiesMutex.RLock()
+	defer fake.updatePropertiesMutex.RUnlock()
+	return fake.updatePropertiesArgsForCall[i].handle, fake.updatePropertiesArgsForCall[i].set, fake.updatePropertiesArgsForCall[i].remove, fake.updatePropertiesArgsForCall[i].expectedVersion
+}
+
+func (fake *FakeConnection) UpdatePropertiesReturns(result1 string, result2 error) {
+	fake.UpdatePropertiesStub = nil
+	fake.updatePropertiesReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) Events(filter garden.EventFilter) (garden.EventStream, error) {
+	fake.eventsMutex.Lock()
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		filter garden.EventFilter
+	}{filter})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(filter)
+	} else {
+		return fake.eventsReturns.result1, fake.eventsReturns.result2
+	}
+}
+
+func (fake *FakeConnection) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeConnection) EventsArgsForCall(i int) garden.EventFilter {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.eventsArgsForCall[i].filter
+}
+
+func (fake *FakeConnection) EventsReturns(result1 garden.EventStream, result2 error) {
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 garden.EventStream
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) PendingReaps() ([]garden.PendingReap, error) {
+	fake.pendingReapsMutex.Lock()
+	fake.pendingReapsArgsForCall = append(fake.pendingReapsArgsForCall, struct{}{})
+	fake.pendingReapsMutex.Unlock()
+	if fake.PendingReapsStub != nil {
+		return fake.PendingReapsStub()
+	} else {
+		return fake.pendingReapsReturns.result1, fake.pendingReapsReturns.result2
+	}
+}
+
+func (fake *FakeConnection) PendingReapsCallCount() int {
+	fake.pendingReapsMutex.RLock()
+	defer fake.pendingReapsMutex.RUnlock()
+	return len(fake.pendingReapsArgsForCall)
+}
+
+func (fake *FakeConnection) PendingReapsReturns(result1 []garden.PendingReap, result2 error) {
+	fake.PendingReapsStub = nil
+	fake.pendingReapsReturns = struct {
+		result1 []garden.PendingReap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConnection) WithContext(ctx context.Context) connection.Connection {
+	fake.withContextMutex.Lock()
+	fake.withContextArgsForCall = append(fake.withContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.withContextMutex.Unlock()
+	if fake.WithContextStub != nil {
+		return fake.WithContextStub(ctx)
+	} else {
+		return fake.withContextReturns.result1
+	}
+}
+
+func (fake *FakeConnection) WithContextCallCount() int {
+	fake.withContextMutex.RLock()
+	defer fake.withContextMutex.RUnlock()
+	return len(fake.withContextArgsForCall)
+}
+
+func (fake *FakeConnection) WithContextArgsForCall(i int) context.Context {
+	fake.withContextMutex.RLock()
+	defer fake.withContextMutex.RUnlock()
+	return fake.withContextArgsForCall[i].ctx
+}
+
+func (fake *FakeConnection) WithContextReturns(result1 connection.Connection) {
+	fake.WithContextStub = nil
+	fake.withContextReturns = struct {
+		result1 connection.Connection
+	}{result1}
+}
+
 var _ connection.Connection = new(FakeConnection)
@@ -3,6 +3,7 @@ package fakes
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"net"
 	"net/url"
@@ -40,6 +41,14 @@ type FakeHijackStreamer struct {
 		result2 *bufio.Reader
 		result3 error
 	}
+	WithContextStub        func(ctx context.Context) connection.HijackStreamer
+	withContextMutex       sync.RWMutex
+	withContextArgsForCall []struct {
+		ctx context.Context
+	}
+	withContextReturns struct {
+		result1 connection.HijackStreamer
+	}
 }
 
 func (fake *FakeHijackStreamer) Stream(handler string, body io.Reader, params rata.Params, query url.Values, contentType string) (io.ReadCloser, error) {
@@ -117,4 +126,36 @@ func (fake *FakeHijackStreamer) HijackReturns(result1 net.Conn, result2 *bufio.R
 	}{result1, result2, result3}
 }
 
+func (fake *FakeHijackStreamer) WithContext(ctx context.Context) connection.HijackStreamer {
+	fake.withContextMutex.Lock()
+	fake.withContextArgsForCall = append(fake.withContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.withContextMutex.Unlock()
+	if fake.WithContextStub != nil {
+		return fake.WithContextStub(ctx)
+	} else {
+		return fake.withContextReturns.result1
+	}
+}
+
+func (fake *FakeHijackStreamer) WithContextCallCount() int {
+	fake.withContextMutex.RLock()
+	defer fake.withContextMutex.RUnlock()
+	return len(fake.withContextArgsForCall)
+}
+
+func (fake *FakeHijackStreamer) WithContextArgsForCall(i int) context.Context {
+	fake.withContextMutex.RLock()
+	defer fake.withContextMutex.RUnlock()
+	return fake.withContextArgsForCall[i].ctx
+}
+
+func (fake *FakeHijackStreamer) WithContextReturns(result1 connection.HijackStreamer) {
+	fake.WithContextStub = nil
+	fake.withContextReturns = struct {
+		result1 connection.HijackStreamer
+	}{result1}
+}
+
 var _ connection.HijackStreamer = new(FakeHijackStreamer)
@@ -2,22 +2,55 @@ package connection
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/cloudfoundry-incubator/garden"
 )
 
+// DroppedOutputReporter is implemented by the processes returned by Run and
+// Attach.
+type DroppedOutputReporter interface {
+	// DroppedOutputBytes returns the number of bytes of stdout and stderr the
+	// server discarded because they were not consumed quickly enough. It is
+	// final once Wait has returned.
+	DroppedOutputBytes() uint64
+}
+
+// OutputOffsets are byte offsets into a process's stdout and stderr, counted
+// from when the server started streaming the process's output.
+type OutputOffsets struct {
+	Stdout uint64
+	Stderr uint64
+}
+
+// OutputOffsetReporter is implemented by the processes returned by Run,
+// Attach and AttachFrom.
+type OutputOffsetReporter interface {
+	// OutputOffsets returns the offsets just past the output written to the
+	// process's stdout and stderr writers so far. Attaching from them resumes
+	// streaming without gaps or duplicates.
+	OutputOffsets() OutputOffsets
+}
+
 type process struct {
+	// accessed atomically; kept first for 64-bit alignment
+	stdoutOffset uint64
+	stderrOffset uint64
+
 	id string
 
 	processInputStream *processStream
 	done               bool
 	exitStatus         int
 	exitErr            error
+	dropped            uint64
 	doneL              *sync.Cond
 }
 
-func newProcess(id string, processInputStream *processStream) *process {
+func newProcess(id string, processInputStream *processStream, offsets OutputOffsets) *process {
 	return &process{
+		stdoutOffset:       offsets.Stdout,
+		stderrOffset:       offsets.Stderr,
 		id:                 id,
 		processInputStream: processInputStream,
 		doneL:              sync.NewCond(&sync.Mutex{}),
@@ -48,6 +81,26 @@ func (p *process) Signal(signal garden.Signal) error {
 	return p.processInputStream.Signal(signal)
 }
 
+func (p *process) OutputOffsets() OutputOffsets {
+	return OutputOffsets{
+		Stdout: atomic.LoadUint64(&p.stdoutOffset),
+		Stderr: atomic.LoadUint64(&p.stderrOffset),
+	}
+}
+
+func (p *process) DroppedOutputBytes() uint64 {
+	p.doneL.L.Lock()
+	defer p.doneL.L.Unlock()
+
+	return p.dropped
+}
+
+func (p *process) droppedOutput(dropped uint64) {
+	p.doneL.L.Lock()
+	p.dropped = dropped
+	p.doneL.L.Unlock()
+}
+
 func (p *process) exited(exitStatus int, err error) {
 	p.doneL.L.Lock()
 	p.exitStatus = exitStatus
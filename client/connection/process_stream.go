@@ -9,8 +9,9 @@ import (
 )
 
 type processStream struct {
-	processID string
-	conn      net.Conn
+	processID  string
+	allSignals bool
+	conn       net.Conn
 
 	sync.Mutex
 }
@@ -41,6 +42,10 @@ func (s *processStream) SetTTY(spec garden.TTYSpec) error {
 }
 
 func (s *processStream) Signal(signal garden.Signal) error {
+	if !s.allSignals && signal != garden.SignalTerminate && signal != garden.SignalKill {
+		return ErrUnsupportedSignal
+	}
+
 	return s.sendPayload(&transport.ProcessPayload{
 		ProcessID: s.processID,
 		Signal:    &signal,
@@ -0,0 +1,293 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+// RetryPolicy configures how a retrying Connection retries failed calls.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a call is made before its error is
+	// returned, including the first.
+	MaxAttempts int
+
+	// InitialBackoff is the longest wait before the first retry. It doubles
+	// with each retry, up to MaxBackoff. Each wait is chosen at random from
+	// between half and all of the backoff, so that clients that failed
+	// together do not all retry together.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// NewRetrying returns a Connection that retries calls made with conn that
+// fail because the server could not be dialled or was unavailable, e.g.
+// while it restarts.
+//
+// Only calls that are safe to repeat are retried: those that read state, and
+// Create when the spec has a handle, as a second container cannot be created
+// with the same handle, or an idempotency key. All other calls are made once.
+func NewRetrying(conn Connection, policy RetryPolicy) Connection {
+	return &retryingConnection{
+		Connection: conn,
+		policy:     policy,
+		ctx:        context.Background(),
+	}
+}
+
+type retryingConnection struct {
+	Connection
+
+	policy RetryPolicy
+	ctx    context.Context
+}
+
+func (c *retryingConnection) WithContext(ctx context.Context) Connection {
+	return &retryingConnection{
+		Connection: c.Connection.WithContext(ctx),
+		policy:     c.policy,
+		ctx:        ctx,
+	}
+}
+
+// retry calls f until it succeeds, fails with an error that is not
+// retryable, or has been called policy.MaxAttempts times, returning its last
+// error.
+func (c *retryingConnection) retry(f func() error) error {
+	backoff := c.policy.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= c.policy.MaxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-c.ctx.Done():
+			timer.Stop()
+			return err
+		}
+
+		backoff *= 2
+		if backoff > c.policy.MaxBackoff {
+			backoff = c.policy.MaxBackoff
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	if _, ok := err.(garden.ServiceUnavailableError); ok {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (c *retryingConnection) Ping() error {
+	return c.retry(c.Connection.Ping)
+}
+
+func (c *retryingConnection) Capacity() (capacity garden.Capacity, err error) {
+	err = c.retry(func() error {
+		capacity, err = c.Connection.Capacity()
+		return err
+	})
+
+	return capacity, err
+}
+
+func (c *retryingConnection) Create(spec garden.ContainerSpec) (handle string, err error) {
+	if spec.Handle == "" && spec.IdempotencyKey == "" {
+		return c.Connection.Create(spec)
+	}
+
+	err = c.retry(func() error {
+		handle, err = c.Connection.Create(spec)
+		return err
+	})
+
+	return handle, err
+}
+
+func (c *retryingConnection) List(properties garden.Properties) (handles []string, err error) {
+	err = c.retry(func() error {
+		handles, err = c.Connection.List(properties)
+		return err
+	})
+
+	return handles, err
+}
+
+func (c *retryingConnection) ListMatching(selector garden.Selector) (handles []string, err error) {
+	err = c.retry(func() error {
+		handles, err = c.Connection.ListMatching(selector)
+		return err
+	})
+
+	return handles, err
+}
+
+func (c *retryingConnection) ListPage(options garden.ListOptions) (page garden.ListPage, err error) {
+	err = c.retry(func() error {
+		page, err = c.Connection.ListPage(options)
+		return err
+	})
+
+	return page, err
+}
+
+func (c *retryingConnection) WaitForDestroy(handle string) error {
+	return c.retry(func() error {
+		return c.Connection.WaitForDestroy(handle)
+	})
+}
+
+func (c *retryingConnection) Info(handle string) (info garden.ContainerInfo, err error) {
+	err = c.retry(func() error {
+		info, err = c.Connection.Info(handle)
+		return err
+	})
+
+	return info, err
+}
+
+func (c *retryingConnection) BulkInfo(handles []string) (infos map[string]garden.ContainerInfoEntry, err error) {
+	err = c.retry(func() error {
+		infos, err = c.Connection.BulkInfo(handles)
+		return err
+	})
+
+	return infos, err
+}
+
+func (c *retryingConnection) BulkMetrics(handles []string) (metrics map[string]garden.ContainerMetricsEntry, err error) {
+	err = c.retry(func() error {
+		metrics, err = c.Connection.BulkMetrics(handles)
+		return err
+	})
+
+	return metrics, err
+}
+
+func (c *retryingConnection) CurrentBandwidthLimits(handle string) (limits garden.BandwidthLimits, err error) {
+	err = c.retry(func() error {
+		limits, err = c.Connection.CurrentBandwidthLimits(handle)
+		return err
+	})
+
+	return limits, err
+}
+
+func (c *retryingConnection) CurrentCPULimits(handle string) (limits garden.CPULimits, err error) {
+	err = c.retry(func() error {
+		limits, err = c.Connection.CurrentCPULimits(handle)
+		return err
+	})
+
+	return limits, err
+}
+
+func (c *retryingConnection) CurrentDiskLimits(handle string) (limits garden.DiskLimits, err error) {
+	err = c.retry(func() error {
+		limits, err = c.Connection.CurrentDiskLimits(handle)
+		return err
+	})
+
+	return limits, err
+}
+
+func (c *retryingConnection) CurrentMemoryLimits(handle string) (limits garden.MemoryLimits, err error) {
+	err = c.retry(func() error {
+		limits, err = c.Connection.CurrentMemoryLimits(handle)
+		return err
+	})
+
+	return limits, err
+}
+
+func (c *retryingConnection) Processes(handle string) (processes []garden.ProcessInfo, err error) {
+	err = c.retry(func() error {
+		processes, err = c.Connection.Processes(handle)
+		return err
+	})
+
+	return processes, err
+}
+
+func (c *retryingConnection) Process(handle string, processID string) (process garden.ProcessInfo, err error) {
+	err = c.retry(func() error {
+		process, err = c.Connection.Process(handle, processID)
+		return err
+	})
+
+	return process, err
+}
+
+func (c *retryingConnection) ProcessStatus(handle string, processID string) (status garden.ProcessStatus, err error) {
+	err = c.retry(func() error {
+		status, err = c.Connection.ProcessStatus(handle, processID)
+		return err
+	})
+
+	return status, err
+}
+
+func (c *retryingConnection) Properties(handle string) (properties garden.Properties, err error) {
+	err = c.retry(func() error {
+		properties, err = c.Connection.Properties(handle)
+		return err
+	})
+
+	return properties, err
+}
+
+func (c *retryingConnection) VersionedProperties(handle string) (properties garden.VersionedProperties, err error) {
+	err = c.retry(func() error {
+		properties, err = c.Connection.VersionedProperties(handle)
+		return err
+	})
+
+	return properties, err
+}
+
+func (c *retryingConnection) Property(handle string, name string) (value string, err error) {
+	err = c.retry(func() error {
+		value, err = c.Connection.Property(handle, name)
+		return err
+	})
+
+	return value, err
+}
+
+func (c *retryingConnection) Metrics(handle string) (metrics garden.Metrics, err error) {
+	err = c.retry(func() error {
+		metrics, err = c.Connection.Metrics(handle)
+		return err
+	})
+
+	return metrics, err
+}
+
+func (c *retryingConnection) PendingReaps() (reaps []garden.PendingReap, err error) {
+	err = c.retry(func() error {
+		reaps, err = c.Connection.PendingReaps()
+		return err
+	})
+
+	return reaps, err
+}
@@ -0,0 +1,167 @@
+package connection_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+	. "github.com/cloudfoundry-incubator/garden/client/connection"
+	"github.com/cloudfoundry-incubator/garden/client/connection/connectionfakes"
+)
+
+var _ = Describe("Retrying connection", func() {
+	var (
+		fakeConnection *connectionfakes.FakeConnection
+		policy         RetryPolicy
+
+		conn Connection
+
+		dialErr        error
+		unavailableErr error
+	)
+
+	BeforeEach(func() {
+		fakeConnection = new(connectionfakes.FakeConnection)
+
+		policy = RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+		}
+
+		dialErr = &net.OpError{Op: "dial", Net: "unix", Err: errors.New("connection refused")}
+		unavailableErr = garden.NewServiceUnavailableError("restarting")
+	})
+
+	JustBeforeEach(func() {
+		conn = NewRetrying(fakeConnection, policy)
+	})
+
+	Describe("idempotent calls", func() {
+		It("retries when the server cannot be dialled", func() {
+			fakeConnection.InfoStub = func(string) (garden.ContainerInfo, error) {
+				if fakeConnection.InfoCallCount() == 1 {
+					return garden.ContainerInfo{}, dialErr
+				}
+
+				return garden.ContainerInfo{State: "active"}, nil
+			}
+
+			info, err := conn.Info("some-handle")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(info.State).Should(Equal("active"))
+
+			Ω(fakeConnection.InfoCallCount()).Should(Equal(2))
+			Ω(fakeConnection.InfoArgsForCall(1)).Should(Equal("some-handle"))
+		})
+
+		It("retries when the server is unavailable", func() {
+			fakeConnection.ListStub = func(garden.Properties) ([]string, error) {
+				if fakeConnection.ListCallCount() < 3 {
+					return nil, unavailableErr
+				}
+
+				return []string{"some-handle"}, nil
+			}
+
+			handles, err := conn.List(nil)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(handles).Should(Equal([]string{"some-handle"}))
+		})
+
+		It("gives up after the maximum number of attempts", func() {
+			fakeConnection.PingReturns(unavailableErr)
+
+			Ω(conn.Ping()).Should(Equal(unavailableErr))
+			Ω(fakeConnection.PingCallCount()).Should(Equal(3))
+		})
+
+		It("does not retry other errors", func() {
+			disaster := errors.New("oh no!")
+			fakeConnection.PropertiesReturns(nil, disaster)
+
+			_, err := conn.Properties("some-handle")
+			Ω(err).Should(Equal(disaster))
+			Ω(fakeConnection.PropertiesCallCount()).Should(Equal(1))
+		})
+
+		Context("when the connection is bound to a context", func() {
+			var boundConnection *connectionfakes.FakeConnection
+
+			BeforeEach(func() {
+				policy.InitialBackoff = time.Hour
+				policy.MaxBackoff = time.Hour
+
+				boundConnection = new(connectionfakes.FakeConnection)
+				boundConnection.MetricsReturns(garden.Metrics{}, unavailableErr)
+				fakeConnection.WithContextReturns(boundConnection)
+			})
+
+			It("stops retrying once the context is done", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+
+				_, err := conn.WithContext(ctx).Metrics("some-handle")
+				Ω(err).Should(Equal(unavailableErr))
+
+				Ω(fakeConnection.WithContextArgsForCall(0)).Should(Equal(ctx))
+				Ω(boundConnection.MetricsCallCount()).Should(Equal(1))
+			})
+		})
+	})
+
+	Describe("Create", func() {
+		BeforeEach(func() {
+			fakeConnection.CreateStub = func(garden.ContainerSpec) (string, error) {
+				if fakeConnection.CreateCallCount() == 1 {
+					return "", dialErr
+				}
+
+				return "some-handle", nil
+			}
+		})
+
+		Context("when the spec has a handle", func() {
+			It("retries", func() {
+				handle, err := conn.Create(garden.ContainerSpec{Handle: "some-handle"})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(handle).Should(Equal("some-handle"))
+
+				Ω(fakeConnection.CreateCallCount()).Should(Equal(2))
+			})
+		})
+
+		Context("when the spec has an idempotency key", func() {
+			It("retries", func() {
+				handle, err := conn.Create(garden.ContainerSpec{IdempotencyKey: "some-key"})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(handle).Should(Equal("some-handle"))
+
+				Ω(fakeConnection.CreateCallCount()).Should(Equal(2))
+			})
+		})
+
+		Context("when the spec has no handle or idempotency key", func() {
+			It("does not retry", func() {
+				_, err := conn.Create(garden.ContainerSpec{})
+				Ω(err).Should(Equal(dialErr))
+
+				Ω(fakeConnection.CreateCallCount()).Should(Equal(1))
+			})
+		})
+	})
+
+	Describe("calls that are not idempotent", func() {
+		It("does not retry them", func() {
+			fakeConnection.DestroyReturns(dialErr)
+
+			Ω(conn.Destroy("some-handle")).Should(Equal(dialErr))
+			Ω(fakeConnection.DestroyCallCount()).Should(Equal(1))
+		})
+	})
+})
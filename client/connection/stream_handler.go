@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 
 	"github.com/cloudfoundry-incubator/garden/transport"
 	"github.com/pivotal-golang/lager"
@@ -39,32 +40,47 @@ func (sh *streamHandler) streamIn(processWriter io.WriteCloser, stdin io.Reader)
 	}(processWriter, stdin, sh.log)
 }
 
-func (sh *streamHandler) streamOut(streamWriter io.Writer, streamReader io.Reader) {
+// streamOut copies the stream to streamWriter, advancing offset by the bytes
+// written.
+func (sh *streamHandler) streamOut(streamWriter io.Writer, streamReader io.Reader, offset *uint64) {
 	sh.wg.Add(1)
 	go func() {
-		io.Copy(streamWriter, streamReader)
+		io.Copy(&offsetWriter{writer: streamWriter, offset: offset}, streamReader)
 		sh.wg.Done()
 	}()
 }
 
-func (sh *streamHandler) wait(decoder *json.Decoder) (int, error) {
+type offsetWriter struct {
+	writer io.Writer
+	offset *uint64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	atomic.AddUint64(w.offset, uint64(n))
+	return n, err
+}
+
+// wait returns the process's exit status along with the number of output
+// bytes the server reported as dropped.
+func (sh *streamHandler) wait(decoder *json.Decoder) (int, uint64, error) {
 	for {
 		payload := &transport.ProcessPayload{}
 		err := decoder.Decode(payload)
 		if err != nil {
 			sh.wg.Wait()
-			return 0, fmt.Errorf("connection: decode failed: %s", err)
+			return 0, 0, fmt.Errorf("connection: decode failed: %s", err)
 		}
 
 		if payload.Error != nil {
 			sh.wg.Wait()
-			return 0, fmt.Errorf("connection: process error: %s", *payload.Error)
+			return 0, payload.DroppedBytes, fmt.Errorf("connection: process error: %s", *payload.Error)
 		}
 
 		if payload.ExitStatus != nil {
 			sh.wg.Wait()
 			status := int(*payload.ExitStatus)
-			return status, nil
+			return status, payload.DroppedBytes, nil
 		}
 
 		// discard other payloads
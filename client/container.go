@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -8,6 +9,36 @@ import (
 	"github.com/cloudfoundry-incubator/garden/client/connection"
 )
 
+// Container is implemented by the containers the client returns.
+type Container interface {
+	garden.Container
+	garden.ContextContainer
+
+	// AttachFrom is like Attach, but resumes streaming the process's stdout and
+	// stderr from the given offsets, e.g. those reported by an earlier
+	// process's OutputOffsets.
+	AttachFrom(processID string, offsets connection.OutputOffsets, io garden.ProcessIO) (garden.Process, error)
+
+	// RunDetached is like Run, but the server keeps the process's exit status
+	// and the end of its output for a while after it exits, so that they are
+	// not lost if the client goes away. They are returned by ProcessStatus, and
+	// by Wait on the process returned by a later Attach.
+	RunDetached(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
+
+	// ProcessStatus returns the status of a process run with RunDetached.
+	ProcessStatus(processID string) (garden.ProcessStatus, error)
+
+	// VersionedProperties is like Properties, but also returns the version of
+	// the properties, which changes whenever they do.
+	VersionedProperties() (garden.VersionedProperties, error)
+
+	// UpdateProperties sets and removes properties in one atomic change, and
+	// returns their new version. If expectedVersion is not empty and the
+	// properties are no longer at that version it fails with a
+	// garden.ConflictError, changing nothing.
+	UpdateProperties(set garden.Properties, remove []string, expectedVersion string) (string, error)
+}
+
 type container struct {
 	handle string
 
@@ -66,6 +97,34 @@ func (container *container) Attach(processID string, io garden.ProcessIO) (garde
 	return container.connection.Attach(container.handle, processID, io)
 }
 
+func (container *container) AttachFrom(processID string, offsets connection.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
+	return container.connection.AttachFrom(container.handle, processID, offsets, io)
+}
+
+func (container *container) RunDetached(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	return container.connection.RunDetached(container.handle, spec, io)
+}
+
+func (container *container) ProcessStatus(processID string) (garden.ProcessStatus, error) {
+	return container.connection.ProcessStatus(container.handle, processID)
+}
+
+func (container *container) SetBandwidthLimits(limits garden.BandwidthLimits) error {
+	return container.connection.SetBandwidthLimits(container.handle, limits)
+}
+
+func (container *container) SetCPULimits(limits garden.CPULimits) error {
+	return container.connection.SetCPULimits(container.handle, limits)
+}
+
+func (container *container) SetDiskLimits(limits garden.DiskLimits) error {
+	return container.connection.SetDiskLimits(container.handle, limits)
+}
+
+func (container *container) SetMemoryLimits(limits garden.MemoryLimits) error {
+	return container.connection.SetMemoryLimits(container.handle, limits)
+}
+
 func (container *container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
 	return container.connection.NetIn(container.handle, hostPort, containerPort)
 }
@@ -74,6 +133,14 @@ func (container *container) NetOut(netOutRule garden.NetOutRule) error {
 	return container.connection.NetOut(container.handle, netOutRule)
 }
 
+func (container *container) Processes() ([]garden.ProcessInfo, error) {
+	return container.connection.Processes(container.handle)
+}
+
+func (container *container) Process(processID string) (garden.ProcessInfo, error) {
+	return container.connection.Process(container.handle, processID)
+}
+
 func (container *container) Metrics() (garden.Metrics, error) {
 	return container.connection.Metrics(container.handle)
 }
@@ -86,6 +153,14 @@ func (container *container) Properties() (garden.Properties, error) {
 	return container.connection.Properties(container.handle)
 }
 
+func (container *container) VersionedProperties() (garden.VersionedProperties, error) {
+	return container.connection.VersionedProperties(container.handle)
+}
+
+func (container *container) UpdateProperties(set garden.Properties, remove []string, expectedVersion string) (string, error) {
+	return container.connection.UpdateProperties(container.handle, set, remove, expectedVersion)
+}
+
 func (container *container) Property(name string) (string, error) {
 	return container.connection.Property(container.handle, name)
 }
@@ -97,3 +172,99 @@ func (container *container) SetProperty(name string, value string) error {
 func (container *container) RemoveProperty(name string) error {
 	return container.connection.RemoveProperty(container.handle, name)
 }
+
+func (container *container) StopContext(ctx context.Context, kill bool) error {
+	return container.connection.WithContext(ctx).Stop(container.handle, kill)
+}
+
+func (container *container) InfoContext(ctx context.Context) (garden.ContainerInfo, error) {
+	return container.connection.WithContext(ctx).Info(container.handle)
+}
+
+func (container *container) StreamInContext(ctx context.Context, spec garden.StreamInSpec) error {
+	return container.connection.WithContext(ctx).StreamIn(container.handle, spec)
+}
+
+func (container *container) StreamOutContext(ctx context.Context, spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	return container.connection.WithContext(ctx).StreamOut(container.handle, spec)
+}
+
+func (container *container) CurrentBandwidthLimitsContext(ctx context.Context) (garden.BandwidthLimits, error) {
+	return container.connection.WithContext(ctx).CurrentBandwidthLimits(container.handle)
+}
+
+func (container *container) CurrentCPULimitsContext(ctx context.Context) (garden.CPULimits, error) {
+	return container.connection.WithContext(ctx).CurrentCPULimits(container.handle)
+}
+
+func (container *container) CurrentDiskLimitsContext(ctx context.Context) (garden.DiskLimits, error) {
+	return container.connection.WithContext(ctx).CurrentDiskLimits(container.handle)
+}
+
+func (container *container) CurrentMemoryLimitsContext(ctx context.Context) (garden.MemoryLimits, error) {
+	return container.connection.WithContext(ctx).CurrentMemoryLimits(container.handle)
+}
+
+func (container *container) RunContext(ctx context.Context, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	return container.connection.WithContext(ctx).Run(container.handle, spec, io)
+}
+
+func (container *container) AttachContext(ctx context.Context, processID string, io garden.ProcessIO) (garden.Process, error) {
+	return container.connection.WithContext(ctx).Attach(container.handle, processID, io)
+}
+
+func (container *container) SetBandwidthLimitsContext(ctx context.Context, limits garden.BandwidthLimits) error {
+	return container.connection.WithContext(ctx).SetBandwidthLimits(container.handle, limits)
+}
+
+func (container *container) SetCPULimitsContext(ctx context.Context, limits garden.CPULimits) error {
+	return container.connection.WithContext(ctx).SetCPULimits(container.handle, limits)
+}
+
+func (container *container) SetDiskLimitsContext(ctx context.Context, limits garden.DiskLimits) error {
+	return container.connection.WithContext(ctx).SetDiskLimits(container.handle, limits)
+}
+
+func (container *container) SetMemoryLimitsContext(ctx context.Context, limits garden.MemoryLimits) error {
+	return container.connection.WithContext(ctx).SetMemoryLimits(container.handle, limits)
+}
+
+func (container *container) NetInContext(ctx context.Context, hostPort, containerPort uint32) (uint32, uint32, error) {
+	return container.connection.WithContext(ctx).NetIn(container.handle, hostPort, containerPort)
+}
+
+func (container *container) NetOutContext(ctx context.Context, netOutRule garden.NetOutRule) error {
+	return container.connection.WithContext(ctx).NetOut(container.handle, netOutRule)
+}
+
+func (container *container) ProcessesContext(ctx context.Context) ([]garden.ProcessInfo, error) {
+	return container.connection.WithContext(ctx).Processes(container.handle)
+}
+
+func (container *container) ProcessContext(ctx context.Context, processID string) (garden.ProcessInfo, error) {
+	return container.connection.WithContext(ctx).Process(container.handle, processID)
+}
+
+func (container *container) MetricsContext(ctx context.Context) (garden.Metrics, error) {
+	return container.connection.WithContext(ctx).Metrics(container.handle)
+}
+
+func (container *container) SetGraceTimeContext(ctx context.Context, graceTime time.Duration) error {
+	return container.connection.WithContext(ctx).SetGraceTime(container.handle, graceTime)
+}
+
+func (container *container) PropertiesContext(ctx context.Context) (garden.Properties, error) {
+	return container.connection.WithContext(ctx).Properties(container.handle)
+}
+
+func (container *container) PropertyContext(ctx context.Context, name string) (string, error) {
+	return container.connection.WithContext(ctx).Property(container.handle, name)
+}
+
+func (container *container) SetPropertyContext(ctx context.Context, name string, value string) error {
+	return container.connection.WithContext(ctx).SetProperty(container.handle, name, value)
+}
+
+func (container *container) RemovePropertyContext(ctx context.Context, name string) error {
+	return container.connection.WithContext(ctx).RemoveProperty(container.handle, name)
+}
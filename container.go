@@ -53,6 +53,30 @@ type Container interface {
 	// Returns the current memory limts set for the container.
 	CurrentMemoryLimits() (MemoryLimits, error)
 
+	// Sets the bandwidth limits of the container, taking effect immediately.
+	//
+	// Errors:
+	// * When the limits cannot be applied to the running container.
+	SetBandwidthLimits(limits BandwidthLimits) error
+
+	// Sets the CPU limits of the container, taking effect immediately.
+	//
+	// Errors:
+	// * When the limits cannot be applied to the running container.
+	SetCPULimits(limits CPULimits) error
+
+	// Sets the disk limits of the container, taking effect immediately.
+	//
+	// Errors:
+	// * When a limit is lower than the container's current disk usage.
+	SetDiskLimits(limits DiskLimits) error
+
+	// Sets the memory limits of the container, taking effect immediately.
+	//
+	// Errors:
+	// * When the limit is lower than the container's current memory usage.
+	SetMemoryLimits(limits MemoryLimits) error
+
 	// Map a port on the host to a port in the container so that traffic to the
 	// host port is forwarded to the container port.
 	//
@@ -94,6 +118,16 @@ type Container interface {
 	// * processID does not refer to a running process.
 	Attach(processID string, io ProcessIO) (Process, error)
 
+	// Processes returns information about the processes run in the container,
+	// both running and exited, in the order they were started.
+	Processes() ([]ProcessInfo, error)
+
+	// Process returns information about a process run in the container.
+	//
+	// Errors:
+	// * processID does not refer to a process run in the container.
+	Process(processID string) (ProcessInfo, error)
+
 	// Metrics returns the current set of metrics for a container
 	Metrics() (Metrics, error)
 
@@ -122,6 +156,23 @@ type Container interface {
 	RemoveProperty(name string) error
 }
 
+// PropertiesUpdater is implemented by Containers that can set and remove
+// several properties atomically. For other Containers the server sets and
+// removes them one at a time, restoring the previous values if one fails.
+type PropertiesUpdater interface {
+	// UpdateProperties sets the properties in set and removes those in remove.
+	// Removing a property that is not set is not an error.
+	UpdateProperties(set Properties, remove []string) error
+}
+
+// VersionedProperties are a container's properties, with their version. The
+// version changes whenever the properties are changed through the server, so
+// that they can be updated with compare-and-swap.
+type VersionedProperties struct {
+	Properties Properties //
+	Version    string     // Opaque. Versions from before the server restarted are never current.
+}
+
 // ProcessSpec contains parameters for running a script inside a container.
 type ProcessSpec struct {
 	// Path to command to execute.
@@ -170,13 +221,50 @@ type Process interface {
 	Signal(Signal) error
 }
 
+// Signal is a signal that can be sent to a process. Besides SignalTerminate
+// and SignalKill, each corresponds to the POSIX signal of the same name.
 type Signal int
 
 const (
 	SignalTerminate Signal = iota
 	SignalKill
+
+	SignalHangup       // SIGHUP
+	SignalInterrupt    // SIGINT
+	SignalQuit         // SIGQUIT
+	SignalUser1        // SIGUSR1
+	SignalUser2        // SIGUSR2
+	SignalWindowChange // SIGWINCH
 )
 
+// Valid reports whether s is one of the signals defined above.
+func (s Signal) Valid() bool {
+	return s >= SignalTerminate && s <= SignalWindowChange
+}
+
+// ProcessInfo describes a process run in a container.
+type ProcessInfo struct {
+	ID              string      //
+	Spec            ProcessSpec // The spec the process was run with.
+	StartedAt       time.Time   //
+	State           string      // Either "running" or "exited".
+	ExitStatus      int         // The process's exit status, once it has exited.
+	TTY             bool        // Whether the process was run with a TTY.
+	AttachedClients int         // The number of clients streaming the process's output. Set by the server; backends need not set it.
+}
+
+// ProcessStatus is the status of a process run detached, which the server
+// keeps for a while after the process exits.
+type ProcessStatus struct {
+	ID         string    //
+	State      string    // Either "running" or "exited".
+	ExitStatus int       // The process's exit status, once it has exited.
+	Error      string    // Why the process's exit status could not be determined, if it could not.
+	ExitedAt   time.Time //
+	Stdout     string    // The end of the process's stdout.
+	Stderr     string    // The end of the process's stderr.
+}
+
 type PortMapping struct {
 	HostPort      uint32
 	ContainerPort uint32
@@ -195,7 +283,7 @@ type StreamOutSpec struct {
 
 // ContainerInfo holds information about a container.
 type ContainerInfo struct {
-	State         string        // Either "active" or "stopped".
+	State         string        // Either "active" or "stopped", or "destroying" while the container is destroyed.
 	Events        []string      // List of events that occurred for the container. It currently includes only "oom" (Out Of Memory) event if it occurred.
 	HostIP        string        // The IP address of the gateway which controls the host side of the container's virtual ethernet pair.
 	ContainerIP   string        // The IP address of the container side of the container's virtual ethernet pair.
@@ -211,6 +299,12 @@ type ContainerInfoEntry struct {
 	Err  *Error
 }
 
+// ContainerResultEntry is the outcome of a bulk operation on one container.
+// Err is nil if the operation succeeded.
+type ContainerResultEntry struct {
+	Err *Error
+}
+
 type Metrics struct {
 	MemoryStat  ContainerMemoryStat
 	CPUStat     ContainerCPUStat
@@ -0,0 +1,98 @@
+package garden
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate counterfeiter . ContextClient
+
+// ContextClient is implemented by Clients whose calls can be cancelled, or
+// given a deadline, with a context. Each method is the Client method of the
+// same name without the suffix.
+//
+// The garden client implements it. The server calls it, for backends that
+// implement it, with a context that is cancelled when the requesting client
+// goes away.
+type ContextClient interface {
+	PingContext(ctx context.Context) error
+
+	CapacityContext(ctx context.Context) (Capacity, error)
+
+	CreateContext(ctx context.Context, spec ContainerSpec) (Container, error)
+
+	DestroyContext(ctx context.Context, handle string) error
+
+	ContainersContext(ctx context.Context, properties Properties) ([]Container, error)
+
+	BulkInfoContext(ctx context.Context, handles []string) (map[string]ContainerInfoEntry, error)
+
+	BulkMetricsContext(ctx context.Context, handles []string) (map[string]ContainerMetricsEntry, error)
+
+	LookupContext(ctx context.Context, handle string) (Container, error)
+}
+
+// ContextSelectorBackend is implemented by SelectorBackends whose selections
+// can be cancelled, or given a deadline, with a context. The server prefers it
+// to SelectorBackend.
+type ContextSelectorBackend interface {
+	ContainersMatchingContext(ctx context.Context, selector Selector) ([]Container, error)
+}
+
+//go:generate counterfeiter . ContextContainer
+
+// ContextContainer is implemented by Containers whose calls can be cancelled,
+// or given a deadline, with a context. Each method is the Container method of
+// the same name without the suffix.
+//
+// Containers returned by a ContextClient are not bound to the context they
+// were returned with.
+type ContextContainer interface {
+	StopContext(ctx context.Context, kill bool) error
+
+	InfoContext(ctx context.Context) (ContainerInfo, error)
+
+	StreamInContext(ctx context.Context, spec StreamInSpec) error
+
+	// StreamOutContext's context also bounds reading the returned stream.
+	StreamOutContext(ctx context.Context, spec StreamOutSpec) (io.ReadCloser, error)
+
+	CurrentBandwidthLimitsContext(ctx context.Context) (BandwidthLimits, error)
+	CurrentCPULimitsContext(ctx context.Context) (CPULimits, error)
+	CurrentDiskLimitsContext(ctx context.Context) (DiskLimits, error)
+	CurrentMemoryLimitsContext(ctx context.Context) (MemoryLimits, error)
+
+	SetBandwidthLimitsContext(ctx context.Context, limits BandwidthLimits) error
+	SetCPULimitsContext(ctx context.Context, limits CPULimits) error
+	SetDiskLimitsContext(ctx context.Context, limits DiskLimits) error
+	SetMemoryLimitsContext(ctx context.Context, limits MemoryLimits) error
+
+	NetInContext(ctx context.Context, hostPort, containerPort uint32) (uint32, uint32, error)
+
+	NetOutContext(ctx context.Context, netOutRule NetOutRule) error
+
+	// RunContext's context bounds spawning the process. The process outlives
+	// it, as it outlives the client that spawned it.
+	RunContext(ctx context.Context, spec ProcessSpec, io ProcessIO) (Process, error)
+
+	// AttachContext's context bounds attaching to the process, not streaming
+	// its output.
+	AttachContext(ctx context.Context, processID string, io ProcessIO) (Process, error)
+
+	ProcessesContext(ctx context.Context) ([]ProcessInfo, error)
+
+	ProcessContext(ctx context.Context, processID string) (ProcessInfo, error)
+
+	MetricsContext(ctx context.Context) (Metrics, error)
+
+	SetGraceTimeContext(ctx context.Context, graceTime time.Duration) error
+
+	PropertiesContext(ctx context.Context) (Properties, error)
+
+	PropertyContext(ctx context.Context, name string) (string, error)
+
+	SetPropertyContext(ctx context.Context, name string, value string) error
+
+	RemovePropertyContext(ctx context.Context, name string) error
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 type errType string
@@ -13,6 +14,13 @@ const (
 	unrecoverableErrType      = "UnrecoverableError"
 	serviceUnavailableErrType = "ServiceUnavailableError"
 	containerNotFoundErrType  = "ContainerNotFoundError"
+	unauthorizedErrType       = "UnauthorizedError"
+	forbiddenErrType          = "ForbiddenError"
+	invalidRequestErrType     = "InvalidRequestError"
+	handleExistsErrType       = "HandleExistsError"
+	conflictErrType           = "ConflictError"
+	processNotFoundErrType    = "ProcessNotFoundError"
+	capacityExhaustedErrType  = "CapacityExhaustedError"
 )
 
 type Error struct {
@@ -24,38 +32,92 @@ func NewError(err string) *Error {
 }
 
 type marshalledError struct {
-	Type    errType
-	Message string
-	Handle  string
+	Type      errType
+	Message   string
+	Handle    string
+	ProcessID string       `json:",omitempty"`
+	Reason    string       `json:",omitempty"`
+	Fields    []FieldError `json:",omitempty"`
 }
 
 func (m Error) Error() string {
 	return m.Err.Error()
 }
 
+// Unwrap returns the wrapped error, so that errors.As can find typed errors
+// wrapped by m.
+func (m Error) Unwrap() error {
+	return m.Err
+}
+
 func (m Error) StatusCode() int {
 	switch m.Err.(type) {
-	case ContainerNotFoundError:
+	case ContainerNotFoundError, ProcessNotFoundError:
 		return http.StatusNotFound
+	case HandleExistsError, ConflictError:
+		return http.StatusConflict
+	case ServiceUnavailableError, CapacityExhaustedError:
+		return http.StatusServiceUnavailable
+	case UnauthorizedError:
+		return http.StatusUnauthorized
+	case ForbiddenError:
+		return http.StatusForbidden
+	case InvalidRequestError:
+		return http.StatusBadRequest
 	}
 
 	return http.StatusInternalServerError
 }
 
-func (m Error) MarshalJSON() ([]byte, error) {
-	var errorType errType
-	handle := ""
-	switch err := m.Err.(type) {
+// Type returns the name of the typed error wrapped by m, as sent on the wire,
+// or an empty string if m wraps an untyped error.
+func (m Error) Type() string {
+	switch m.Err.(type) {
 	case ContainerNotFoundError:
-		errorType = containerNotFoundErrType
-		handle = err.Handle
+		return containerNotFoundErrType
 	case ServiceUnavailableError:
-		errorType = serviceUnavailableErrType
+		return serviceUnavailableErrType
 	case UnrecoverableError:
-		errorType = unrecoverableErrType
+		return unrecoverableErrType
+	case UnauthorizedError:
+		return unauthorizedErrType
+	case ForbiddenError:
+		return forbiddenErrType
+	case InvalidRequestError:
+		return invalidRequestErrType
+	case HandleExistsError:
+		return handleExistsErrType
+	case ConflictError:
+		return conflictErrType
+	case ProcessNotFoundError:
+		return processNotFoundErrType
+	case CapacityExhaustedError:
+		return capacityExhaustedErrType
 	}
 
-	return json.Marshal(marshalledError{errorType, m.Err.Error(), handle})
+	return ""
+}
+
+func (m Error) MarshalJSON() ([]byte, error) {
+	result := marshalledError{
+		Type:    errType(m.Type()),
+		Message: m.Err.Error(),
+	}
+
+	switch err := m.Err.(type) {
+	case ContainerNotFoundError:
+		result.Handle = err.Handle
+		result.Reason = err.Reason
+	case HandleExistsError:
+		result.Handle = err.Handle
+	case ProcessNotFoundError:
+		result.Handle = err.Handle
+		result.ProcessID = err.ProcessID
+	case InvalidRequestError:
+		result.Fields = err.Fields
+	}
+
+	return json.Marshal(result)
 }
 
 func (m *Error) UnmarshalJSON(data []byte) error {
@@ -71,7 +133,25 @@ func (m *Error) UnmarshalJSON(data []byte) error {
 	case serviceUnavailableErrType:
 		m.Err = ServiceUnavailableError{result.Message}
 	case containerNotFoundErrType:
-		m.Err = ContainerNotFoundError{result.Handle}
+		m.Err = ContainerNotFoundError{result.Handle, result.Reason}
+	case unauthorizedErrType:
+		m.Err = UnauthorizedError{result.Message}
+	case forbiddenErrType:
+		m.Err = ForbiddenError{result.Message}
+	case invalidRequestErrType:
+		if len(result.Fields) == 0 {
+			m.Err = InvalidRequestError{Message: result.Message}
+		} else {
+			m.Err = InvalidRequestError{Fields: result.Fields}
+		}
+	case handleExistsErrType:
+		m.Err = HandleExistsError{result.Handle}
+	case conflictErrType:
+		m.Err = ConflictError{result.Message}
+	case processNotFoundErrType:
+		m.Err = ProcessNotFoundError{result.Handle, result.ProcessID}
+	case capacityExhaustedErrType:
+		m.Err = CapacityExhaustedError{result.Message}
 	default:
 		m.Err = errors.New(result.Message)
 	}
@@ -93,11 +173,19 @@ func (err UnrecoverableError) Error() string {
 	return err.Symptom
 }
 
+// ContainerNotFoundError indicates that there is no container with the
+// handle. Reason says why, if it is known, e.g. that the container was
+// reaped.
 type ContainerNotFoundError struct {
 	Handle string
+	Reason string
 }
 
 func (err ContainerNotFoundError) Error() string {
+	if err.Reason != "" {
+		return fmt.Sprintf("unknown handle: %s (%s)", err.Handle, err.Reason)
+	}
+
 	return fmt.Sprintf("unknown handle: %s", err.Handle)
 }
 
@@ -114,3 +202,125 @@ type ServiceUnavailableError struct {
 func (err ServiceUnavailableError) Error() string {
 	return err.Cause
 }
+
+func NewUnauthorizedError(message string) error {
+	return UnauthorizedError{
+		Message: message,
+	}
+}
+
+// UnauthorizedError indicates that the caller could not be identified from
+// the credentials presented with the request.
+type UnauthorizedError struct {
+	Message string
+}
+
+func (err UnauthorizedError) Error() string {
+	return err.Message
+}
+
+func NewForbiddenError(message string) error {
+	return ForbiddenError{
+		Message: message,
+	}
+}
+
+// ForbiddenError indicates that the caller was identified but is not allowed
+// to perform the request.
+type ForbiddenError struct {
+	Message string
+}
+
+func (err ForbiddenError) Error() string {
+	return err.Message
+}
+
+type HandleExistsError struct {
+	Handle string
+}
+
+func (err HandleExistsError) Error() string {
+	return fmt.Sprintf("handle already exists: %s", err.Handle)
+}
+
+func NewConflictError(message string) error {
+	return ConflictError{
+		Message: message,
+	}
+}
+
+// ConflictError indicates that a request could not be performed because of
+// the state of its target, e.g. a container already being destroyed.
+type ConflictError struct {
+	Message string
+}
+
+func (err ConflictError) Error() string {
+	return err.Message
+}
+
+// ProcessNotFoundError indicates that a container has no process with the
+// given ID. Handle is empty when the container is not known.
+type ProcessNotFoundError struct {
+	Handle    string
+	ProcessID string
+}
+
+func (err ProcessNotFoundError) Error() string {
+	return fmt.Sprintf("unknown process: %s", err.ProcessID)
+}
+
+func NewCapacityExhaustedError(message string) error {
+	return CapacityExhaustedError{
+		Message: message,
+	}
+}
+
+// CapacityExhaustedError indicates that a backend has run out of a resource,
+// e.g. containers or host ports, needed to perform a request. The request may
+// succeed once the resource has been released.
+type CapacityExhaustedError struct {
+	Message string
+}
+
+func (err CapacityExhaustedError) Error() string {
+	return err.Message
+}
+
+func NewInvalidRequestError(message string) error {
+	return InvalidRequestError{
+		Message: message,
+	}
+}
+
+// InvalidRequestError indicates that a request was malformed, naming each
+// offending field, or describing the problem in Message when it is not with
+// particular fields.
+type InvalidRequestError struct {
+	Message string
+	Fields  []FieldError
+}
+
+func (err InvalidRequestError) Error() string {
+	if len(err.Fields) == 0 {
+		return err.Message
+	}
+
+	problems := make([]string, len(err.Fields))
+	for i, field := range err.Fields {
+		problems[i] = field.Error()
+	}
+
+	return "invalid request: " + strings.Join(problems, "; ")
+}
+
+// FieldError describes a problem with a field of a request. The field is
+// named by its path in the request's JSON, e.g. "bind_mounts[0].dst_path".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (err FieldError) Error() string {
+	return err.Field + " " + err.Message
+}
@@ -0,0 +1,125 @@
+package garden_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+var _ = Describe("Error", func() {
+	roundTrip := func(err error) error {
+		payload, marshalErr := json.Marshal(garden.Error{Err: err})
+		Ω(marshalErr).ShouldNot(HaveOccurred())
+
+		var result garden.Error
+		Ω(json.Unmarshal(payload, &result)).Should(Succeed())
+
+		return result.Err
+	}
+
+	It("round-trips an UnauthorizedError as a 401", func() {
+		err := garden.NewUnauthorizedError("who are you")
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusUnauthorized))
+		Ω(roundTrip(err)).Should(Equal(err))
+	})
+
+	It("round-trips a ForbiddenError as a 403", func() {
+		err := garden.NewForbiddenError("not yours")
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusForbidden))
+		Ω(roundTrip(err)).Should(Equal(err))
+	})
+
+	It("round-trips an InvalidRequestError with its fields as a 400", func() {
+		err := garden.InvalidRequestError{
+			Fields: []garden.FieldError{
+				{Field: "network", Message: "must be a CIDR"},
+				{Field: "env[0]", Message: "must be of the form KEY=VALUE"},
+			},
+		}
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusBadRequest))
+		Ω(roundTrip(err)).Should(Equal(err))
+		Ω(err.Error()).Should(Equal("invalid request: network must be a CIDR; env[0] must be of the form KEY=VALUE"))
+	})
+
+	It("round-trips a ContainerNotFoundError with its reason as a 404", func() {
+		err := garden.ContainerNotFoundError{Handle: "some-handle", Reason: "reaped"}
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusNotFound))
+		Ω(roundTrip(err)).Should(Equal(err))
+		Ω(err.Error()).Should(Equal("unknown handle: some-handle (reaped)"))
+	})
+
+	It("round-trips a HandleExistsError as a 409", func() {
+		err := garden.HandleExistsError{Handle: "some-handle"}
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusConflict))
+		Ω(roundTrip(err)).Should(Equal(err))
+	})
+
+	It("round-trips a ConflictError as a 409", func() {
+		err := garden.NewConflictError("container already being destroyed")
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusConflict))
+		Ω(roundTrip(err)).Should(Equal(err))
+	})
+
+	It("round-trips a ProcessNotFoundError as a 404", func() {
+		err := garden.ProcessNotFoundError{Handle: "some-handle", ProcessID: "some-process"}
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusNotFound))
+		Ω(roundTrip(err)).Should(Equal(err))
+		Ω(err.Error()).Should(Equal("unknown process: some-process"))
+	})
+
+	It("round-trips a CapacityExhaustedError as a 503", func() {
+		err := garden.NewCapacityExhaustedError("host port pool exhausted")
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusServiceUnavailable))
+		Ω(roundTrip(err)).Should(Equal(err))
+	})
+
+	It("round-trips a ServiceUnavailableError as a 503", func() {
+		err := garden.NewServiceUnavailableError("restarting")
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusServiceUnavailable))
+		Ω(roundTrip(err)).Should(Equal(err))
+	})
+
+	It("round-trips an InvalidRequestError without fields as a 400", func() {
+		err := garden.NewInvalidRequestError("content-type must be application/json")
+
+		Ω(garden.Error{Err: err}.StatusCode()).Should(Equal(http.StatusBadRequest))
+		Ω(roundTrip(err)).Should(Equal(err))
+		Ω(err.Error()).Should(Equal("content-type must be application/json"))
+	})
+
+	It("lets errors.As find the typed error it wraps", func() {
+		var wrapped error = &garden.Error{Err: garden.HandleExistsError{Handle: "some-handle"}}
+
+		var handleExists garden.HandleExistsError
+		Ω(errors.As(wrapped, &handleExists)).Should(BeTrue())
+		Ω(handleExists.Handle).Should(Equal("some-handle"))
+
+		var conflict garden.ConflictError
+		Ω(errors.As(wrapped, &conflict)).Should(BeFalse())
+	})
+
+	Describe("Type", func() {
+		It("names typed errors as they are sent on the wire", func() {
+			Ω(garden.Error{Err: garden.ContainerNotFoundError{Handle: "foo"}}.Type()).Should(Equal("ContainerNotFoundError"))
+			Ω(garden.Error{Err: garden.NewForbiddenError("nope")}.Type()).Should(Equal("ForbiddenError"))
+		})
+
+		It("is empty for untyped errors", func() {
+			Ω(garden.NewError("boom").Type()).Should(BeEmpty())
+		})
+	})
+})
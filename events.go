@@ -0,0 +1,87 @@
+package garden
+
+import "time"
+
+type EventKind string
+
+const (
+	EventContainerCreated   EventKind = "container-created"
+	EventContainerStopped   EventKind = "container-stopped"
+	EventContainerDestroyed EventKind = "container-destroyed"
+
+	// EventContainerReaped is emitted instead of EventContainerDestroyed when
+	// the server destroys a container whose grace time has elapsed.
+	EventContainerReaped EventKind = "container-reaped"
+
+	// EventContainerOOM is emitted the first time the server sees "oom" in a
+	// container's ContainerInfo.Events, i.e. when a process run through the
+	// server exits or when the container's info is requested.
+	EventContainerOOM EventKind = "container-oom"
+
+	EventProcessSpawned EventKind = "process-spawned"
+	EventProcessExited  EventKind = "process-exited"
+
+	EventPropertyChanged EventKind = "property-changed"
+)
+
+// Event describes a change in the lifecycle of a container, as observed by
+// the server.
+type Event struct {
+	Kind   EventKind `json:"kind"`
+	Handle string    `json:"handle"`
+	Time   time.Time `json:"time"`
+
+	// ProcessID is set for process events.
+	ProcessID string `json:"process_id,omitempty"`
+
+	// ExitStatus is set for EventProcessExited, unless waiting for the process
+	// failed.
+	ExitStatus *int `json:"exit_status,omitempty"`
+
+	// Property is set for EventPropertyChanged. Value is nil if the property
+	// was removed.
+	Property string  `json:"property,omitempty"`
+	Value    *string `json:"value,omitempty"`
+}
+
+// EventFilter selects events by handle and kind. Empty fields match
+// everything.
+type EventFilter struct {
+	Handles []string
+	Kinds   []EventKind
+}
+
+func (f EventFilter) Matches(event Event) bool {
+	return (len(f.Handles) == 0 || containsString(f.Handles, event.Handle)) &&
+		(len(f.Kinds) == 0 || containsKind(f.Kinds, event.Kind))
+}
+
+// EventStream is a stream of events from the server.
+type EventStream interface {
+	// Next blocks until the next event arrives. It returns an error once the
+	// stream has ended, e.g. because it was closed, the server stopped or the
+	// subscriber fell too far behind.
+	Next() (Event, error)
+
+	Close() error
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsKind(kinds []EventKind, kind EventKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -76,6 +76,38 @@ type FakeContainer struct {
 		result1 garden.MemoryLimits
 		result2 error
 	}
+	SetBandwidthLimitsStub        func(limits garden.BandwidthLimits) error
+	setBandwidthLimitsMutex       sync.RWMutex
+	setBandwidthLimitsArgsForCall []struct {
+		limits garden.BandwidthLimits
+	}
+	setBandwidthLimitsReturns struct {
+		result1 error
+	}
+	SetCPULimitsStub        func(limits garden.CPULimits) error
+	setCPULimitsMutex       sync.RWMutex
+	setCPULimitsArgsForCall []struct {
+		limits garden.CPULimits
+	}
+	setCPULimitsReturns struct {
+		result1 error
+	}
+	SetDiskLimitsStub        func(limits garden.DiskLimits) error
+	setDiskLimitsMutex       sync.RWMutex
+	setDiskLimitsArgsForCall []struct {
+		limits garden.DiskLimits
+	}
+	setDiskLimitsReturns struct {
+		result1 error
+	}
+	SetMemoryLimitsStub        func(limits garden.MemoryLimits) error
+	setMemoryLimitsMutex       sync.RWMutex
+	setMemoryLimitsArgsForCall []struct {
+		limits garden.MemoryLimits
+	}
+	setMemoryLimitsReturns struct {
+		result1 error
+	}
 	NetInStub        func(hostPort, containerPort uint32) (uint32, uint32, error)
 	netInMutex       sync.RWMutex
 	netInArgsForCall []struct {
@@ -115,6 +147,22 @@ type FakeContainer struct {
 		result1 garden.Process
 		result2 error
 	}
+	ProcessesStub        func() ([]garden.ProcessInfo, error)
+	processesMutex       sync.RWMutex
+	processesArgsForCall []struct{}
+	processesReturns     struct {
+		result1 []garden.ProcessInfo
+		result2 error
+	}
+	ProcessStub        func(processID string) (garden.ProcessInfo, error)
+	processMutex       sync.RWMutex
+	processArgsForCall []struct {
+		processID string
+	}
+	processReturns struct {
+		result1 garden.ProcessInfo
+		result2 error
+	}
 	MetricsStub        func() (garden.Metrics, error)
 	metricsMutex       sync.RWMutex
 	metricsArgsForCall []struct{}
@@ -422,6 +470,138 @@ func (fake *FakeContainer) CurrentMemoryLimitsReturns(result1 garden.MemoryLimit
 	}{result1, result2}
 }
 
+func (fake *FakeContainer) SetBandwidthLimits(limits garden.BandwidthLimits) error {
+	fake.setBandwidthLimitsMutex.Lock()
+	fake.setBandwidthLimitsArgsForCall = append(fake.setBandwidthLimitsArgsForCall, struct {
+		limits garden.BandwidthLimits
+	}{limits})
+	fake.recordInvocation("SetBandwidthLimits", []interface{}{limits})
+	fake.setBandwidthLimitsMutex.Unlock()
+	if fake.SetBandwidthLimitsStub != nil {
+		return fake.SetBandwidthLimitsStub(limits)
+	} else {
+		return fake.setBandwidthLimitsReturns.result1
+	}
+}
+
+func (fake *FakeContainer) SetBandwidthLimitsCallCount() int {
+	fake.setBandwidthLimitsMutex.RLock()
+	defer fake.setBandwidthLimitsMutex.RUnlock()
+	return len(fake.setBandwidthLimitsArgsForCall)
+}
+
+func (fake *FakeContainer) SetBandwidthLimitsArgsForCall(i int) garden.BandwidthLimits {
+	fake.setBandwidthLimitsMutex.RLock()
+	defer fake.setBandwidthLimitsMutex.RUnlock()
+	return fake.setBandwidthLimitsArgsForCall[i].limits
+}
+
+func (fake *FakeContainer) SetBandwidthLimitsReturns(result1 error) {
+	fake.SetBandwidthLimitsStub = nil
+	fake.setBandwidthLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainer) SetCPULimits(limits garden.CPULimits) error {
+	fake.setCPULimitsMutex.Lock()
+	fake.setCPULimitsArgsForCall = append(fake.setCPULimitsArgsForCall, struct {
+		limits garden.CPULimits
+	}{limits})
+	fake.recordInvocation("SetCPULimits", []interface{}{limits})
+	fake.setCPULimitsMutex.Unlock()
+	if fake.SetCPULimitsStub != nil {
+		return fake.SetCPULimitsStub(limits)
+	} else {
+		return fake.setCPULimitsReturns.result1
+	}
+}
+
+func (fake *FakeContainer) SetCPULimitsCallCount() int {
+	fake.setCPULimitsMutex.RLock()
+	defer fake.setCPULimitsMutex.RUnlock()
+	return len(fake.setCPULimitsArgsForCall)
+}
+
+func (fake *FakeContainer) SetCPULimitsArgsForCall(i int) garden.CPULimits {
+	fake.setCPULimitsMutex.RLock()
+	defer fake.setCPULimitsMutex.RUnlock()
+	return fake.setCPULimitsArgsForCall[i].limits
+}
+
+func (fake *FakeContainer) SetCPULimitsReturns(result1 error) {
+	fake.SetCPULimitsStub = nil
+	fake.setCPULimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainer) SetDiskLimits(limits garden.DiskLimits) error {
+	fake.setDiskLimitsMutex.Lock()
+	fake.setDiskLimitsArgsForCall = append(fake.setDiskLimitsArgsForCall, struct {
+		limits garden.DiskLimits
+	}{limits})
+	fake.recordInvocation("SetDiskLimits", []interface{}{limits})
+	fake.setDiskLimitsMutex.Unlock()
+	if fake.SetDiskLimitsStub != nil {
+		return fake.SetDiskLimitsStub(limits)
+	} else {
+		return fake.setDiskLimitsReturns.result1
+	}
+}
+
+func (fake *FakeContainer) SetDiskLimitsCallCount() int {
+	fake.setDiskLimitsMutex.RLock()
+	defer fake.setDiskLimitsMutex.RUnlock()
+	return len(fake.setDiskLimitsArgsForCall)
+}
+
+func (fake *FakeContainer) SetDiskLimitsArgsForCall(i int) garden.DiskLimits {
+	fake.setDiskLimitsMutex.RLock()
+	defer fake.setDiskLimitsMutex.RUnlock()
+	return fake.setDiskLimitsArgsForCall[i].limits
+}
+
+func (fake *FakeContainer) SetDiskLimitsReturns(result1 error) {
+	fake.SetDiskLimitsStub = nil
+	fake.setDiskLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainer) SetMemoryLimits(limits garden.MemoryLimits) error {
+	fake.setMemoryLimitsMutex.Lock()
+	fake.setMemoryLimitsArgsForCall = append(fake.setMemoryLimitsArgsForCall, struct {
+		limits garden.MemoryLimits
+	}{limits})
+	fake.recordInvocation("SetMemoryLimits", []interface{}{limits})
+	fake.setMemoryLimitsMutex.Unlock()
+	if fake.SetMemoryLimitsStub != nil {
+		return fake.SetMemoryLimitsStub(limits)
+	} else {
+		return fake.setMemoryLimitsReturns.result1
+	}
+}
+
+func (fake *FakeContainer) SetMemoryLimitsCallCount() int {
+	fake.setMemoryLimitsMutex.RLock()
+	defer fake.setMemoryLimitsMutex.RUnlock()
+	return len(fake.setMemoryLimitsArgsForCall)
+}
+
+func (fake *FakeContainer) SetMemoryLimitsArgsForCall(i int) garden.MemoryLimits {
+	fake.setMemoryLimitsMutex.RLock()
+	defer fake.setMemoryLimitsMutex.RUnlock()
+	return fake.setMemoryLimitsArgsForCall[i].limits
+}
+
+func (fake *FakeContainer) SetMemoryLimitsReturns(result1 error) {
+	fake.SetMemoryLimitsStub = nil
+	fake.setMemoryLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainer) NetIn(hostPort uint32, containerPort uint32) (uint32, uint32, error) {
 	fake.netInMutex.Lock()
 	fake.netInArgsForCall = append(fake.netInArgsForCall, struct {
@@ -561,6 +741,66 @@ func (fake *FakeContainer) AttachReturns(result1 garden.Process, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeContainer) Processes() ([]garden.ProcessInfo, error) {
+	fake.processesMutex.Lock()
+	fake.processesArgsForCall = append(fake.processesArgsForCall, struct{}{})
+	fake.recordInvocation("Processes", []interface{}{})
+	fake.processesMutex.Unlock()
+	if fake.ProcessesStub != nil {
+		return fake.ProcessesStub()
+	} else {
+		return fake.processesReturns.result1, fake.processesReturns.result2
+	}
+}
+
+func (fake *FakeContainer) ProcessesCallCount() int {
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	return len(fake.processesArgsForCall)
+}
+
+func (fake *FakeContainer) ProcessesReturns(result1 []garden.ProcessInfo, result2 error) {
+	fake.ProcessesStub = nil
+	fake.processesReturns = struct {
+		result1 []garden.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainer) Process(processID string) (garden.ProcessInfo, error) {
+	fake.processMutex.Lock()
+	fake.processArgsForCall = append(fake.processArgsForCall, struct {
+		processID string
+	}{processID})
+	fake.recordInvocation("Process", []interface{}{processID})
+	fake.processMutex.Unlock()
+	if fake.ProcessStub != nil {
+		return fake.ProcessStub(processID)
+	} else {
+		return fake.processReturns.result1, fake.processReturns.result2
+	}
+}
+
+func (fake *FakeContainer) ProcessCallCount() int {
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
+	return len(fake.processArgsForCall)
+}
+
+func (fake *FakeContainer) ProcessArgsForCall(i int) string {
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
+	return fake.processArgsForCall[i].processID
+}
+
+func (fake *FakeContainer) ProcessReturns(result1 garden.ProcessInfo, result2 error) {
+	fake.ProcessStub = nil
+	fake.processReturns = struct {
+		result1 garden.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeContainer) Metrics() (garden.Metrics, error) {
 	fake.metricsMutex.Lock()
 	fake.metricsArgsForCall = append(fake.metricsArgsForCall, struct{}{})
@@ -768,6 +1008,14 @@ func (fake *FakeContainer) Invocations() map[string][][]interface{} {
 	defer fake.currentDiskLimitsMutex.RUnlock()
 	fake.currentMemoryLimitsMutex.RLock()
 	defer fake.currentMemoryLimitsMutex.RUnlock()
+	fake.setBandwidthLimitsMutex.RLock()
+	defer fake.setBandwidthLimitsMutex.RUnlock()
+	fake.setCPULimitsMutex.RLock()
+	defer fake.setCPULimitsMutex.RUnlock()
+	fake.setDiskLimitsMutex.RLock()
+	defer fake.setDiskLimitsMutex.RUnlock()
+	fake.setMemoryLimitsMutex.RLock()
+	defer fake.setMemoryLimitsMutex.RUnlock()
 	fake.netInMutex.RLock()
 	defer fake.netInMutex.RUnlock()
 	fake.netOutMutex.RLock()
@@ -776,6 +1024,10 @@ func (fake *FakeContainer) Invocations() map[string][][]interface{} {
 	defer fake.runMutex.RUnlock()
 	fake.attachMutex.RLock()
 	defer fake.attachMutex.RUnlock()
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
 	fake.metricsMutex.RLock()
 	defer fake.metricsMutex.RUnlock()
 	fake.setGraceTimeMutex.RLock()
@@ -0,0 +1,412 @@
+// This file was generated by counterfeiter
+package gardenfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+type FakeContextClient struct {
+	PingContextStub        func(ctx context.Context) error
+	pingContextMutex       sync.RWMutex
+	pingContextArgsForCall []struct {
+		ctx context.Context
+	}
+	pingContextReturns struct {
+		result1 error
+	}
+	CapacityContextStub        func(ctx context.Context) (garden.Capacity, error)
+	capacityContextMutex       sync.RWMutex
+	capacityContextArgsForCall []struct {
+		ctx context.Context
+	}
+	capacityContextReturns struct {
+		result1 garden.Capacity
+		result2 error
+	}
+	CreateContextStub        func(ctx context.Context, spec garden.ContainerSpec) (garden.Container, error)
+	createContextMutex       sync.RWMutex
+	createContextArgsForCall []struct {
+		ctx  context.Context
+		spec garden.ContainerSpec
+	}
+	createContextReturns struct {
+		result1 garden.Container
+		result2 error
+	}
+	DestroyContextStub        func(ctx context.Context, handle string) error
+	destroyContextMutex       sync.RWMutex
+	destroyContextArgsForCall []struct {
+		ctx    context.Context
+		handle string
+	}
+	destroyContextReturns struct {
+		result1 error
+	}
+	ContainersContextStub        func(ctx context.Context, properties garden.Properties) ([]garden.Container, error)
+	containersContextMutex       sync.RWMutex
+	containersContextArgsForCall []struct {
+		ctx        context.Context
+		properties garden.Properties
+	}
+	containersContextReturns struct {
+		result1 []garden.Container
+		result2 error
+	}
+	BulkInfoContextStub        func(ctx context.Context, handles []string) (map[string]garden.ContainerInfoEntry, error)
+	bulkInfoContextMutex       sync.RWMutex
+	bulkInfoContextArgsForCall []struct {
+		ctx     context.Context
+		handles []string
+	}
+	bulkInfoContextReturns struct {
+		result1 map[string]garden.ContainerInfoEntry
+		result2 error
+	}
+	BulkMetricsContextStub        func(ctx context.Context, handles []string) (map[string]garden.ContainerMetricsEntry, error)
+	bulkMetricsContextMutex       sync.RWMutex
+	bulkMetricsContextArgsForCall []struct {
+		ctx     context.Context
+		handles []string
+	}
+	bulkMetricsContextReturns struct {
+		result1 map[string]garden.ContainerMetricsEntry
+		result2 error
+	}
+	LookupContextStub        func(ctx context.Context, handle string) (garden.Container, error)
+	lookupContextMutex       sync.RWMutex
+	lookupContextArgsForCall []struct {
+		ctx    context.Context
+		handle string
+	}
+	lookupContextReturns struct {
+		result1 garden.Container
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeContextClient) PingContext(ctx context.Context) error {
+	fake.pingContextMutex.Lock()
+	fake.pingContextArgsForCall = append(fake.pingContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("PingContext", []interface{}{ctx})
+	fake.pingContextMutex.Unlock()
+	if fake.PingContextStub != nil {
+		return fake.PingContextStub(ctx)
+	} else {
+		return fake.pingContextReturns.result1
+	}
+}
+
+func (fake *FakeContextClient) PingContextCallCount() int {
+	fake.pingContextMutex.RLock()
+	defer fake.pingContextMutex.RUnlock()
+	return len(fake.pingContextArgsForCall)
+}
+
+func (fake *FakeContextClient) PingContextArgsForCall(i int) context.Context {
+	fake.pingContextMutex.RLock()
+	defer fake.pingContextMutex.RUnlock()
+	return fake.pingContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextClient) PingContextReturns(result1 error) {
+	fake.PingContextStub = nil
+	fake.pingContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextClient) CapacityContext(ctx context.Context) (garden.Capacity, error) {
+	fake.capacityContextMutex.Lock()
+	fake.capacityContextArgsForCall = append(fake.capacityContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("CapacityContext", []interface{}{ctx})
+	fake.capacityContextMutex.Unlock()
+	if fake.CapacityContextStub != nil {
+		return fake.CapacityContextStub(ctx)
+	} else {
+		return fake.capacityContextReturns.result1, fake.capacityContextReturns.result2
+	}
+}
+
+func (fake *FakeContextClient) CapacityContextCallCount() int {
+	fake.capacityContextMutex.RLock()
+	defer fake.capacityContextMutex.RUnlock()
+	return len(fake.capacityContextArgsForCall)
+}
+
+func (fake *FakeContextClient) CapacityContextArgsForCall(i int) context.Context {
+	fake.capacityContextMutex.RLock()
+	defer fake.capacityContextMutex.RUnlock()
+	return fake.capacityContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextClient) CapacityContextReturns(result1 garden.Capacity, result2 error) {
+	fake.CapacityContextStub = nil
+	fake.capacityContextReturns = struct {
+		result1 garden.Capacity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextClient) CreateContext(ctx context.Context, spec garden.ContainerSpec) (garden.Container, error) {
+	fake.createContextMutex.Lock()
+	fake.createContextArgsForCall = append(fake.createContextArgsForCall, struct {
+		ctx  context.Context
+		spec garden.ContainerSpec
+	}{ctx, spec})
+	fake.recordInvocation("CreateContext", []interface{}{ctx, spec})
+	fake.createContextMutex.Unlock()
+	if fake.CreateContextStub != nil {
+		return fake.CreateContextStub(ctx, spec)
+	} else {
+		return fake.createContextReturns.result1, fake.createContextReturns.result2
+	}
+}
+
+func (fake *FakeContextClient) CreateContextCallCount() int {
+	fake.createContextMutex.RLock()
+	defer fake.createContextMutex.RUnlock()
+	return len(fake.createContextArgsForCall)
+}
+
+func (fake *FakeContextClient) CreateContextArgsForCall(i int) (context.Context, garden.ContainerSpec) {
+	fake.createContextMutex.RLock()
+	defer fake.createContextMutex.RUnlock()
+	return fake.createContextArgsForCall[i].ctx, fake.createContextArgsForCall[i].spec
+}
+
+func (fake *FakeContextClient) CreateContextReturns(result1 garden.Container, result2 error) {
+	fake.CreateContextStub = nil
+	fake.createContextReturns = struct {
+		result1 garden.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextClient) DestroyContext(ctx context.Context, handle string) error {
+	fake.destroyContextMutex.Lock()
+	fake.destroyContextArgsForCall = append(fake.destroyContextArgsForCall, struct {
+		ctx    context.Context
+		handle string
+	}{ctx, handle})
+	fake.recordInvocation("DestroyContext", []interface{}{ctx, handle})
+	fake.destroyContextMutex.Unlock()
+	if fake.DestroyContextStub != nil {
+		return fake.DestroyContextStub(ctx, handle)
+	} else {
+		return fake.destroyContextReturns.result1
+	}
+}
+
+func (fake *FakeContextClient) DestroyContextCallCount() int {
+	fake.destroyContextMutex.RLock()
+	defer fake.destroyContextMutex.RUnlock()
+	return len(fake.destroyContextArgsForCall)
+}
+
+func (fake *FakeContextClient) DestroyContextArgsForCall(i int) (context.Context, string) {
+	fake.destroyContextMutex.RLock()
+	defer fake.destroyContextMutex.RUnlock()
+	return fake.destroyContextArgsForCall[i].ctx, fake.destroyContextArgsForCall[i].handle
+}
+
+func (fake *FakeContextClient) DestroyContextReturns(result1 error) {
+	fake.DestroyContextStub = nil
+	fake.destroyContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextClient) ContainersContext(ctx context.Context, properties garden.Properties) ([]garden.Container, error) {
+	fake.containersContextMutex.Lock()
+	fake.containersContextArgsForCall = append(fake.containersContextArgsForCall, struct {
+		ctx        context.Context
+		properties garden.Properties
+	}{ctx, properties})
+	fake.recordInvocation("ContainersContext", []interface{}{ctx, properties})
+	fake.containersContextMutex.Unlock()
+	if fake.ContainersContextStub != nil {
+		return fake.ContainersContextStub(ctx, properties)
+	} else {
+		return fake.containersContextReturns.result1, fake.containersContextReturns.result2
+	}
+}
+
+func (fake *FakeContextClient) ContainersContextCallCount() int {
+	fake.containersContextMutex.RLock()
+	defer fake.containersContextMutex.RUnlock()
+	return len(fake.containersContextArgsForCall)
+}
+
+func (fake *FakeContextClient) ContainersContextArgsForCall(i int) (context.Context, garden.Properties) {
+	fake.containersContextMutex.RLock()
+	defer fake.containersContextMutex.RUnlock()
+	return fake.containersContextArgsForCall[i].ctx, fake.containersContextArgsForCall[i].properties
+}
+
+func (fake *FakeContextClient) ContainersContextReturns(result1 []garden.Container, result2 error) {
+	fake.ContainersContextStub = nil
+	fake.containersContextReturns = struct {
+		result1 []garden.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextClient) BulkInfoContext(ctx context.Context, handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	var handlesCopy []string
+	if handles != nil {
+		handlesCopy = make([]string, len(handles))
+		copy(handlesCopy, handles)
+	}
+	fake.bulkInfoContextMutex.Lock()
+	fake.bulkInfoContextArgsForCall = append(fake.bulkInfoContextArgsForCall, struct {
+		ctx     context.Context
+		handles []string
+	}{ctx, handlesCopy})
+	fake.recordInvocation("BulkInfoContext", []interface{}{ctx, handlesCopy})
+	fake.bulkInfoContextMutex.Unlock()
+	if fake.BulkInfoContextStub != nil {
+		return fake.BulkInfoContextStub(ctx, handles)
+	} else {
+		return fake.bulkInfoContextReturns.result1, fake.bulkInfoContextReturns.result2
+	}
+}
+
+func (fake *FakeContextClient) BulkInfoContextCallCount() int {
+	fake.bulkInfoContextMutex.RLock()
+	defer fake.bulkInfoContextMutex.RUnlock()
+	return len(fake.bulkInfoContextArgsForCall)
+}
+
+func (fake *FakeContextClient) BulkInfoContextArgsForCall(i int) (context.Context, []string) {
+	fake.bulkInfoContextMutex.RLock()
+	defer fake.bulkInfoContextMutex.RUnlock()
+	return fake.bulkInfoContextArgsForCall[i].ctx, fake.bulkInfoContextArgsForCall[i].handles
+}
+
+func (fake *FakeContextClient) BulkInfoContextReturns(result1 map[string]garden.ContainerInfoEntry, result2 error) {
+	fake.BulkInfoContextStub = nil
+	fake.bulkInfoContextReturns = struct {
+		result1 map[string]garden.ContainerInfoEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextClient) BulkMetricsContext(ctx context.Context, handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	var handlesCopy []string
+	if handles != nil {
+		handlesCopy = make([]string, len(handles))
+		copy(handlesCopy, handles)
+	}
+	fake.bulkMetricsContextMutex.Lock()
+	fake.bulkMetricsContextArgsForCall = append(fake.bulkMetricsContextArgsForCall, struct {
+		ctx     context.Context
+		handles []string
+	}{ctx, handlesCopy})
+	fake.recordInvocation("BulkMetricsContext", []interface{}{ctx, handlesCopy})
+	fake.bulkMetricsContextMutex.Unlock()
+	if fake.BulkMetricsContextStub != nil {
+		return fake.BulkMetricsContextStub(ctx, handles)
+	} else {
+		return fake.bulkMetricsContextReturns.result1, fake.bulkMetricsContextReturns.result2
+	}
+}
+
+func (fake *FakeContextClient) BulkMetricsContextCallCount() int {
+	fake.bulkMetricsContextMutex.RLock()
+	defer fake.bulkMetricsContextMutex.RUnlock()
+	return len(fake.bulkMetricsContextArgsForCall)
+}
+
+func (fake *FakeContextClient) BulkMetricsContextArgsForCall(i int) (context.Context, []string) {
+	fake.bulkMetricsContextMutex.RLock()
+	defer fake.bulkMetricsContextMutex.RUnlock()
+	return fake.bulkMetricsContextArgsForCall[i].ctx, fake.bulkMetricsContextArgsForCall[i].handles
+}
+
+func (fake *FakeContextClient) BulkMetricsContextReturns(result1 map[string]garden.ContainerMetricsEntry, result2 error) {
+	fake.BulkMetricsContextStub = nil
+	fake.bulkMetricsContextReturns = struct {
+		result1 map[string]garden.ContainerMetricsEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextClient) LookupContext(ctx context.Context, handle string) (garden.Container, error) {
+	fake.lookupContextMutex.Lock()
+	fake.lookupContextArgsForCall = append(fake.lookupContextArgsForCall, struct {
+		ctx    context.Context
+		handle string
+	}{ctx, handle})
+	fake.recordInvocation("LookupContext", []interface{}{ctx, handle})
+	fake.lookupContextMutex.Unlock()
+	if fake.LookupContextStub != nil {
+		return fake.LookupContextStub(ctx, handle)
+	} else {
+		return fake.lookupContextReturns.result1, fake.lookupContextReturns.result2
+	}
+}
+
+func (fake *FakeContextClient) LookupContextCallCount() int {
+	fake.lookupContextMutex.RLock()
+	defer fake.lookupContextMutex.RUnlock()
+	return len(fake.lookupContextArgsForCall)
+}
+
+func (fake *FakeContextClient) LookupContextArgsForCall(i int) (context.Context, string) {
+	fake.lookupContextMutex.RLock()
+	defer fake.lookupContextMutex.RUnlock()
+	return fake.lookupContextArgsForCall[i].ctx, fake.lookupContextArgsForCall[i].handle
+}
+
+func (fake *FakeContextClient) LookupContextReturns(result1 garden.Container, result2 error) {
+	fake.LookupContextStub = nil
+	fake.lookupContextReturns = struct {
+		result1 garden.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pingContextMutex.RLock()
+	defer fake.pingContextMutex.RUnlock()
+	fake.capacityContextMutex.RLock()
+	defer fake.capacityContextMutex.RUnlock()
+	fake.createContextMutex.RLock()
+	defer fake.createContextMutex.RUnlock()
+	fake.destroyContextMutex.RLock()
+	defer fake.destroyContextMutex.RUnlock()
+	fake.containersContextMutex.RLock()
+	defer fake.containersContextMutex.RUnlock()
+	fake.bulkInfoContextMutex.RLock()
+	defer fake.bulkInfoContextMutex.RUnlock()
+	fake.bulkMetricsContextMutex.RLock()
+	defer fake.bulkMetricsContextMutex.RUnlock()
+	fake.lookupContextMutex.RLock()
+	defer fake.lookupContextMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeContextClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ garden.ContextClient = new(FakeContextClient)
@@ -0,0 +1,1138 @@
+// This file was generated by counterfeiter
+package gardenfakes
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+type FakeContextContainer struct {
+	StopContextStub        func(ctx context.Context, kill bool) error
+	stopContextMutex       sync.RWMutex
+	stopContextArgsForCall []struct {
+		ctx  context.Context
+		kill bool
+	}
+	stopContextReturns struct {
+		result1 error
+	}
+	InfoContextStub        func(ctx context.Context) (garden.ContainerInfo, error)
+	infoContextMutex       sync.RWMutex
+	infoContextArgsForCall []struct {
+		ctx context.Context
+	}
+	infoContextReturns struct {
+		result1 garden.ContainerInfo
+		result2 error
+	}
+	StreamInContextStub        func(ctx context.Context, spec garden.StreamInSpec) error
+	streamInContextMutex       sync.RWMutex
+	streamInContextArgsForCall []struct {
+		ctx  context.Context
+		spec garden.StreamInSpec
+	}
+	streamInContextReturns struct {
+		result1 error
+	}
+	StreamOutContextStub        func(ctx context.Context, spec garden.StreamOutSpec) (io.ReadCloser, error)
+	streamOutContextMutex       sync.RWMutex
+	streamOutContextArgsForCall []struct {
+		ctx  context.Context
+		spec garden.StreamOutSpec
+	}
+	streamOutContextReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	CurrentBandwidthLimitsContextStub        func(ctx context.Context) (garden.BandwidthLimits, error)
+	currentBandwidthLimitsContextMutex       sync.RWMutex
+	currentBandwidthLimitsContextArgsForCall []struct {
+		ctx context.Context
+	}
+	currentBandwidthLimitsContextReturns struct {
+		result1 garden.BandwidthLimits
+		result2 error
+	}
+	CurrentCPULimitsContextStub        func(ctx context.Context) (garden.CPULimits, error)
+	currentCPULimitsContextMutex       sync.RWMutex
+	currentCPULimitsContextArgsForCall []struct {
+		ctx context.Context
+	}
+	currentCPULimitsContextReturns struct {
+		result1 garden.CPULimits
+		result2 error
+	}
+	CurrentDiskLimitsContextStub        func(ctx context.Context) (garden.DiskLimits, error)
+	currentDiskLimitsContextMutex       sync.RWMutex
+	currentDiskLimitsContextArgsForCall []struct {
+		ctx context.Context
+	}
+	currentDiskLimitsContextReturns struct {
+		result1 garden.DiskLimits
+		result2 error
+	}
+	CurrentMemoryLimitsContextStub        func(ctx context.Context) (garden.MemoryLimits, error)
+	currentMemoryLimitsContextMutex       sync.RWMutex
+	currentMemoryLimitsContextArgsForCall []struct {
+		ctx context.Context
+	}
+	currentMemoryLimitsContextReturns struct {
+		result1 garden.MemoryLimits
+		result2 error
+	}
+	SetBandwidthLimitsContextStub        func(ctx context.Context, limits garden.BandwidthLimits) error
+	setBandwidthLimitsContextMutex       sync.RWMutex
+	setBandwidthLimitsContextArgsForCall []struct {
+		ctx    context.Context
+		limits garden.BandwidthLimits
+	}
+	setBandwidthLimitsContextReturns struct {
+		result1 error
+	}
+	SetCPULimitsContextStub        func(ctx context.Context, limits garden.CPULimits) error
+	setCPULimitsContextMutex       sync.RWMutex
+	setCPULimitsContextArgsForCall []struct {
+		ctx    context.Context
+		limits garden.CPULimits
+	}
+	setCPULimitsContextReturns struct {
+		result1 error
+	}
+	SetDiskLimitsContextStub        func(ctx context.Context, limits garden.DiskLimits) error
+	setDiskLimitsContextMutex       sync.RWMutex
+	setDiskLimitsContextArgsForCall []struct {
+		ctx    context.Context
+		limits garden.DiskLimits
+	}
+	setDiskLimitsContextReturns struct {
+		result1 error
+	}
+	SetMemoryLimitsContextStub        func(ctx context.Context, limits garden.MemoryLimits) error
+	setMemoryLimitsContextMutex       sync.RWMutex
+	setMemoryLimitsContextArgsForCall []struct {
+		ctx    context.Context
+		limits garden.MemoryLimits
+	}
+	setMemoryLimitsContextReturns struct {
+		result1 error
+	}
+	NetInContextStub        func(ctx context.Context, hostPort, containerPort uint32) (uint32, uint32, error)
+	netInContextMutex       sync.RWMutex
+	netInContextArgsForCall []struct {
+		ctx           context.Context
+		hostPort      uint32
+		containerPort uint32
+	}
+	netInContextReturns struct {
+		result1 uint32
+		result2 uint32
+		result3 error
+	}
+	NetOutContextStub        func(ctx context.Context, netOutRule garden.NetOutRule) error
+	netOutContextMutex       sync.RWMutex
+	netOutContextArgsForCall []struct {
+		ctx        context.Context
+		netOutRule garden.NetOutRule
+	}
+	netOutContextReturns struct {
+		result1 error
+	}
+	RunContextStub        func(ctx context.Context, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
+	runContextMutex       sync.RWMutex
+	runContextArgsForCall []struct {
+		ctx  context.Context
+		spec garden.ProcessSpec
+		io   garden.ProcessIO
+	}
+	runContextReturns struct {
+		result1 garden.Process
+		result2 error
+	}
+	AttachContextStub        func(ctx context.Context, processID string, io garden.ProcessIO) (garden.Process, error)
+	attachContextMutex       sync.RWMutex
+	attachContextArgsForCall []struct {
+		ctx       context.Context
+		processID string
+		io        garden.ProcessIO
+	}
+	attachContextReturns struct {
+		result1 garden.Process
+		result2 error
+	}
+	ProcessesContextStub        func(ctx context.Context) ([]garden.ProcessInfo, error)
+	processesContextMutex       sync.RWMutex
+	processesContextArgsForCall []struct {
+		ctx context.Context
+	}
+	processesContextReturns struct {
+		result1 []garden.ProcessInfo
+		result2 error
+	}
+	ProcessContextStub        func(ctx context.Context, processID string) (garden.ProcessInfo, error)
+	processContextMutex       sync.RWMutex
+	processContextArgsForCall []struct {
+		ctx       context.Context
+		processID string
+	}
+	processContextReturns struct {
+		result1 garden.ProcessInfo
+		result2 error
+	}
+	MetricsContextStub        func(ctx context.Context) (garden.Metrics, error)
+	metricsContextMutex       sync.RWMutex
+	metricsContextArgsForCall []struct {
+		ctx context.Context
+	}
+	metricsContextReturns struct {
+		result1 garden.Metrics
+		result2 error
+	}
+	SetGraceTimeContextStub        func(ctx context.Context, graceTime time.Duration) error
+	setGraceTimeContextMutex       sync.RWMutex
+	setGraceTimeContextArgsForCall []struct {
+		ctx       context.Context
+		graceTime time.Duration
+	}
+	setGraceTimeContextReturns struct {
+		result1 error
+	}
+	PropertiesContextStub        func(ctx context.Context) (garden.Properties, error)
+	propertiesContextMutex       sync.RWMutex
+	propertiesContextArgsForCall []struct {
+		ctx context.Context
+	}
+	propertiesContextReturns struct {
+		result1 garden.Properties
+		result2 error
+	}
+	PropertyContextStub        func(ctx context.Context, name string) (string, error)
+	propertyContextMutex       sync.RWMutex
+	propertyContextArgsForCall []struct {
+		ctx  context.Context
+		name string
+	}
+	propertyContextReturns struct {
+		result1 string
+		result2 error
+	}
+	SetPropertyContextStub        func(ctx context.Context, name string, value string) error
+	setPropertyContextMutex       sync.RWMutex
+	setPropertyContextArgsForCall []struct {
+		ctx   context.Context
+		name  string
+		value string
+	}
+	setPropertyContextReturns struct {
+		result1 error
+	}
+	RemovePropertyContextStub        func(ctx context.Context, name string) error
+	removePropertyContextMutex       sync.RWMutex
+	removePropertyContextArgsForCall []struct {
+		ctx  context.Context
+		name string
+	}
+	removePropertyContextReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeContextContainer) StopContext(ctx context.Context, kill bool) error {
+	fake.stopContextMutex.Lock()
+	fake.stopContextArgsForCall = append(fake.stopContextArgsForCall, struct {
+		ctx  context.Context
+		kill bool
+	}{ctx, kill})
+	fake.recordInvocation("StopContext", []interface{}{ctx, kill})
+	fake.stopContextMutex.Unlock()
+	if fake.StopContextStub != nil {
+		return fake.StopContextStub(ctx, kill)
+	} else {
+		return fake.stopContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) StopContextCallCount() int {
+	fake.stopContextMutex.RLock()
+	defer fake.stopContextMutex.RUnlock()
+	return len(fake.stopContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) StopContextArgsForCall(i int) (context.Context, bool) {
+	fake.stopContextMutex.RLock()
+	defer fake.stopContextMutex.RUnlock()
+	return fake.stopContextArgsForCall[i].ctx, fake.stopContextArgsForCall[i].kill
+}
+
+func (fake *FakeContextContainer) StopContextReturns(result1 error) {
+	fake.StopContextStub = nil
+	fake.stopContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) InfoContext(ctx context.Context) (garden.ContainerInfo, error) {
+	fake.infoContextMutex.Lock()
+	fake.infoContextArgsForCall = append(fake.infoContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("InfoContext", []interface{}{ctx})
+	fake.infoContextMutex.Unlock()
+	if fake.InfoContextStub != nil {
+		return fake.InfoContextStub(ctx)
+	} else {
+		return fake.infoContextReturns.result1, fake.infoContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) InfoContextCallCount() int {
+	fake.infoContextMutex.RLock()
+	defer fake.infoContextMutex.RUnlock()
+	return len(fake.infoContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) InfoContextArgsForCall(i int) context.Context {
+	fake.infoContextMutex.RLock()
+	defer fake.infoContextMutex.RUnlock()
+	return fake.infoContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) InfoContextReturns(result1 garden.ContainerInfo, result2 error) {
+	fake.InfoContextStub = nil
+	fake.infoContextReturns = struct {
+		result1 garden.ContainerInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) StreamInContext(ctx context.Context, spec garden.StreamInSpec) error {
+	fake.streamInContextMutex.Lock()
+	fake.streamInContextArgsForCall = append(fake.streamInContextArgsForCall, struct {
+		ctx  context.Context
+		spec garden.StreamInSpec
+	}{ctx, spec})
+	fake.recordInvocation("StreamInContext", []interface{}{ctx, spec})
+	fake.streamInContextMutex.Unlock()
+	if fake.StreamInContextStub != nil {
+		return fake.StreamInContextStub(ctx, spec)
+	} else {
+		return fake.streamInContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) StreamInContextCallCount() int {
+	fake.streamInContextMutex.RLock()
+	defer fake.streamInContextMutex.RUnlock()
+	return len(fake.streamInContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) StreamInContextArgsForCall(i int) (context.Context, garden.StreamInSpec) {
+	fake.streamInContextMutex.RLock()
+	defer fake.streamInContextMutex.RUnlock()
+	return fake.streamInContextArgsForCall[i].ctx, fake.streamInContextArgsForCall[i].spec
+}
+
+func (fake *FakeContextContainer) StreamInContextReturns(result1 error) {
+	fake.StreamInContextStub = nil
+	fake.streamInContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) StreamOutContext(ctx context.Context, spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	fake.streamOutContextMutex.Lock()
+	fake.streamOutContextArgsForCall = append(fake.streamOutContextArgsForCall, struct {
+		ctx  context.Context
+		spec garden.StreamOutSpec
+	}{ctx, spec})
+	fake.recordInvocation("StreamOutContext", []interface{}{ctx, spec})
+	fake.streamOutContextMutex.Unlock()
+	if fake.StreamOutContextStub != nil {
+		return fake.StreamOutContextStub(ctx, spec)
+	} else {
+		return fake.streamOutContextReturns.result1, fake.streamOutContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) StreamOutContextCallCount() int {
+	fake.streamOutContextMutex.RLock()
+	defer fake.streamOutContextMutex.RUnlock()
+	return len(fake.streamOutContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) StreamOutContextArgsForCall(i int) (context.Context, garden.StreamOutSpec) {
+	fake.streamOutContextMutex.RLock()
+	defer fake.streamOutContextMutex.RUnlock()
+	return fake.streamOutContextArgsForCall[i].ctx, fake.streamOutContextArgsForCall[i].spec
+}
+
+func (fake *FakeContextContainer) StreamOutContextReturns(result1 io.ReadCloser, result2 error) {
+	fake.StreamOutContextStub = nil
+	fake.streamOutContextReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) CurrentBandwidthLimitsContext(ctx context.Context) (garden.BandwidthLimits, error) {
+	fake.currentBandwidthLimitsContextMutex.Lock()
+	fake.currentBandwidthLimitsContextArgsForCall = append(fake.currentBandwidthLimitsContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("CurrentBandwidthLimitsContext", []interface{}{ctx})
+	fake.currentBandwidthLimitsContextMutex.Unlock()
+	if fake.CurrentBandwidthLimitsContextStub != nil {
+		return fake.CurrentBandwidthLimitsContextStub(ctx)
+	} else {
+		return fake.currentBandwidthLimitsContextReturns.result1, fake.currentBandwidthLimitsContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) CurrentBandwidthLimitsContextCallCount() int {
+	fake.currentBandwidthLimitsContextMutex.RLock()
+	defer fake.currentBandwidthLimitsContextMutex.RUnlock()
+	return len(fake.currentBandwidthLimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) CurrentBandwidthLimitsContextArgsForCall(i int) context.Context {
+	fake.currentBandwidthLimitsContextMutex.RLock()
+	defer fake.currentBandwidthLimitsContextMutex.RUnlock()
+	return fake.currentBandwidthLimitsContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) CurrentBandwidthLimitsContextReturns(result1 garden.BandwidthLimits, result2 error) {
+	fake.CurrentBandwidthLimitsContextStub = nil
+	fake.currentBandwidthLimitsContextReturns = struct {
+		result1 garden.BandwidthLimits
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) CurrentCPULimitsContext(ctx context.Context) (garden.CPULimits, error) {
+	fake.currentCPULimitsContextMutex.Lock()
+	fake.currentCPULimitsContextArgsForCall = append(fake.currentCPULimitsContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("CurrentCPULimitsContext", []interface{}{ctx})
+	fake.currentCPULimitsContextMutex.Unlock()
+	if fake.CurrentCPULimitsContextStub != nil {
+		return fake.CurrentCPULimitsContextStub(ctx)
+	} else {
+		return fake.currentCPULimitsContextReturns.result1, fake.currentCPULimitsContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) CurrentCPULimitsContextCallCount() int {
+	fake.currentCPULimitsContextMutex.RLock()
+	defer fake.currentCPULimitsContextMutex.RUnlock()
+	return len(fake.currentCPULimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) CurrentCPULimitsContextArgsForCall(i int) context.Context {
+	fake.currentCPULimitsContextMutex.RLock()
+	defer fake.currentCPULimitsContextMutex.RUnlock()
+	return fake.currentCPULimitsContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) CurrentCPULimitsContextReturns(result1 garden.CPULimits, result2 error) {
+	fake.CurrentCPULimitsContextStub = nil
+	fake.currentCPULimitsContextReturns = struct {
+		result1 garden.CPULimits
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) CurrentDiskLimitsContext(ctx context.Context) (garden.DiskLimits, error) {
+	fake.currentDiskLimitsContextMutex.Lock()
+	fake.currentDiskLimitsContextArgsForCall = append(fake.currentDiskLimitsContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("CurrentDiskLimitsContext", []interface{}{ctx})
+	fake.currentDiskLimitsContextMutex.Unlock()
+	if fake.CurrentDiskLimitsContextStub != nil {
+		return fake.CurrentDiskLimitsContextStub(ctx)
+	} else {
+		return fake.currentDiskLimitsContextReturns.result1, fake.currentDiskLimitsContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) CurrentDiskLimitsContextCallCount() int {
+	fake.currentDiskLimitsContextMutex.RLock()
+	defer fake.currentDiskLimitsContextMutex.RUnlock()
+	return len(fake.currentDiskLimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) CurrentDiskLimitsContextArgsForCall(i int) context.Context {
+	fake.currentDiskLimitsContextMutex.RLock()
+	defer fake.currentDiskLimitsContextMutex.RUnlock()
+	return fake.currentDiskLimitsContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) CurrentDiskLimitsContextReturns(result1 garden.DiskLimits, result2 error) {
+	fake.CurrentDiskLimitsContextStub = nil
+	fake.currentDiskLimitsContextReturns = struct {
+		result1 garden.DiskLimits
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) CurrentMemoryLimitsContext(ctx context.Context) (garden.MemoryLimits, error) {
+	fake.currentMemoryLimitsContextMutex.Lock()
+	fake.currentMemoryLimitsContextArgsForCall = append(fake.currentMemoryLimitsContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("CurrentMemoryLimitsContext", []interface{}{ctx})
+	fake.currentMemoryLimitsContextMutex.Unlock()
+	if fake.CurrentMemoryLimitsContextStub != nil {
+		return fake.CurrentMemoryLimitsContextStub(ctx)
+	} else {
+		return fake.currentMemoryLimitsContextReturns.result1, fake.currentMemoryLimitsContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) CurrentMemoryLimitsContextCallCount() int {
+	fake.currentMemoryLimitsContextMutex.RLock()
+	defer fake.currentMemoryLimitsContextMutex.RUnlock()
+	return len(fake.currentMemoryLimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) CurrentMemoryLimitsContextArgsForCall(i int) context.Context {
+	fake.currentMemoryLimitsContextMutex.RLock()
+	defer fake.currentMemoryLimitsContextMutex.RUnlock()
+	return fake.currentMemoryLimitsContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) CurrentMemoryLimitsContextReturns(result1 garden.MemoryLimits, result2 error) {
+	fake.CurrentMemoryLimitsContextStub = nil
+	fake.currentMemoryLimitsContextReturns = struct {
+		result1 garden.MemoryLimits
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) SetBandwidthLimitsContext(ctx context.Context, limits garden.BandwidthLimits) error {
+	fake.setBandwidthLimitsContextMutex.Lock()
+	fake.setBandwidthLimitsContextArgsForCall = append(fake.setBandwidthLimitsContextArgsForCall, struct {
+		ctx    context.Context
+		limits garden.BandwidthLimits
+	}{ctx, limits})
+	fake.recordInvocation("SetBandwidthLimitsContext", []interface{}{ctx, limits})
+	fake.setBandwidthLimitsContextMutex.Unlock()
+	if fake.SetBandwidthLimitsContextStub != nil {
+		return fake.SetBandwidthLimitsContextStub(ctx, limits)
+	} else {
+		return fake.setBandwidthLimitsContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) SetBandwidthLimitsContextCallCount() int {
+	fake.setBandwidthLimitsContextMutex.RLock()
+	defer fake.setBandwidthLimitsContextMutex.RUnlock()
+	return len(fake.setBandwidthLimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) SetBandwidthLimitsContextArgsForCall(i int) (context.Context, garden.BandwidthLimits) {
+	fake.setBandwidthLimitsContextMutex.RLock()
+	defer fake.setBandwidthLimitsContextMutex.RUnlock()
+	return fake.setBandwidthLimitsContextArgsForCall[i].ctx, fake.setBandwidthLimitsContextArgsForCall[i].limits
+}
+
+func (fake *FakeContextContainer) SetBandwidthLimitsContextReturns(result1 error) {
+	fake.SetBandwidthLimitsContextStub = nil
+	fake.setBandwidthLimitsContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) SetCPULimitsContext(ctx context.Context, limits garden.CPULimits) error {
+	fake.setCPULimitsContextMutex.Lock()
+	fake.setCPULimitsContextArgsForCall = append(fake.setCPULimitsContextArgsForCall, struct {
+		ctx    context.Context
+		limits garden.CPULimits
+	}{ctx, limits})
+	fake.recordInvocation("SetCPULimitsContext", []interface{}{ctx, limits})
+	fake.setCPULimitsContextMutex.Unlock()
+	if fake.SetCPULimitsContextStub != nil {
+		return fake.SetCPULimitsContextStub(ctx, limits)
+	} else {
+		return fake.setCPULimitsContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) SetCPULimitsContextCallCount() int {
+	fake.setCPULimitsContextMutex.RLock()
+	defer fake.setCPULimitsContextMutex.RUnlock()
+	return len(fake.setCPULimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) SetCPULimitsContextArgsForCall(i int) (context.Context, garden.CPULimits) {
+	fake.setCPULimitsContextMutex.RLock()
+	defer fake.setCPULimitsContextMutex.RUnlock()
+	return fake.setCPULimitsContextArgsForCall[i].ctx, fake.setCPULimitsContextArgsForCall[i].limits
+}
+
+func (fake *FakeContextContainer) SetCPULimitsContextReturns(result1 error) {
+	fake.SetCPULimitsContextStub = nil
+	fake.setCPULimitsContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) SetDiskLimitsContext(ctx context.Context, limits garden.DiskLimits) error {
+	fake.setDiskLimitsContextMutex.Lock()
+	fake.setDiskLimitsContextArgsForCall = append(fake.setDiskLimitsContextArgsForCall, struct {
+		ctx    context.Context
+		limits garden.DiskLimits
+	}{ctx, limits})
+	fake.recordInvocation("SetDiskLimitsContext", []interface{}{ctx, limits})
+	fake.setDiskLimitsContextMutex.Unlock()
+	if fake.SetDiskLimitsContextStub != nil {
+		return fake.SetDiskLimitsContextStub(ctx, limits)
+	} else {
+		return fake.setDiskLimitsContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) SetDiskLimitsContextCallCount() int {
+	fake.setDiskLimitsContextMutex.RLock()
+	defer fake.setDiskLimitsContextMutex.RUnlock()
+	return len(fake.setDiskLimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) SetDiskLimitsContextArgsForCall(i int) (context.Context, garden.DiskLimits) {
+	fake.setDiskLimitsContextMutex.RLock()
+	defer fake.setDiskLimitsContextMutex.RUnlock()
+	return fake.setDiskLimitsContextArgsForCall[i].ctx, fake.setDiskLimitsContextArgsForCall[i].limits
+}
+
+func (fake *FakeContextContainer) SetDiskLimitsContextReturns(result1 error) {
+	fake.SetDiskLimitsContextStub = nil
+	fake.setDiskLimitsContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) SetMemoryLimitsContext(ctx context.Context, limits garden.MemoryLimits) error {
+	fake.setMemoryLimitsContextMutex.Lock()
+	fake.setMemoryLimitsContextArgsForCall = append(fake.setMemoryLimitsContextArgsForCall, struct {
+		ctx    context.Context
+		limits garden.MemoryLimits
+	}{ctx, limits})
+	fake.recordInvocation("SetMemoryLimitsContext", []interface{}{ctx, limits})
+	fake.setMemoryLimitsContextMutex.Unlock()
+	if fake.SetMemoryLimitsContextStub != nil {
+		return fake.SetMemoryLimitsContextStub(ctx, limits)
+	} else {
+		return fake.setMemoryLimitsContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) SetMemoryLimitsContextCallCount() int {
+	fake.setMemoryLimitsContextMutex.RLock()
+	defer fake.setMemoryLimitsContextMutex.RUnlock()
+	return len(fake.setMemoryLimitsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) SetMemoryLimitsContextArgsForCall(i int) (context.Context, garden.MemoryLimits) {
+	fake.setMemoryLimitsContextMutex.RLock()
+	defer fake.setMemoryLimitsContextMutex.RUnlock()
+	return fake.setMemoryLimitsContextArgsForCall[i].ctx, fake.setMemoryLimitsContextArgsForCall[i].limits
+}
+
+func (fake *FakeContextContainer) SetMemoryLimitsContextReturns(result1 error) {
+	fake.SetMemoryLimitsContextStub = nil
+	fake.setMemoryLimitsContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) NetInContext(ctx context.Context, hostPort uint32, containerPort uint32) (uint32, uint32, error) {
+	fake.netInContextMutex.Lock()
+	fake.netInContextArgsForCall = append(fake.netInContextArgsForCall, struct {
+		ctx           context.Context
+		hostPort      uint32
+		containerPort uint32
+	}{ctx, hostPort, containerPort})
+	fake.recordInvocation("NetInContext", []interface{}{ctx, hostPort, containerPort})
+	fake.netInContextMutex.Unlock()
+	if fake.NetInContextStub != nil {
+		return fake.NetInContextStub(ctx, hostPort, containerPort)
+	} else {
+		return fake.netInContextReturns.result1, fake.netInContextReturns.result2, fake.netInContextReturns.result3
+	}
+}
+
+func (fake *FakeContextContainer) NetInContextCallCount() int {
+	fake.netInContextMutex.RLock()
+	defer fake.netInContextMutex.RUnlock()
+	return len(fake.netInContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) NetInContextArgsForCall(i int) (context.Context, uint32, uint32) {
+	fake.netInContextMutex.RLock()
+	defer fake.netInContextMutex.RUnlock()
+	return fake.netInContextArgsForCall[i].ctx, fake.netInContextArgsForCall[i].hostPort, fake.netInContextArgsForCall[i].containerPort
+}
+
+func (fake *FakeContextContainer) NetInContextReturns(result1 uint32, result2 uint32, result3 error) {
+	fake.NetInContextStub = nil
+	fake.netInContextReturns = struct {
+		result1 uint32
+		result2 uint32
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeContextContainer) NetOutContext(ctx context.Context, netOutRule garden.NetOutRule) error {
+	fake.netOutContextMutex.Lock()
+	fake.netOutContextArgsForCall = append(fake.netOutContextArgsForCall, struct {
+		ctx        context.Context
+		netOutRule garden.NetOutRule
+	}{ctx, netOutRule})
+	fake.recordInvocation("NetOutContext", []interface{}{ctx, netOutRule})
+	fake.netOutContextMutex.Unlock()
+	if fake.NetOutContextStub != nil {
+		return fake.NetOutContextStub(ctx, netOutRule)
+	} else {
+		return fake.netOutContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) NetOutContextCallCount() int {
+	fake.netOutContextMutex.RLock()
+	defer fake.netOutContextMutex.RUnlock()
+	return len(fake.netOutContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) NetOutContextArgsForCall(i int) (context.Context, garden.NetOutRule) {
+	fake.netOutContextMutex.RLock()
+	defer fake.netOutContextMutex.RUnlock()
+	return fake.netOutContextArgsForCall[i].ctx, fake.netOutContextArgsForCall[i].netOutRule
+}
+
+func (fake *FakeContextContainer) NetOutContextReturns(result1 error) {
+	fake.NetOutContextStub = nil
+	fake.netOutContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) RunContext(ctx context.Context, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	fake.runContextMutex.Lock()
+	fake.runContextArgsForCall = append(fake.runContextArgsForCall, struct {
+		ctx  context.Context
+		spec garden.ProcessSpec
+		io   garden.ProcessIO
+	}{ctx, spec, io})
+	fake.recordInvocation("RunContext", []interface{}{ctx, spec, io})
+	fake.runContextMutex.Unlock()
+	if fake.RunContextStub != nil {
+		return fake.RunContextStub(ctx, spec, io)
+	} else {
+		return fake.runContextReturns.result1, fake.runContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) RunContextCallCount() int {
+	fake.runContextMutex.RLock()
+	defer fake.runContextMutex.RUnlock()
+	return len(fake.runContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) RunContextArgsForCall(i int) (context.Context, garden.ProcessSpec, garden.ProcessIO) {
+	fake.runContextMutex.RLock()
+	defer fake.runContextMutex.RUnlock()
+	return fake.runContextArgsForCall[i].ctx, fake.runContextArgsForCall[i].spec, fake.runContextArgsForCall[i].io
+}
+
+func (fake *FakeContextContainer) RunContextReturns(result1 garden.Process, result2 error) {
+	fake.RunContextStub = nil
+	fake.runContextReturns = struct {
+		result1 garden.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) AttachContext(ctx context.Context, processID string, io garden.ProcessIO) (garden.Process, error) {
+	fake.attachContextMutex.Lock()
+	fake.attachContextArgsForCall = append(fake.attachContextArgsForCall, struct {
+		ctx       context.Context
+		processID string
+		io        garden.ProcessIO
+	}{ctx, processID, io})
+	fake.recordInvocation("AttachContext", []interface{}{ctx, processID, io})
+	fake.attachContextMutex.Unlock()
+	if fake.AttachContextStub != nil {
+		return fake.AttachContextStub(ctx, processID, io)
+	} else {
+		return fake.attachContextReturns.result1, fake.attachContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) AttachContextCallCount() int {
+	fake.attachContextMutex.RLock()
+	defer fake.attachContextMutex.RUnlock()
+	return len(fake.attachContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) AttachContextArgsForCall(i int) (context.Context, string, garden.ProcessIO) {
+	fake.attachContextMutex.RLock()
+	defer fake.attachContextMutex.RUnlock()
+	return fake.attachContextArgsForCall[i].ctx, fake.attachContextArgsForCall[i].processID, fake.attachContextArgsForCall[i].io
+}
+
+func (fake *FakeContextContainer) AttachContextReturns(result1 garden.Process, result2 error) {
+	fake.AttachContextStub = nil
+	fake.attachContextReturns = struct {
+		result1 garden.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) ProcessesContext(ctx context.Context) ([]garden.ProcessInfo, error) {
+	fake.processesContextMutex.Lock()
+	fake.processesContextArgsForCall = append(fake.processesContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("ProcessesContext", []interface{}{ctx})
+	fake.processesContextMutex.Unlock()
+	if fake.ProcessesContextStub != nil {
+		return fake.ProcessesContextStub(ctx)
+	} else {
+		return fake.processesContextReturns.result1, fake.processesContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) ProcessesContextCallCount() int {
+	fake.processesContextMutex.RLock()
+	defer fake.processesContextMutex.RUnlock()
+	return len(fake.processesContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) ProcessesContextArgsForCall(i int) context.Context {
+	fake.processesContextMutex.RLock()
+	defer fake.processesContextMutex.RUnlock()
+	return fake.processesContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) ProcessesContextReturns(result1 []garden.ProcessInfo, result2 error) {
+	fake.ProcessesContextStub = nil
+	fake.processesContextReturns = struct {
+		result1 []garden.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) ProcessContext(ctx context.Context, processID string) (garden.ProcessInfo, error) {
+	fake.processContextMutex.Lock()
+	fake.processContextArgsForCall = append(fake.processContextArgsForCall, struct {
+		ctx       context.Context
+		processID string
+	}{ctx, processID})
+	fake.recordInvocation("ProcessContext", []interface{}{ctx, processID})
+	fake.processContextMutex.Unlock()
+	if fake.ProcessContextStub != nil {
+		return fake.ProcessContextStub(ctx, processID)
+	} else {
+		return fake.processContextReturns.result1, fake.processContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) ProcessContextCallCount() int {
+	fake.processContextMutex.RLock()
+	defer fake.processContextMutex.RUnlock()
+	return len(fake.processContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) ProcessContextArgsForCall(i int) (context.Context, string) {
+	fake.processContextMutex.RLock()
+	defer fake.processContextMutex.RUnlock()
+	return fake.processContextArgsForCall[i].ctx, fake.processContextArgsForCall[i].processID
+}
+
+func (fake *FakeContextContainer) ProcessContextReturns(result1 garden.ProcessInfo, result2 error) {
+	fake.ProcessContextStub = nil
+	fake.processContextReturns = struct {
+		result1 garden.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) MetricsContext(ctx context.Context) (garden.Metrics, error) {
+	fake.metricsContextMutex.Lock()
+	fake.metricsContextArgsForCall = append(fake.metricsContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("MetricsContext", []interface{}{ctx})
+	fake.metricsContextMutex.Unlock()
+	if fake.MetricsContextStub != nil {
+		return fake.MetricsContextStub(ctx)
+	} else {
+		return fake.metricsContextReturns.result1, fake.metricsContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) MetricsContextCallCount() int {
+	fake.metricsContextMutex.RLock()
+	defer fake.metricsContextMutex.RUnlock()
+	return len(fake.metricsContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) MetricsContextArgsForCall(i int) context.Context {
+	fake.metricsContextMutex.RLock()
+	defer fake.metricsContextMutex.RUnlock()
+	return fake.metricsContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) MetricsContextReturns(result1 garden.Metrics, result2 error) {
+	fake.MetricsContextStub = nil
+	fake.metricsContextReturns = struct {
+		result1 garden.Metrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) SetGraceTimeContext(ctx context.Context, graceTime time.Duration) error {
+	fake.setGraceTimeContextMutex.Lock()
+	fake.setGraceTimeContextArgsForCall = append(fake.setGraceTimeContextArgsForCall, struct {
+		ctx       context.Context
+		graceTime time.Duration
+	}{ctx, graceTime})
+	fake.recordInvocation("SetGraceTimeContext", []interface{}{ctx, graceTime})
+	fake.setGraceTimeContextMutex.Unlock()
+	if fake.SetGraceTimeContextStub != nil {
+		return fake.SetGraceTimeContextStub(ctx, graceTime)
+	} else {
+		return fake.setGraceTimeContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) SetGraceTimeContextCallCount() int {
+	fake.setGraceTimeContextMutex.RLock()
+	defer fake.setGraceTimeContextMutex.RUnlock()
+	return len(fake.setGraceTimeContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) SetGraceTimeContextArgsForCall(i int) (context.Context, time.Duration) {
+	fake.setGraceTimeContextMutex.RLock()
+	defer fake.setGraceTimeContextMutex.RUnlock()
+	return fake.setGraceTimeContextArgsForCall[i].ctx, fake.setGraceTimeContextArgsForCall[i].graceTime
+}
+
+func (fake *FakeContextContainer) SetGraceTimeContextReturns(result1 error) {
+	fake.SetGraceTimeContextStub = nil
+	fake.setGraceTimeContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) PropertiesContext(ctx context.Context) (garden.Properties, error) {
+	fake.propertiesContextMutex.Lock()
+	fake.propertiesContextArgsForCall = append(fake.propertiesContextArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("PropertiesContext", []interface{}{ctx})
+	fake.propertiesContextMutex.Unlock()
+	if fake.PropertiesContextStub != nil {
+		return fake.PropertiesContextStub(ctx)
+	} else {
+		return fake.propertiesContextReturns.result1, fake.propertiesContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) PropertiesContextCallCount() int {
+	fake.propertiesContextMutex.RLock()
+	defer fake.propertiesContextMutex.RUnlock()
+	return len(fake.propertiesContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) PropertiesContextArgsForCall(i int) context.Context {
+	fake.propertiesContextMutex.RLock()
+	defer fake.propertiesContextMutex.RUnlock()
+	return fake.propertiesContextArgsForCall[i].ctx
+}
+
+func (fake *FakeContextContainer) PropertiesContextReturns(result1 garden.Properties, result2 error) {
+	fake.PropertiesContextStub = nil
+	fake.propertiesContextReturns = struct {
+		result1 garden.Properties
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) PropertyContext(ctx context.Context, name string) (string, error) {
+	fake.propertyContextMutex.Lock()
+	fake.propertyContextArgsForCall = append(fake.propertyContextArgsForCall, struct {
+		ctx  context.Context
+		name string
+	}{ctx, name})
+	fake.recordInvocation("PropertyContext", []interface{}{ctx, name})
+	fake.propertyContextMutex.Unlock()
+	if fake.PropertyContextStub != nil {
+		return fake.PropertyContextStub(ctx, name)
+	} else {
+		return fake.propertyContextReturns.result1, fake.propertyContextReturns.result2
+	}
+}
+
+func (fake *FakeContextContainer) PropertyContextCallCount() int {
+	fake.propertyContextMutex.RLock()
+	defer fake.propertyContextMutex.RUnlock()
+	return len(fake.propertyContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) PropertyContextArgsForCall(i int) (context.Context, string) {
+	fake.propertyContextMutex.RLock()
+	defer fake.propertyContextMutex.RUnlock()
+	return fake.propertyContextArgsForCall[i].ctx, fake.propertyContextArgsForCall[i].name
+}
+
+func (fake *FakeContextContainer) PropertyContextReturns(result1 string, result2 error) {
+	fake.PropertyContextStub = nil
+	fake.propertyContextReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContextContainer) SetPropertyContext(ctx context.Context, name string, value string) error {
+	fake.setPropertyContextMutex.Lock()
+	fake.setPropertyContextArgsForCall = append(fake.setPropertyContextArgsForCall, struct {
+		ctx   context.Context
+		name  string
+		value string
+	}{ctx, name, value})
+	fake.recordInvocation("SetPropertyContext", []interface{}{ctx, name, value})
+	fake.setPropertyContextMutex.Unlock()
+	if fake.SetPropertyContextStub != nil {
+		return fake.SetPropertyContextStub(ctx, name, value)
+	} else {
+		return fake.setPropertyContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) SetPropertyContextCallCount() int {
+	fake.setPropertyContextMutex.RLock()
+	defer fake.setPropertyContextMutex.RUnlock()
+	return len(fake.setPropertyContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) SetPropertyContextArgsForCall(i int) (context.Context, string, string) {
+	fake.setPropertyContextMutex.RLock()
+	defer fake.setPropertyContextMutex.RUnlock()
+	return fake.setPropertyContextArgsForCall[i].ctx, fake.setPropertyContextArgsForCall[i].name, fake.setPropertyContextArgsForCall[i].value
+}
+
+func (fake *FakeContextContainer) SetPropertyContextReturns(result1 error) {
+	fake.SetPropertyContextStub = nil
+	fake.setPropertyContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) RemovePropertyContext(ctx context.Context, name string) error {
+	fake.removePropertyContextMutex.Lock()
+	fake.removePropertyContextArgsForCall = append(fake.removePropertyContextArgsForCall, struct {
+		ctx  context.Context
+		name string
+	}{ctx, name})
+	fake.recordInvocation("RemovePropertyContext", []interface{}{ctx, name})
+	fake.removePropertyContextMutex.Unlock()
+	if fake.RemovePropertyContextStub != nil {
+		return fake.RemovePropertyContextStub(ctx, name)
+	} else {
+		return fake.removePropertyContextReturns.result1
+	}
+}
+
+func (fake *FakeContextContainer) RemovePropertyContextCallCount() int {
+	fake.removePropertyContextMutex.RLock()
+	defer fake.removePropertyContextMutex.RUnlock()
+	return len(fake.removePropertyContextArgsForCall)
+}
+
+func (fake *FakeContextContainer) RemovePropertyContextArgsForCall(i int) (context.Context, string) {
+	fake.removePropertyContextMutex.RLock()
+	defer fake.removePropertyContextMutex.RUnlock()
+	return fake.removePropertyContextArgsForCall[i].ctx, fake.removePropertyContextArgsForCall[i].name
+}
+
+func (fake *FakeContextContainer) RemovePropertyContextReturns(result1 error) {
+	fake.RemovePropertyContextStub = nil
+	fake.removePropertyContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContextContainer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.stopContextMutex.RLock()
+	defer fake.stopContextMutex.RUnlock()
+	fake.infoContextMutex.RLock()
+	defer fake.infoContextMutex.RUnlock()
+	fake.streamInContextMutex.RLock()
+	defer fake.streamInContextMutex.RUnlock()
+	fake.streamOutContextMutex.RLock()
+	defer fake.streamOutContextMutex.RUnlock()
+	fake.currentBandwidthLimitsContextMutex.RLock()
+	defer fake.currentBandwidthLimitsContextMutex.RUnlock()
+	fake.currentCPULimitsContextMutex.RLock()
+	defer fake.currentCPULimitsContextMutex.RUnlock()
+	fake.currentDiskLimitsContextMutex.RLock()
+	defer fake.currentDiskLimitsContextMutex.RUnlock()
+	fake.currentMemoryLimitsContextMutex.RLock()
+	defer fake.currentMemoryLimitsContextMutex.RUnlock()
+	fake.setBandwidthLimitsContextMutex.RLock()
+	defer fake.setBandwidthLimitsContextMutex.RUnlock()
+	fake.setCPULimitsContextMutex.RLock()
+	defer fake.setCPULimitsContextMutex.RUnlock()
+	fake.setDiskLimitsContextMutex.RLock()
+	defer fake.setDiskLimitsContextMutex.RUnlock()
+	fake.setMemoryLimitsContextMutex.RLock()
+	defer fake.setMemoryLimitsContextMutex.RUnlock()
+	fake.netInContextMutex.RLock()
+	defer fake.netInContextMutex.RUnlock()
+	fake.netOutContextMutex.RLock()
+	defer fake.netOutContextMutex.RUnlock()
+	fake.runContextMutex.RLock()
+	defer fake.runContextMutex.RUnlock()
+	fake.attachContextMutex.RLock()
+	defer fake.attachContextMutex.RUnlock()
+	fake.processesContextMutex.RLock()
+	defer fake.processesContextMutex.RUnlock()
+	fake.processContextMutex.RLock()
+	defer fake.processContextMutex.RUnlock()
+	fake.metricsContextMutex.RLock()
+	defer fake.metricsContextMutex.RUnlock()
+	fake.setGraceTimeContextMutex.RLock()
+	defer fake.setGraceTimeContextMutex.RUnlock()
+	fake.propertiesContextMutex.RLock()
+	defer fake.propertiesContextMutex.RUnlock()
+	fake.propertyContextMutex.RLock()
+	defer fake.propertyContextMutex.RUnlock()
+	fake.setPropertyContextMutex.RLock()
+	defer fake.setPropertyContextMutex.RUnlock()
+	fake.removePropertyContextMutex.RLock()
+	defer fake.removePropertyContextMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeContextContainer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ garden.ContextContainer = new(FakeContextContainer)
@@ -0,0 +1,421 @@
+// Package gardentest provides a Ginkgo suite that checks a garden.Backend
+// against the contract documented on garden.Client and garden.Container.
+//
+// Backend authors register it from within their own test suite:
+//
+//	var _ = gardentest.DescribeBackend("my backend", gardentest.Config{
+//		NewBackend: func() garden.Backend { return mybackend.New() },
+//	})
+package gardentest
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+type Config struct {
+	// NewBackend returns a fresh backend for each spec. The suite starts it
+	// before and stops it after the spec, destroying any containers the spec
+	// created.
+	NewBackend func() garden.Backend
+
+	// ContainerSpec is the base spec for every container the suite creates,
+	// e.g. to set a RootFSPath. Handle and Properties are overwritten.
+	ContainerSpec garden.ContainerSpec
+
+	// LongRunningProcess must run until it is signalled. Defaults to
+	// `sleep 1000`.
+	LongRunningProcess garden.ProcessSpec
+
+	// CatProcess must copy its stdin to its stdout and exit 0 once stdin is
+	// closed. Defaults to `cat`.
+	CatProcess garden.ProcessSpec
+
+	// WritableDir is a directory in the container that files can be streamed
+	// into. Defaults to /tmp.
+	WritableDir string
+
+	// Timeout bounds how long the suite waits for processes to react.
+	// Defaults to 15 seconds, enough for a graceful Stop to escalate to kill.
+	Timeout time.Duration
+}
+
+// DescribeBackend registers the conformance specs in the enclosing Ginkgo
+// suite.
+func DescribeBackend(description string, config Config) bool {
+	if config.LongRunningProcess.Path == "" {
+		config.LongRunningProcess = garden.ProcessSpec{Path: "sleep", Args: []string{"1000"}}
+	}
+
+	if config.CatProcess.Path == "" {
+		config.CatProcess = garden.ProcessSpec{Path: "cat"}
+	}
+
+	if config.WritableDir == "" {
+		config.WritableDir = "/tmp"
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = 15 * time.Second
+	}
+
+	return Describe(description+" (garden.Backend conformance)", func() {
+		var (
+			backend garden.Backend
+			handles []string
+			created int
+		)
+
+		create := func(properties garden.Properties) garden.Container {
+			spec := config.ContainerSpec
+
+			created++
+			spec.Handle = fmt.Sprintf("gardentest-%d-%d", GinkgoParallelNode(), created)
+			spec.Properties = properties
+
+			container, err := backend.Create(spec)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			handles = append(handles, container.Handle())
+
+			return container
+		}
+
+		BeforeEach(func() {
+			handles = nil
+
+			backend = config.NewBackend()
+			Ω(backend.Start()).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			for _, handle := range handles {
+				backend.Destroy(handle)
+			}
+
+			backend.Stop()
+		})
+
+		Describe("Create", func() {
+			It("uses the requested handle", func() {
+				container := create(nil)
+				Ω(container.Handle()).Should(HavePrefix("gardentest-"))
+			})
+
+			It("fails when the requested handle is already taken", func() {
+				container := create(nil)
+
+				spec := config.ContainerSpec
+				spec.Handle = container.Handle()
+
+				_, err := backend.Create(spec)
+				Ω(err).Should(HaveOccurred())
+			})
+
+			It("generates unique handles when none is requested", func() {
+				container1, err := backend.Create(config.ContainerSpec)
+				Ω(err).ShouldNot(HaveOccurred())
+				handles = append(handles, container1.Handle())
+
+				container2, err := backend.Create(config.ContainerSpec)
+				Ω(err).ShouldNot(HaveOccurred())
+				handles = append(handles, container2.Handle())
+
+				Ω(container1.Handle()).ShouldNot(BeEmpty())
+				Ω(container1.Handle()).ShouldNot(Equal(container2.Handle()))
+			})
+		})
+
+		Describe("Lookup", func() {
+			It("returns the container with the given handle", func() {
+				container := create(nil)
+
+				found, err := backend.Lookup(container.Handle())
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found.Handle()).Should(Equal(container.Handle()))
+			})
+
+			It("returns a ContainerNotFoundError for an unknown handle", func() {
+				_, err := backend.Lookup("gardentest-bogus")
+				Ω(err).Should(Equal(garden.ContainerNotFoundError{Handle: "gardentest-bogus"}))
+			})
+		})
+
+		Describe("Destroy", func() {
+			It("removes the container", func() {
+				container := create(nil)
+
+				Ω(backend.Destroy(container.Handle())).Should(Succeed())
+
+				_, err := backend.Lookup(container.Handle())
+				Ω(err).Should(BeAssignableToTypeOf(garden.ContainerNotFoundError{}))
+			})
+
+			It("returns a ContainerNotFoundError for an unknown handle", func() {
+				err := backend.Destroy("gardentest-bogus")
+				Ω(err).Should(Equal(garden.ContainerNotFoundError{Handle: "gardentest-bogus"}))
+			})
+		})
+
+		Describe("Containers", func() {
+			var a, b garden.Container
+
+			BeforeEach(func() {
+				a = create(garden.Properties{"gardentest-a": "x", "gardentest-b": "y"})
+				b = create(garden.Properties{"gardentest-a": "x", "gardentest-b": "z"})
+			})
+
+			handlesOf := func(containers []garden.Container) []string {
+				found := []string{}
+				for _, c := range containers {
+					found = append(found, c.Handle())
+				}
+				return found
+			}
+
+			It("ANDs the given properties together", func() {
+				containers, err := backend.Containers(garden.Properties{"gardentest-a": "x", "gardentest-b": "z"})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(handlesOf(containers)).Should(ConsistOf(b.Handle()))
+
+				containers, err = backend.Containers(garden.Properties{"gardentest-a": "x"})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(handlesOf(containers)).Should(ConsistOf(a.Handle(), b.Handle()))
+			})
+
+			It("returns every container when no properties are given", func() {
+				containers, err := backend.Containers(nil)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(handlesOf(containers)).Should(ContainElement(a.Handle()))
+				Ω(handlesOf(containers)).Should(ContainElement(b.Handle()))
+			})
+		})
+
+		Describe("BulkInfo", func() {
+			It("returns info for known handles and a ContainerNotFoundError for unknown ones", func() {
+				container := create(nil)
+
+				infos, err := backend.BulkInfo([]string{container.Handle(), "gardentest-bogus"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(infos[container.Handle()].Err).Should(BeNil())
+				Ω(infos["gardentest-bogus"].Err).ShouldNot(BeNil())
+				Ω(infos["gardentest-bogus"].Err.Err).Should(BeAssignableToTypeOf(garden.ContainerNotFoundError{}))
+			})
+		})
+
+		Describe("BulkMetrics", func() {
+			It("returns metrics for known handles and a ContainerNotFoundError for unknown ones", func() {
+				container := create(nil)
+
+				metrics, err := backend.BulkMetrics([]string{container.Handle(), "gardentest-bogus"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(metrics[container.Handle()].Err).Should(BeNil())
+				Ω(metrics["gardentest-bogus"].Err).ShouldNot(BeNil())
+				Ω(metrics["gardentest-bogus"].Err.Err).Should(BeAssignableToTypeOf(garden.ContainerNotFoundError{}))
+			})
+		})
+
+		Describe("Stop", func() {
+			for _, kill := range []bool{false, true} {
+				kill := kill
+
+				It(fmt.Sprintf("ends running processes and marks the container as stopped (kill: %t)", kill), func() {
+					container := create(nil)
+
+					process, err := container.Run(config.LongRunningProcess, garden.ProcessIO{})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(container.Stop(kill)).Should(Succeed())
+
+					exited := make(chan struct{})
+					go func() {
+						process.Wait()
+						close(exited)
+					}()
+
+					Eventually(exited, config.Timeout).Should(BeClosed())
+
+					info, err := container.Info()
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(info.State).Should(Equal("stopped"))
+				})
+			}
+		})
+
+		Describe("StreamIn and StreamOut", func() {
+			It("round-trips a file through a tar stream", func() {
+				container := create(nil)
+
+				dir := path.Join(config.WritableDir, fmt.Sprintf("gardentest-%d", time.Now().UnixNano()))
+
+				Ω(container.StreamIn(garden.StreamInSpec{
+					Path:      dir,
+					TarStream: tarWithFile("some-file", "hello"),
+				})).Should(Succeed())
+
+				reader, err := container.StreamOut(garden.StreamOutSpec{
+					Path: path.Join(dir, "some-file"),
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				defer reader.Close()
+
+				tr := tar.NewReader(reader)
+
+				hdr, err := tr.Next()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(hdr.Name).Should(Equal("some-file"))
+				Ω(ioutil.ReadAll(tr)).Should(Equal([]byte("hello")))
+			})
+		})
+
+		Describe("NetIn", func() {
+			It("acquires a host port and defaults the container port to it", func() {
+				container := create(nil)
+
+				hostPort, containerPort, err := container.NetIn(0, 0)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(hostPort).ShouldNot(BeZero())
+				Ω(containerPort).Should(Equal(hostPort))
+
+				info, err := container.Info()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(info.MappedPorts).Should(ContainElement(garden.PortMapping{
+					HostPort:      hostPort,
+					ContainerPort: containerPort,
+				}))
+			})
+		})
+
+		Describe("Attach", func() {
+			It("streams the output of a running process and its exit status", func() {
+				container := create(nil)
+
+				stdin, stdinW := io.Pipe()
+
+				process, err := container.Run(config.CatProcess, garden.ProcessIO{
+					Stdin: stdin,
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				stdout := gbytes.NewBuffer()
+				attached, err := container.Attach(process.ID(), garden.ProcessIO{
+					Stdout: stdout,
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(attached.ID()).Should(Equal(process.ID()))
+
+				_, err = stdinW.Write([]byte("hello\n"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Eventually(stdout, config.Timeout).Should(gbytes.Say("hello"))
+
+				Ω(stdinW.Close()).Should(Succeed())
+				Ω(attached.Wait()).Should(Equal(0))
+			})
+
+			It("fails for an unknown process", func() {
+				container := create(nil)
+
+				_, err := container.Attach("gardentest-bogus", garden.ProcessIO{})
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+
+		Describe("Processes", func() {
+			It("describes running and exited processes", func() {
+				container := create(nil)
+
+				stdin, stdinW := io.Pipe()
+
+				running, err := container.Run(config.CatProcess, garden.ProcessIO{
+					Stdin: stdin,
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				exited, err := container.Run(config.CatProcess, garden.ProcessIO{
+					Stdin: strings.NewReader(""),
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(exited.Wait()).Should(Equal(0))
+
+				processes, err := container.Processes()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(processes).Should(HaveLen(2))
+
+				Ω(processes[0].ID).Should(Equal(running.ID()))
+				Ω(processes[0].Spec.Path).Should(Equal(config.CatProcess.Path))
+				Ω(processes[0].State).Should(Equal("running"))
+
+				Ω(processes[1].ID).Should(Equal(exited.ID()))
+				Ω(processes[1].State).Should(Equal("exited"))
+				Ω(processes[1].ExitStatus).Should(Equal(0))
+
+				process, err := container.Process(running.ID())
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(process.ID).Should(Equal(running.ID()))
+
+				Ω(stdinW.Close()).Should(Succeed())
+				Ω(running.Wait()).Should(Equal(0))
+			})
+
+			It("fails for an unknown process", func() {
+				container := create(nil)
+
+				_, err := container.Process("gardentest-bogus")
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+
+		Describe("properties", func() {
+			It("sets, gets and removes properties", func() {
+				container := create(garden.Properties{"gardentest-a": "x"})
+
+				Ω(container.SetProperty("gardentest-b", "y")).Should(Succeed())
+				Ω(container.Property("gardentest-b")).Should(Equal("y"))
+
+				Ω(container.Properties()).Should(Equal(garden.Properties{
+					"gardentest-a": "x",
+					"gardentest-b": "y",
+				}))
+
+				Ω(container.RemoveProperty("gardentest-b")).Should(Succeed())
+
+				_, err := container.Property("gardentest-b")
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+	})
+}
+
+func tarWithFile(name, contents string) io.Reader {
+	buf := new(bytes.Buffer)
+
+	tw := tar.NewWriter(buf)
+	Ω(tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len(contents)),
+	})).Should(Succeed())
+
+	_, err := tw.Write([]byte(contents))
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(tw.Close()).Should(Succeed())
+
+	return buf
+}
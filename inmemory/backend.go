@@ -0,0 +1,271 @@
+// Package inmemory provides a garden.Backend that keeps all container state in
+// memory and runs processes as plain subprocesses of the host.
+//
+// It performs no isolation whatsoever and is intended for local development
+// and for exercising the client and server packages end-to-end on machines
+// without a Linux container stack.
+package inmemory
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+// DefaultMaxContainers is the container capacity reported by a Backend.
+const DefaultMaxContainers = 1024
+
+// First and last host ports handed out by NetIn when no host port is given.
+const (
+	firstHostPort = 61001
+	lastHostPort  = 65535
+)
+
+// Network from which container subnets are allocated when ContainerSpec.Network
+// is not specified.
+var defaultNetwork = net.IPv4(10, 254, 0, 0).To4()
+
+type Backend struct {
+	containers map[string]*container
+	mu         sync.RWMutex
+
+	nextContainerID uint64
+	nextHostPort    uint32
+	nextSubnet      uint32
+}
+
+func New() *Backend {
+	return &Backend{
+		containers:   make(map[string]*container),
+		nextHostPort: firstHostPort,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+// Stop kills every process running in any container. The containers
+// themselves are left in place.
+func (b *Backend) Stop() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, c := range b.containers {
+		c.killAll()
+	}
+}
+
+func (b *Backend) Ping() error {
+	return nil
+}
+
+func (b *Backend) Capacity() (garden.Capacity, error) {
+	return garden.Capacity{
+		MaxContainers: DefaultMaxContainers,
+	}, nil
+}
+
+func (b *Backend) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if uint64(len(b.containers)) >= DefaultMaxContainers {
+		return nil, garden.NewCapacityExhaustedError(fmt.Sprintf("cannot create more than %d containers", DefaultMaxContainers))
+	}
+
+	handle := spec.Handle
+	if handle == "" {
+		b.nextContainerID++
+		handle = fmt.Sprintf("inmemory-%d", b.nextContainerID)
+	}
+
+	if _, found := b.containers[handle]; found {
+		return nil, garden.HandleExistsError{Handle: handle}
+	}
+
+	hostIP, containerIP, err := b.allocateIPs(spec.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newContainer(b, handle, spec, hostIP, containerIP)
+	b.containers[handle] = c
+
+	return c, nil
+}
+
+func (b *Backend) Destroy(handle string) error {
+	b.mu.Lock()
+	c, found := b.containers[handle]
+	delete(b.containers, handle)
+	b.mu.Unlock()
+
+	if !found {
+		return garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	c.killAll()
+
+	return nil
+}
+
+func (b *Backend) Containers(filter garden.Properties) ([]garden.Container, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	containers := []garden.Container{}
+	for _, c := range b.containers {
+		if c.hasProperties(filter) {
+			containers = append(containers, c)
+		}
+	}
+
+	return containers, nil
+}
+
+func (b *Backend) ContainersMatching(selector garden.Selector) ([]garden.Container, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	containers := []garden.Container{}
+	for _, c := range b.containers {
+		if c.matches(selector) {
+			containers = append(containers, c)
+		}
+	}
+
+	return containers, nil
+}
+
+func (b *Backend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	infos := make(map[string]garden.ContainerInfoEntry)
+
+	for _, handle := range handles {
+		c, err := b.lookup(handle)
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: &garden.Error{Err: err}}
+			continue
+		}
+
+		info, err := c.Info()
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: &garden.Error{Err: err}}
+			continue
+		}
+
+		infos[handle] = garden.ContainerInfoEntry{Info: info}
+	}
+
+	return infos, nil
+}
+
+func (b *Backend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	metrics := make(map[string]garden.ContainerMetricsEntry)
+
+	for _, handle := range handles {
+		c, err := b.lookup(handle)
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: &garden.Error{Err: err}}
+			continue
+		}
+
+		m, err := c.Metrics()
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: &garden.Error{Err: err}}
+			continue
+		}
+
+		metrics[handle] = garden.ContainerMetricsEntry{Metrics: m}
+	}
+
+	return metrics, nil
+}
+
+func (b *Backend) Lookup(handle string) (garden.Container, error) {
+	return b.lookup(handle)
+}
+
+func (b *Backend) GraceTime(container garden.Container) time.Duration {
+	c, err := b.lookup(container.Handle())
+	if err != nil {
+		return 0
+	}
+
+	return c.currentGraceTime()
+}
+
+func (b *Backend) lookup(handle string) (*container, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	c, found := b.containers[handle]
+	if !found {
+		return nil, garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return c, nil
+}
+
+func (b *Backend) acquireHostPort() (uint32, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.nextHostPort > lastHostPort {
+		return 0, garden.NewCapacityExhaustedError("host port pool exhausted")
+	}
+
+	port := b.nextHostPort
+	b.nextHostPort++
+
+	return port, nil
+}
+
+// allocateIPs follows the rules documented on ContainerSpec.Network, handing
+// out /30 subnets from defaultNetwork when no network is requested. Addresses
+// are only for reporting; no interfaces are created.
+func (b *Backend) allocateIPs(network string) (string, string, error) {
+	if network == "" {
+		subnet := make(net.IP, len(defaultNetwork))
+		copy(subnet, defaultNetwork)
+		addToIP(subnet, b.nextSubnet*4)
+		b.nextSubnet++
+
+		return offsetIP(subnet, 1).String(), offsetIP(subnet, 2).String(), nil
+	}
+
+	ip, ipNet, err := net.ParseCIDR(network)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid network %q: %s", network, err)
+	}
+
+	subnet := ipNet.IP.To4()
+	if subnet == nil {
+		return "", "", fmt.Errorf("invalid network %q: not an IPv4 network", network)
+	}
+
+	containerIP := ip.To4()
+	if containerIP.Equal(subnet) {
+		containerIP = offsetIP(subnet, 2)
+	}
+
+	return offsetIP(subnet, 1).String(), containerIP.String(), nil
+}
+
+func offsetIP(ip net.IP, n uint32) net.IP {
+	offset := make(net.IP, len(ip))
+	copy(offset, ip)
+	addToIP(offset, n)
+	return offset
+}
+
+func addToIP(ip net.IP, n uint32) {
+	for i := len(ip) - 1; i >= 0 && n > 0; i-- {
+		sum := uint32(ip[i]) + n
+		ip[i] = byte(sum)
+		n = sum >> 8
+	}
+}
@@ -0,0 +1,284 @@
+package inmemory_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	"github.com/cloudfoundry-incubator/garden/client/connection"
+	"github.com/cloudfoundry-incubator/garden/inmemory"
+	"github.com/cloudfoundry-incubator/garden/server"
+)
+
+var _ = Describe("Backend", func() {
+	var backend *inmemory.Backend
+
+	BeforeEach(func() {
+		backend = inmemory.New()
+		Ω(backend.Start()).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		backend.Stop()
+	})
+
+	Describe("Create", func() {
+		It("uses the given handle", func() {
+			container, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(container.Handle()).Should(Equal("some-handle"))
+		})
+
+		It("generates a unique handle when none is given", func() {
+			container1, err := backend.Create(garden.ContainerSpec{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			container2, err := backend.Create(garden.ContainerSpec{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(container1.Handle()).ShouldNot(BeEmpty())
+			Ω(container1.Handle()).ShouldNot(Equal(container2.Handle()))
+		})
+
+		It("fails when the handle is already taken", func() {
+			_, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Ω(err).Should(Equal(garden.HandleExistsError{Handle: "some-handle"}))
+		})
+
+		It("allocates a container IP from the requested network", func() {
+			container, err := backend.Create(garden.ContainerSpec{Network: "10.0.0.8/30"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			info, err := container.Info()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(info.HostIP).Should(Equal("10.0.0.9"))
+			Ω(info.ContainerIP).Should(Equal("10.0.0.10"))
+		})
+
+		It("uses an explicitly requested container IP", func() {
+			container, err := backend.Create(garden.ContainerSpec{Network: "10.0.0.5/24"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			info, err := container.Info()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(info.ContainerIP).Should(Equal("10.0.0.5"))
+		})
+
+		It("fails when the network is invalid", func() {
+			_, err := backend.Create(garden.ContainerSpec{Network: "banana"})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Lookup", func() {
+		It("returns the container with the given handle", func() {
+			created, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			container, err := backend.Lookup("some-handle")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(container).Should(Equal(created))
+		})
+
+		It("returns ContainerNotFoundError for an unknown handle", func() {
+			_, err := backend.Lookup("bogus")
+			Ω(err).Should(Equal(garden.ContainerNotFoundError{Handle: "bogus"}))
+		})
+	})
+
+	Describe("Destroy", func() {
+		It("removes the container", func() {
+			_, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(backend.Destroy("some-handle")).Should(Succeed())
+
+			_, err = backend.Lookup("some-handle")
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("kills the container's processes", func() {
+			container, err := backend.Create(garden.ContainerSpec{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sleep",
+				Args: []string{"100"},
+			}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(backend.Destroy(container.Handle())).Should(Succeed())
+
+			exited := make(chan struct{})
+			go func() {
+				process.Wait()
+				close(exited)
+			}()
+
+			Eventually(exited).Should(BeClosed())
+		})
+
+		It("returns ContainerNotFoundError for an unknown handle", func() {
+			Ω(backend.Destroy("bogus")).Should(Equal(garden.ContainerNotFoundError{Handle: "bogus"}))
+		})
+	})
+
+	Describe("Containers", func() {
+		BeforeEach(func() {
+			_, err := backend.Create(garden.ContainerSpec{
+				Handle:     "a",
+				Properties: garden.Properties{"foo": "bar", "baz": "1"},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = backend.Create(garden.ContainerSpec{
+				Handle:     "b",
+				Properties: garden.Properties{"foo": "bar", "baz": "2"},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns containers matching all of the given properties", func() {
+			containers, err := backend.Containers(garden.Properties{"foo": "bar", "baz": "2"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(containers).Should(HaveLen(1))
+			Ω(containers[0].Handle()).Should(Equal("b"))
+		})
+
+		It("returns all containers when no properties are given", func() {
+			containers, err := backend.Containers(nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(containers).Should(HaveLen(2))
+		})
+	})
+
+	Describe("ContainersMatching", func() {
+		BeforeEach(func() {
+			_, err := backend.Create(garden.ContainerSpec{
+				Handle:     "a",
+				Properties: garden.Properties{"foo": "bar", "baz": "1"},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = backend.Create(garden.ContainerSpec{
+				Handle:     "b",
+				Properties: garden.Properties{"foo": "bar"},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns containers whose properties match the selector", func() {
+			selector, err := garden.ParseSelector("foo=bar,!baz")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			containers, err := backend.ContainersMatching(selector)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(containers).Should(HaveLen(1))
+			Ω(containers[0].Handle()).Should(Equal("b"))
+		})
+	})
+
+	Describe("BulkInfo", func() {
+		It("returns an error entry for unknown handles", func() {
+			_, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			infos, err := backend.BulkInfo([]string{"some-handle", "bogus"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(infos["some-handle"].Err).Should(BeNil())
+			Ω(infos["some-handle"].Info.State).Should(Equal("active"))
+			Ω(infos["bogus"].Err).Should(Equal(&garden.Error{Err: garden.ContainerNotFoundError{Handle: "bogus"}}))
+		})
+	})
+
+	Describe("BulkMetrics", func() {
+		It("returns an error entry for unknown handles", func() {
+			_, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			metrics, err := backend.BulkMetrics([]string{"some-handle", "bogus"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(metrics["some-handle"].Err).Should(BeNil())
+			Ω(metrics["bogus"].Err).Should(Equal(&garden.Error{Err: garden.ContainerNotFoundError{Handle: "bogus"}}))
+		})
+	})
+
+	Describe("GraceTime", func() {
+		It("returns the container's grace time", func() {
+			container, err := backend.Create(garden.ContainerSpec{GraceTime: time.Minute})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(backend.GraceTime(container)).Should(Equal(time.Minute))
+
+			Ω(container.SetGraceTime(time.Hour)).Should(Succeed())
+			Ω(backend.GraceTime(container)).Should(Equal(time.Hour))
+		})
+	})
+
+	Context("when served by a garden server", func() {
+		var (
+			tmpdir    string
+			apiServer *server.GardenServer
+			apiClient garden.Client
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "inmemory-test")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			socketPath := path.Join(tmpdir, "api.sock")
+
+			apiServer = server.New("unix", socketPath, time.Minute, backend, lagertest.NewTestLogger("test"))
+			Ω(apiServer.Start()).Should(Succeed())
+
+			apiClient = client.New(connection.New("unix", socketPath))
+			Eventually(apiClient.Ping).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			apiServer.Stop()
+			os.RemoveAll(tmpdir)
+		})
+
+		It("runs processes end-to-end", func() {
+			container, err := apiClient.Create(garden.ContainerSpec{
+				Env: []string{"GREETING=hello"},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			stdout := gbytes.NewBuffer()
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sh",
+				Args: []string{"-c", "echo $GREETING; exit 3"},
+			}, garden.ProcessIO{
+				Stdout: stdout,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(process.Wait()).Should(Equal(3))
+			Eventually(stdout).Should(gbytes.Say("hello"))
+
+			Ω(apiClient.Destroy(container.Handle())).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package inmemory_test
+
+import (
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/gardentest"
+	"github.com/cloudfoundry-incubator/garden/inmemory"
+)
+
+var _ = gardentest.DescribeBackend("in-memory backend", gardentest.Config{
+	NewBackend: func() garden.Backend {
+		return inmemory.New()
+	},
+})
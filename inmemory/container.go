@@ -0,0 +1,417 @@
+package inmemory
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+// How long Stop waits for processes to exit after SIGTERM before killing them.
+const stopGracePeriod = 10 * time.Second
+
+var ErrContainerStopped = errors.New("container is stopped")
+
+type container struct {
+	backend *Backend
+
+	handle      string
+	env         []string
+	hostIP      string
+	containerIP string
+
+	mu          sync.RWMutex
+	state       string
+	graceTime   time.Duration
+	properties  garden.Properties
+	limits      garden.Limits
+	portMapping []garden.PortMapping
+	netOutRules []garden.NetOutRule
+	processes   map[string]*process
+	nextPID     uint64
+
+	fs *filesystem
+}
+
+func newContainer(backend *Backend, handle string, spec garden.ContainerSpec, hostIP, containerIP string) *container {
+	properties := garden.Properties{}
+	for k, v := range spec.Properties {
+		properties[k] = v
+	}
+
+	return &container{
+		backend: backend,
+
+		handle:      handle,
+		env:         spec.Env,
+		hostIP:      hostIP,
+		containerIP: containerIP,
+
+		state:      "active",
+		graceTime:  spec.GraceTime,
+		properties: properties,
+		limits:     spec.Limits,
+		processes:  make(map[string]*process),
+
+		fs: newFilesystem(),
+	}
+}
+
+func (c *container) Handle() string {
+	return c.handle
+}
+
+func (c *container) Stop(kill bool) error {
+	c.mu.Lock()
+	c.state = "stopped"
+	processes := c.runningProcesses()
+	c.mu.Unlock()
+
+	for _, p := range processes {
+		if kill {
+			p.signal(garden.SignalKill)
+			continue
+		}
+
+		p.signal(garden.SignalTerminate)
+	}
+
+	for _, p := range processes {
+		select {
+		case <-p.exited:
+		case <-time.After(stopGracePeriod):
+			p.signal(garden.SignalKill)
+			<-p.exited
+		}
+	}
+
+	return nil
+}
+
+func (c *container) Info() (garden.ContainerInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	processIDs := []string{}
+	for _, p := range c.runningProcesses() {
+		processIDs = append(processIDs, p.id)
+	}
+
+	mappedPorts := make([]garden.PortMapping, len(c.portMapping))
+	copy(mappedPorts, c.portMapping)
+
+	return garden.ContainerInfo{
+		State:       c.state,
+		Events:      []string{},
+		HostIP:      c.hostIP,
+		ContainerIP: c.containerIP,
+		ExternalIP:  "127.0.0.1",
+		ProcessIDs:  processIDs,
+		Properties:  c.copyProperties(),
+		MappedPorts: mappedPorts,
+	}, nil
+}
+
+func (c *container) StreamIn(spec garden.StreamInSpec) error {
+	return c.fs.streamIn(spec.Path, spec.TarStream)
+}
+
+func (c *container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	return c.fs.streamOut(spec.Path)
+}
+
+func (c *container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.limits.Bandwidth, nil
+}
+
+func (c *container) CurrentCPULimits() (garden.CPULimits, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.limits.CPU, nil
+}
+
+func (c *container) CurrentDiskLimits() (garden.DiskLimits, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.limits.Disk, nil
+}
+
+func (c *container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.limits.Memory, nil
+}
+
+func (c *container) SetBandwidthLimits(limits garden.BandwidthLimits) error {
+	c.mu.Lock()
+	c.limits.Bandwidth = limits
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *container) SetCPULimits(limits garden.CPULimits) error {
+	c.mu.Lock()
+	c.limits.CPU = limits
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *container) SetDiskLimits(limits garden.DiskLimits) error {
+	c.mu.Lock()
+	c.limits.Disk = limits
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *container) SetMemoryLimits(limits garden.MemoryLimits) error {
+	c.mu.Lock()
+	c.limits.Memory = limits
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	if hostPort == 0 {
+		var err error
+		hostPort, err = c.backend.acquireHostPort()
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if containerPort == 0 {
+		containerPort = hostPort
+	}
+
+	c.mu.Lock()
+	c.portMapping = append(c.portMapping, garden.PortMapping{
+		HostPort:      hostPort,
+		ContainerPort: containerPort,
+	})
+	c.mu.Unlock()
+
+	return hostPort, containerPort, nil
+}
+
+func (c *container) NetOut(netOutRule garden.NetOutRule) error {
+	c.mu.Lock()
+	c.netOutRules = append(c.netOutRules, netOutRule)
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *container) Run(spec garden.ProcessSpec, pio garden.ProcessIO) (garden.Process, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == "stopped" {
+		return nil, ErrContainerStopped
+	}
+
+	c.nextPID++
+	id := fmt.Sprintf("%d", c.nextPID)
+
+	p, err := startProcess(id, spec, c.env, pio)
+	if err != nil {
+		return nil, err
+	}
+
+	c.processes[id] = p
+
+	return p, nil
+}
+
+func (c *container) Attach(processID string, pio garden.ProcessIO) (garden.Process, error) {
+	c.mu.RLock()
+	p, found := c.processes[processID]
+	c.mu.RUnlock()
+
+	if !found {
+		return nil, garden.ProcessNotFoundError{Handle: c.handle, ProcessID: processID}
+	}
+
+	p.attach(pio)
+
+	return p, nil
+}
+
+func (c *container) Processes() ([]garden.ProcessInfo, error) {
+	c.mu.RLock()
+	processes := make([]*process, 0, len(c.processes))
+	for _, p := range c.processes {
+		processes = append(processes, p)
+	}
+	c.mu.RUnlock()
+
+	sort.Sort(byStartTime(processes))
+
+	infos := make([]garden.ProcessInfo, len(processes))
+	for i, p := range processes {
+		infos[i] = p.info()
+	}
+
+	return infos, nil
+}
+
+func (c *container) Process(processID string) (garden.ProcessInfo, error) {
+	c.mu.RLock()
+	p, found := c.processes[processID]
+	c.mu.RUnlock()
+
+	if !found {
+		return garden.ProcessInfo{}, garden.ProcessNotFoundError{Handle: c.handle, ProcessID: processID}
+	}
+
+	return p.info(), nil
+}
+
+func (c *container) Metrics() (garden.Metrics, error) {
+	return garden.Metrics{}, nil
+}
+
+func (c *container) SetGraceTime(graceTime time.Duration) error {
+	c.mu.Lock()
+	c.graceTime = graceTime
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *container) Properties() (garden.Properties, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.copyProperties(), nil
+}
+
+func (c *container) Property(name string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, found := c.properties[name]
+	if !found {
+		return "", fmt.Errorf("property does not exist: %s", name)
+	}
+
+	return value, nil
+}
+
+func (c *container) SetProperty(name string, value string) error {
+	c.mu.Lock()
+	c.properties[name] = value
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *container) RemoveProperty(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, found := c.properties[name]; !found {
+		return fmt.Errorf("property does not exist: %s", name)
+	}
+
+	delete(c.properties, name)
+
+	return nil
+}
+
+func (c *container) UpdateProperties(set garden.Properties, remove []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, value := range set {
+		c.properties[name] = value
+	}
+
+	for _, name := range remove {
+		delete(c.properties, name)
+	}
+
+	return nil
+}
+
+func (c *container) currentGraceTime() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.graceTime
+}
+
+func (c *container) hasProperties(filter garden.Properties) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for k, v := range filter {
+		if value, found := c.properties[k]; !found || value != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *container) matches(selector garden.Selector) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return selector.Matches(c.properties)
+}
+
+func (c *container) killAll() {
+	c.mu.RLock()
+	processes := c.runningProcesses()
+	c.mu.RUnlock()
+
+	for _, p := range processes {
+		p.signal(garden.SignalKill)
+	}
+}
+
+// must be called with c.mu held
+func (c *container) runningProcesses() []*process {
+	running := []*process{}
+	for _, p := range c.processes {
+		select {
+		case <-p.exited:
+		default:
+			running = append(running, p)
+		}
+	}
+
+	return running
+}
+
+// must be called with c.mu held
+func (c *container) copyProperties() garden.Properties {
+	properties := garden.Properties{}
+	for k, v := range c.properties {
+		properties[k] = v
+	}
+
+	return properties
+}
+
+type byStartTime []*process
+
+func (ps byStartTime) Len() int      { return len(ps) }
+func (ps byStartTime) Swap(i, j int) { ps[i], ps[j] = ps[j], ps[i] }
+func (ps byStartTime) Less(i, j int) bool {
+	return ps[i].startedAt.Before(ps[j].startedAt)
+}
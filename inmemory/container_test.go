@@ -0,0 +1,359 @@
+package inmemory_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/inmemory"
+)
+
+var _ = Describe("Container", func() {
+	var backend *inmemory.Backend
+	var container garden.Container
+
+	BeforeEach(func() {
+		backend = inmemory.New()
+
+		var err error
+		container, err = backend.Create(garden.ContainerSpec{
+			Handle:     "some-handle",
+			Properties: garden.Properties{"foo": "bar"},
+			Limits: garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				CPU:    garden.CPULimits{LimitInShares: 5},
+			},
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		backend.Stop()
+	})
+
+	Describe("properties", func() {
+		It("starts with the properties given at creation", func() {
+			Ω(container.Properties()).Should(Equal(garden.Properties{"foo": "bar"}))
+		})
+
+		It("can set, get and remove properties", func() {
+			Ω(container.SetProperty("a", "b")).Should(Succeed())
+			Ω(container.Property("a")).Should(Equal("b"))
+
+			Ω(container.RemoveProperty("a")).Should(Succeed())
+
+			_, err := container.Property("a")
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("fails to remove a property that does not exist", func() {
+			Ω(container.RemoveProperty("bogus")).ShouldNot(Succeed())
+		})
+
+		It("can set and remove several properties at once", func() {
+			updater := container.(garden.PropertiesUpdater)
+
+			Ω(updater.UpdateProperties(garden.Properties{"a": "b", "c": "d"}, []string{"foo", "bogus"})).Should(Succeed())
+			Ω(container.Properties()).Should(Equal(garden.Properties{"a": "b", "c": "d"}))
+		})
+	})
+
+	Describe("limits", func() {
+		It("reports the limits given at creation", func() {
+			Ω(container.CurrentMemoryLimits()).Should(Equal(garden.MemoryLimits{LimitInBytes: 1024}))
+			Ω(container.CurrentCPULimits()).Should(Equal(garden.CPULimits{LimitInShares: 5}))
+			Ω(container.CurrentDiskLimits()).Should(BeZero())
+			Ω(container.CurrentBandwidthLimits()).Should(BeZero())
+		})
+
+		It("reports the limits set since", func() {
+			Ω(container.SetMemoryLimits(garden.MemoryLimits{LimitInBytes: 2048})).Should(Succeed())
+			Ω(container.SetCPULimits(garden.CPULimits{LimitInShares: 10})).Should(Succeed())
+			Ω(container.SetDiskLimits(garden.DiskLimits{ByteHard: 4096})).Should(Succeed())
+			Ω(container.SetBandwidthLimits(garden.BandwidthLimits{RateInBytesPerSecond: 1})).Should(Succeed())
+
+			Ω(container.CurrentMemoryLimits()).Should(Equal(garden.MemoryLimits{LimitInBytes: 2048}))
+			Ω(container.CurrentCPULimits()).Should(Equal(garden.CPULimits{LimitInShares: 10}))
+			Ω(container.CurrentDiskLimits()).Should(Equal(garden.DiskLimits{ByteHard: 4096}))
+			Ω(container.CurrentBandwidthLimits()).Should(Equal(garden.BandwidthLimits{RateInBytesPerSecond: 1}))
+		})
+	})
+
+	Describe("NetIn", func() {
+		It("acquires a host port when none is given and defaults the container port to it", func() {
+			hostPort, containerPort, err := container.NetIn(0, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(hostPort).ShouldNot(BeZero())
+			Ω(containerPort).Should(Equal(hostPort))
+
+			otherHostPort, _, err := container.NetIn(0, 8080)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(otherHostPort).ShouldNot(Equal(hostPort))
+		})
+
+		It("records the mapping in the container's info", func() {
+			_, _, err := container.NetIn(1234, 5678)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			info, err := container.Info()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(info.MappedPorts).Should(ConsistOf(garden.PortMapping{HostPort: 1234, ContainerPort: 5678}))
+		})
+
+		It("fails once the host port pool is exhausted", func() {
+			var err error
+			for err == nil {
+				_, _, err = container.NetIn(0, 8080)
+			}
+
+			Ω(err).Should(BeAssignableToTypeOf(garden.CapacityExhaustedError{}))
+		})
+	})
+
+	Describe("streaming files", func() {
+		BeforeEach(func() {
+			buf := new(bytes.Buffer)
+			tw := tar.NewWriter(buf)
+
+			Ω(tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755})).Should(Succeed())
+			Ω(tw.WriteHeader(&tar.Header{Name: "dir/file", Typeflag: tar.TypeReg, Mode: 0644, Size: 5})).Should(Succeed())
+			_, err := tw.Write([]byte("hello"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tw.Close()).Should(Succeed())
+
+			Ω(container.StreamIn(garden.StreamInSpec{Path: "/some/dst", TarStream: buf})).Should(Succeed())
+		})
+
+		It("streams out a file that was streamed in", func() {
+			reader, err := container.StreamOut(garden.StreamOutSpec{Path: "/some/dst/dir/file"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			tr := tar.NewReader(reader)
+
+			hdr, err := tr.Next()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(hdr.Name).Should(Equal("file"))
+
+			Ω(ioutil.ReadAll(tr)).Should(Equal([]byte("hello")))
+
+			_, err = tr.Next()
+			Ω(err).Should(Equal(io.EOF))
+		})
+
+		It("streams out a directory including the directory itself", func() {
+			reader, err := container.StreamOut(garden.StreamOutSpec{Path: "/some/dst/dir"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(tarEntries(reader)).Should(Equal([]string{"dir/", "dir/file"}))
+		})
+
+		It("streams out the contents of a directory when the path ends in a slash", func() {
+			reader, err := container.StreamOut(garden.StreamOutSpec{Path: "/some/dst/dir/"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(tarEntries(reader)).Should(Equal([]string{"./", "./file"}))
+		})
+
+		It("fails to stream out a path that does not exist", func() {
+			_, err := container.StreamOut(garden.StreamOutSpec{Path: "/bogus"})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Run", func() {
+		It("streams stdin, stdout and stderr and reports the exit status", func() {
+			stdout := gbytes.NewBuffer()
+			stderr := gbytes.NewBuffer()
+
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sh",
+				Args: []string{"-c", "cat; echo oops >&2; exit 42"},
+			}, garden.ProcessIO{
+				Stdin:  strings.NewReader("hello"),
+				Stdout: stdout,
+				Stderr: stderr,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(process.Wait()).Should(Equal(42))
+			Ω(stdout).Should(gbytes.Say("hello"))
+			Ω(stderr).Should(gbytes.Say("oops"))
+		})
+
+		It("lists running processes in the container's info", func() {
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sleep",
+				Args: []string{"100"},
+			}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			info, err := container.Info()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(info.ProcessIDs).Should(ConsistOf(process.ID()))
+
+			Ω(process.Signal(garden.SignalKill)).Should(Succeed())
+			Ω(process.Wait()).Should(Equal(137))
+		})
+
+		It("delivers POSIX signals to the process", func() {
+			stdout := gbytes.NewBuffer()
+
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sh",
+				Args: []string{"-c", "trap 'echo hup' HUP; echo ready; while true; do sleep 0.01; done"},
+			}, garden.ProcessIO{
+				Stdout: stdout,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Eventually(stdout).Should(gbytes.Say("ready"))
+
+			Ω(process.Signal(garden.SignalHangup)).Should(Succeed())
+			Eventually(stdout).Should(gbytes.Say("hup"))
+
+			Ω(process.Signal(garden.SignalKill)).Should(Succeed())
+			Ω(process.Wait()).Should(Equal(137))
+		})
+
+		It("describes the processes it has run", func() {
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sleep",
+				Args: []string{"100"},
+				User: "alice",
+			}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			exited, err := container.Run(garden.ProcessSpec{Path: "false"}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(exited.Wait()).Should(Equal(1))
+
+			processes, err := container.Processes()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(processes).Should(HaveLen(2))
+
+			Ω(processes[0].ID).Should(Equal(process.ID()))
+			Ω(processes[0].Spec.Args).Should(Equal([]string{"100"}))
+			Ω(processes[0].Spec.User).Should(Equal("alice"))
+			Ω(processes[0].State).Should(Equal("running"))
+			Ω(processes[0].StartedAt).ShouldNot(BeZero())
+
+			Ω(processes[1].ID).Should(Equal(exited.ID()))
+			Ω(processes[1].State).Should(Equal("exited"))
+			Ω(processes[1].ExitStatus).Should(Equal(1))
+
+			Ω(container.Process(exited.ID())).Should(Equal(processes[1]))
+
+			Ω(process.Signal(garden.SignalKill)).Should(Succeed())
+			Ω(process.Wait()).Should(Equal(137))
+		})
+
+		It("fails to describe an unknown process", func() {
+			_, err := container.Process("bogus")
+			Ω(err).Should(Equal(garden.ProcessNotFoundError{Handle: container.Handle(), ProcessID: "bogus"}))
+		})
+
+		It("fails when the executable does not exist", func() {
+			_, err := container.Run(garden.ProcessSpec{Path: "/does/not/exist"}, garden.ProcessIO{})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Attach", func() {
+		It("streams output of a running process to the attached writers", func() {
+			stdin, stdinW := io.Pipe()
+
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "cat",
+			}, garden.ProcessIO{
+				Stdin: stdin,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			stdout := gbytes.NewBuffer()
+			attached, err := container.Attach(process.ID(), garden.ProcessIO{
+				Stdout: stdout,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = stdinW.Write([]byte("hello\n"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Eventually(stdout).Should(gbytes.Say("hello"))
+
+			Ω(stdinW.Close()).Should(Succeed())
+			Ω(attached.Wait()).Should(Equal(0))
+		})
+
+		It("fails for an unknown process", func() {
+			_, err := container.Attach("bogus", garden.ProcessIO{})
+			Ω(err).Should(Equal(garden.ProcessNotFoundError{Handle: container.Handle(), ProcessID: "bogus"}))
+		})
+	})
+
+	Describe("Stop", func() {
+		It("terminates running processes and marks the container as stopped", func() {
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sleep",
+				Args: []string{"100"},
+			}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(container.Stop(false)).Should(Succeed())
+			Ω(process.Wait()).Should(Equal(143))
+
+			info, err := container.Info()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(info.State).Should(Equal("stopped"))
+			Ω(info.ProcessIDs).Should(BeEmpty())
+		})
+
+		It("kills running processes when kill is true", func() {
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sh",
+				Args: []string{"-c", "trap '' TERM; sleep 100"},
+			}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			done := make(chan struct{})
+			go func() {
+				container.Stop(true)
+				close(done)
+			}()
+
+			Eventually(done, 5*time.Second).Should(BeClosed())
+			Ω(process.Wait()).Should(Equal(137))
+		})
+
+		It("refuses to run processes afterwards", func() {
+			Ω(container.Stop(false)).Should(Succeed())
+
+			_, err := container.Run(garden.ProcessSpec{Path: "true"}, garden.ProcessIO{})
+			Ω(err).Should(Equal(inmemory.ErrContainerStopped))
+		})
+	})
+})
+
+func tarEntries(reader io.Reader) []string {
+	names := []string{}
+
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return names
+		}
+		Ω(err).ShouldNot(HaveOccurred())
+
+		names = append(names, hdr.Name)
+	}
+}
@@ -0,0 +1,196 @@
+package inmemory
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type file struct {
+	mode     os.FileMode
+	modTime  time.Time
+	data     []byte
+	linkname string
+}
+
+// filesystem is a flat map of absolute, cleaned paths to files, standing in
+// for a container's root filesystem.
+type filesystem struct {
+	mu    sync.RWMutex
+	files map[string]*file
+}
+
+func newFilesystem() *filesystem {
+	return &filesystem{
+		files: map[string]*file{
+			"/": {mode: os.ModeDir | 0755, modTime: time.Now()},
+		},
+	}
+}
+
+// streamIn extracts the tar stream into the directory dst, creating it if
+// necessary.
+func (fs *filesystem) streamIn(dst string, tarStream io.Reader) error {
+	dst = cleanPath(dst)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if err := fs.mkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(tarStream)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		name := path.Join(dst, hdr.Name)
+		mode := os.FileMode(hdr.Mode).Perm()
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = fs.mkdirAll(name, mode)
+
+		case tar.TypeReg, tar.TypeRegA:
+			var data []byte
+			data, err = ioutil.ReadAll(tr)
+			if err == nil {
+				err = fs.create(name, &file{mode: mode, modTime: hdr.ModTime, data: data})
+			}
+
+		case tar.TypeSymlink:
+			err = fs.create(name, &file{mode: os.ModeSymlink | mode, modTime: hdr.ModTime, linkname: hdr.Linkname})
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// streamOut returns a tar stream of src. As with garden-linux, if src ends in
+// a slash the contents of the directory are streamed, otherwise the directory
+// (or file) itself is.
+func (fs *filesystem) streamOut(src string) (io.ReadCloser, error) {
+	root := cleanPath(src)
+
+	prefix := path.Base(root)
+	if strings.HasSuffix(src, "/") || root == "/" {
+		prefix = "."
+	}
+
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	if _, found := fs.files[root]; !found {
+		return nil, fmt.Errorf("stat %s: no such file or directory", src)
+	}
+
+	paths := []string{}
+	for p := range fs.files {
+		if p == root || strings.HasPrefix(p, strings.TrimSuffix(root, "/")+"/") {
+			paths = append(paths, p)
+		}
+	}
+
+	sort.Strings(paths)
+
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+
+	for _, p := range paths {
+		f := fs.files[p]
+
+		name := path.Join(prefix, strings.TrimPrefix(p, root))
+		if prefix == "." {
+			name = "./" + strings.TrimPrefix(strings.TrimPrefix(p, root), "/")
+		}
+
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    int64(f.mode.Perm()),
+			ModTime: f.modTime,
+		}
+
+		switch {
+		case f.mode.IsDir():
+			hdr.Typeflag = tar.TypeDir
+			if !strings.HasSuffix(hdr.Name, "/") {
+				hdr.Name += "/"
+			}
+		case f.mode&os.ModeSymlink != 0:
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = f.linkname
+		default:
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = int64(len(f.data))
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+
+		if _, err := tw.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return ioutil.NopCloser(buf), nil
+}
+
+// must be called with fs.mu held
+func (fs *filesystem) mkdirAll(dir string, mode os.FileMode) error {
+	if existing, found := fs.files[dir]; found {
+		if !existing.mode.IsDir() {
+			return fmt.Errorf("mkdir %s: not a directory", dir)
+		}
+
+		return nil
+	}
+
+	if err := fs.mkdirAll(path.Dir(dir), 0755); err != nil {
+		return err
+	}
+
+	fs.files[dir] = &file{mode: os.ModeDir | mode, modTime: time.Now()}
+
+	return nil
+}
+
+// must be called with fs.mu held
+func (fs *filesystem) create(name string, f *file) error {
+	if err := fs.mkdirAll(path.Dir(name), 0755); err != nil {
+		return err
+	}
+
+	if existing, found := fs.files[name]; found && existing.mode.IsDir() {
+		return fmt.Errorf("open %s: is a directory", name)
+	}
+
+	fs.files[name] = f
+
+	return nil
+}
+
+func cleanPath(p string) string {
+	return path.Clean("/" + p)
+}
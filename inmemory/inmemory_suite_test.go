@@ -0,0 +1,13 @@
+package inmemory_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestInmemory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "In-memory Backend Suite")
+}
@@ -0,0 +1,198 @@
+package inmemory
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+type process struct {
+	id        string
+	spec      garden.ProcessSpec
+	startedAt time.Time
+	cmd       *exec.Cmd
+
+	stdin  io.WriteCloser
+	stdout *fanOut
+	stderr *fanOut
+
+	exited     chan struct{}
+	exitStatus int
+	exitErr    error
+}
+
+// startProcess runs the process on the host. The user, resource limits and
+// TTY settings of the spec are ignored.
+func startProcess(id string, spec garden.ProcessSpec, containerEnv []string, pio garden.ProcessIO) (*process, error) {
+	cmd := exec.Command(spec.Path, spec.Args...)
+	cmd.Dir = spec.Dir
+	cmd.Env = append(append(os.Environ(), containerEnv...), spec.Env...)
+
+	// run in its own process group so that signals reach any children too
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	p := &process{
+		id:   id,
+		spec: spec,
+		cmd:  cmd,
+
+		stdout: new(fanOut),
+		stderr: new(fanOut),
+
+		exited: make(chan struct{}),
+	}
+
+	cmd.Stdout = p.stdout
+	cmd.Stderr = p.stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	p.stdin = stdin
+
+	p.attach(pio)
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p.startedAt = time.Now()
+
+	go p.wait()
+
+	return p, nil
+}
+
+func (p *process) ID() string {
+	return p.id
+}
+
+func (p *process) Wait() (int, error) {
+	<-p.exited
+	return p.exitStatus, p.exitErr
+}
+
+func (p *process) SetTTY(garden.TTYSpec) error {
+	return nil
+}
+
+func (p *process) Signal(signal garden.Signal) error {
+	return p.signal(signal)
+}
+
+func (p *process) info() garden.ProcessInfo {
+	info := garden.ProcessInfo{
+		ID:        p.id,
+		Spec:      p.spec,
+		StartedAt: p.startedAt,
+		State:     "running",
+		TTY:       p.spec.TTY != nil,
+	}
+
+	select {
+	case <-p.exited:
+		info.State = "exited"
+		info.ExitStatus = p.exitStatus
+	default:
+	}
+
+	return info
+}
+
+func (p *process) attach(pio garden.ProcessIO) {
+	if pio.Stdout != nil {
+		p.stdout.add(pio.Stdout)
+	}
+
+	if pio.Stderr != nil {
+		p.stderr.add(pio.Stderr)
+	}
+
+	if pio.Stdin != nil {
+		go func() {
+			if _, err := io.Copy(p.stdin, pio.Stdin); err == nil {
+				p.stdin.Close()
+			}
+		}()
+	}
+}
+
+var signals = map[garden.Signal]syscall.Signal{
+	garden.SignalTerminate:    syscall.SIGTERM,
+	garden.SignalKill:         syscall.SIGKILL,
+	garden.SignalHangup:       syscall.SIGHUP,
+	garden.SignalInterrupt:    syscall.SIGINT,
+	garden.SignalQuit:         syscall.SIGQUIT,
+	garden.SignalUser1:        syscall.SIGUSR1,
+	garden.SignalUser2:        syscall.SIGUSR2,
+	garden.SignalWindowChange: syscall.SIGWINCH,
+}
+
+func (p *process) signal(signal garden.Signal) error {
+	sig, ok := signals[signal]
+	if !ok {
+		return fmt.Errorf("unknown signal: %d", signal)
+	}
+
+	select {
+	case <-p.exited:
+		return nil
+	default:
+	}
+
+	return syscall.Kill(-p.cmd.Process.Pid, sig)
+}
+
+func (p *process) wait() {
+	err := p.cmd.Wait()
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		status := exitErr.Sys().(syscall.WaitStatus)
+		if status.Signaled() {
+			p.exitStatus = 128 + int(status.Signal())
+		} else {
+			p.exitStatus = status.ExitStatus()
+		}
+	} else if err != nil {
+		p.exitErr = err
+	}
+
+	close(p.exited)
+}
+
+// fanOut copies everything written to it to each of the attached writers,
+// detaching any writer that returns an error.
+type fanOut struct {
+	mu      sync.Mutex
+	writers []io.Writer
+}
+
+func (f *fanOut) add(w io.Writer) {
+	f.mu.Lock()
+	f.writers = append(f.writers, w)
+	f.mu.Unlock()
+}
+
+func (f *fanOut) Write(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	live := f.writers[:0]
+	for _, w := range f.writers {
+		if _, err := w.Write(data); err == nil {
+			live = append(live, w)
+		}
+	}
+
+	f.writers = live
+
+	return len(data), nil
+}
@@ -12,8 +12,12 @@ const (
 	BulkInfo    = "BulkInfo"
 	BulkMetrics = "BulkMetrics"
 	Destroy     = "Destroy"
+	BulkDestroy = "BulkDestroy"
 
-	Stop = "Stop"
+	WaitForDestroy = "WaitForDestroy"
+
+	Stop     = "Stop"
+	BulkStop = "BulkStop"
 
 	StreamIn  = "StreamIn"
 	StreamOut = "StreamOut"
@@ -26,21 +30,35 @@ const (
 	CurrentDiskLimits      = "CurrentDiskLimits"
 	CurrentMemoryLimits    = "CurrentMemoryLimits"
 
+	SetBandwidthLimits = "SetBandwidthLimits"
+	SetCPULimits       = "SetCPULimits"
+	SetDiskLimits      = "SetDiskLimits"
+	SetMemoryLimits    = "SetMemoryLimits"
+
 	NetIn  = "NetIn"
 	NetOut = "NetOut"
 
 	Run    = "Run"
 	Attach = "Attach"
 
+	Processes     = "Processes"
+	Process       = "Process"
+	ProcessStatus = "ProcessStatus"
+
 	SetGraceTime = "SetGraceTime"
 
-	Properties  = "Properties"
-	Property    = "Property"
-	SetProperty = "SetProperty"
+	Properties       = "Properties"
+	UpdateProperties = "UpdateProperties"
+	Property         = "Property"
+	SetProperty      = "SetProperty"
 
 	Metrics = "Metrics"
 
 	RemoveProperty = "RemoveProperty"
+
+	Events = "Events"
+
+	PendingReaps = "PendingReaps"
 )
 
 var Routes = rata.Routes{
@@ -55,7 +73,10 @@ var Routes = rata.Routes{
 	{Path: "/containers/bulk_metrics", Method: "GET", Name: BulkMetrics},
 
 	{Path: "/containers/:handle", Method: "DELETE", Name: Destroy},
+	{Path: "/containers/bulk_destroy", Method: "POST", Name: BulkDestroy},
+	{Path: "/containers/:handle/destroy", Method: "GET", Name: WaitForDestroy},
 	{Path: "/containers/:handle/stop", Method: "PUT", Name: Stop},
+	{Path: "/containers/bulk_stop", Method: "PUT", Name: BulkStop},
 
 	{Path: "/containers/:handle/files", Method: "PUT", Name: StreamIn},
 	{Path: "/containers/:handle/files", Method: "GET", Name: StreamOut},
@@ -64,6 +85,10 @@ var Routes = rata.Routes{
 	{Path: "/containers/:handle/limits/cpu", Method: "GET", Name: CurrentCPULimits},
 	{Path: "/containers/:handle/limits/disk", Method: "GET", Name: CurrentDiskLimits},
 	{Path: "/containers/:handle/limits/memory", Method: "GET", Name: CurrentMemoryLimits},
+	{Path: "/containers/:handle/limits/bandwidth", Method: "PUT", Name: SetBandwidthLimits},
+	{Path: "/containers/:handle/limits/cpu", Method: "PUT", Name: SetCPULimits},
+	{Path: "/containers/:handle/limits/disk", Method: "PUT", Name: SetDiskLimits},
+	{Path: "/containers/:handle/limits/memory", Method: "PUT", Name: SetMemoryLimits},
 
 	{Path: "/containers/:handle/net/in", Method: "POST", Name: NetIn},
 	{Path: "/containers/:handle/net/out", Method: "POST", Name: NetOut},
@@ -72,13 +97,21 @@ var Routes = rata.Routes{
 	{Path: "/containers/:handle/processes/:pid/attaches/:streamid/stderr", Method: "GET", Name: Stderr},
 	{Path: "/containers/:handle/processes", Method: "POST", Name: Run},
 	{Path: "/containers/:handle/processes/:pid", Method: "GET", Name: Attach},
+	{Path: "/containers/:handle/processes", Method: "GET", Name: Processes},
+	{Path: "/containers/:handle/processes/:pid/info", Method: "GET", Name: Process},
+	{Path: "/containers/:handle/processes/:pid/status", Method: "GET", Name: ProcessStatus},
 
 	{Path: "/containers/:handle/grace_time", Method: "PUT", Name: SetGraceTime},
 
 	{Path: "/containers/:handle/properties", Method: "GET", Name: Properties},
+	{Path: "/containers/:handle/properties", Method: "PUT", Name: UpdateProperties},
 	{Path: "/containers/:handle/properties/:key", Method: "GET", Name: Property},
 	{Path: "/containers/:handle/properties/:key", Method: "PUT", Name: SetProperty},
 	{Path: "/containers/:handle/properties/:key", Method: "DELETE", Name: RemoveProperty},
 
 	{Path: "/containers/:handle/metrics", Method: "GET", Name: Metrics},
+
+	{Path: "/events", Method: "GET", Name: Events},
+
+	{Path: "/reaps", Method: "GET", Name: PendingReaps},
 }
@@ -0,0 +1,261 @@
+package garden
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Selector selects containers by their properties. A container is selected
+// if its properties meet every requirement.
+//
+// A selector is written as a comma-separated list of requirements, in the
+// style of Kubernetes label selectors:
+//
+//	key            the property is set
+//	!key           the property is not set
+//	key=value      the property is set to value (also key==value)
+//	key!=value     the property is not set to value, or is not set
+//	key^=prefix    the property is set to a value beginning with prefix
+//	key in (a,b)   the property is set to one of the values
+//	key notin (a,b) the property is not set to any of the values, or is not set
+//
+// Keys and values may not contain whitespace or any of ",()=!^".
+type Selector []SelectorRequirement
+
+type SelectorOperator string
+
+const (
+	SelectorExists       SelectorOperator = "exists"
+	SelectorDoesNotExist SelectorOperator = "!"
+	SelectorEquals       SelectorOperator = "="
+	SelectorNotEquals    SelectorOperator = "!="
+	SelectorPrefix       SelectorOperator = "^="
+	SelectorIn           SelectorOperator = "in"
+	SelectorNotIn        SelectorOperator = "notin"
+)
+
+// SelectorRequirement is a requirement on the value of one property. Values
+// is empty for SelectorExists and SelectorDoesNotExist, has one value for
+// SelectorEquals, SelectorNotEquals and SelectorPrefix, and any number for
+// SelectorIn and SelectorNotIn.
+type SelectorRequirement struct {
+	Key      string
+	Operator SelectorOperator
+	Values   []string
+}
+
+// ParseSelector parses a selector written as described on Selector,
+// returning an InvalidRequestError if it is malformed.
+func ParseSelector(selector string) (Selector, error) {
+	var parsed Selector
+
+	for _, requirement := range splitRequirements(selector) {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			continue
+		}
+
+		parsedRequirement, err := parseRequirement(requirement)
+		if err != nil {
+			return nil, InvalidRequestError{
+				Fields: []FieldError{{Field: "selector", Message: err.Error()}},
+			}
+		}
+
+		parsed = append(parsed, parsedRequirement)
+	}
+
+	return parsed, nil
+}
+
+// SelectorFromProperties returns the selector that requires each of the
+// properties to be set to its value, as Client.Containers does.
+func SelectorFromProperties(properties Properties) Selector {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	selector := make(Selector, len(keys))
+	for i, key := range keys {
+		selector[i] = SelectorRequirement{Key: key, Operator: SelectorEquals, Values: []string{properties[key]}}
+	}
+
+	return selector
+}
+
+// Matches reports whether the properties meet every requirement of the
+// selector.
+func (selector Selector) Matches(properties Properties) bool {
+	for _, requirement := range selector {
+		if !requirement.Matches(properties) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equalities returns the properties the selector requires to be set to a
+// single value, for filtering with Client.Containers before evaluating the
+// rest of the selector.
+func (selector Selector) Equalities() Properties {
+	properties := Properties{}
+
+	for _, requirement := range selector {
+		if requirement.Operator == SelectorEquals {
+			properties[requirement.Key] = requirement.Values[0]
+		}
+	}
+
+	return properties
+}
+
+func (selector Selector) String() string {
+	requirements := make([]string, len(selector))
+	for i, requirement := range selector {
+		requirements[i] = requirement.String()
+	}
+
+	return strings.Join(requirements, ",")
+}
+
+// Matches reports whether the properties meet the requirement.
+func (requirement SelectorRequirement) Matches(properties Properties) bool {
+	value, found := properties[requirement.Key]
+
+	switch requirement.Operator {
+	case SelectorExists:
+		return found
+	case SelectorDoesNotExist:
+		return !found
+	case SelectorEquals:
+		return found && value == requirement.Values[0]
+	case SelectorNotEquals:
+		return !found || value != requirement.Values[0]
+	case SelectorPrefix:
+		return found && strings.HasPrefix(value, requirement.Values[0])
+	case SelectorIn:
+		return found && containsString(requirement.Values, value)
+	case SelectorNotIn:
+		return !found || !containsString(requirement.Values, value)
+	}
+
+	return false
+}
+
+func (requirement SelectorRequirement) String() string {
+	switch requirement.Operator {
+	case SelectorExists:
+		return requirement.Key
+	case SelectorDoesNotExist:
+		return "!" + requirement.Key
+	case SelectorIn, SelectorNotIn:
+		return fmt.Sprintf("%s %s (%s)", requirement.Key, requirement.Operator, strings.Join(requirement.Values, ","))
+	}
+
+	return requirement.Key + string(requirement.Operator) + strings.Join(requirement.Values, "")
+}
+
+// splitRequirements splits a selector on the commas that are not within the
+// parentheses of a set of values.
+func splitRequirements(selector string) []string {
+	var requirements []string
+
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				requirements = append(requirements, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(requirements, selector[start:])
+}
+
+func parseRequirement(requirement string) (SelectorRequirement, error) {
+	if strings.HasPrefix(requirement, "!") && !strings.ContainsAny(requirement, "=") {
+		key := strings.TrimSpace(requirement[1:])
+		if !validSelectorToken(key) {
+			return SelectorRequirement{}, fmt.Errorf("has an invalid key in %q", requirement)
+		}
+
+		return SelectorRequirement{Key: key, Operator: SelectorDoesNotExist}, nil
+	}
+
+	for _, op := range []struct {
+		token    string
+		operator SelectorOperator
+	}{
+		{"!=", SelectorNotEquals},
+		{"^=", SelectorPrefix},
+		{"==", SelectorEquals},
+		{"=", SelectorEquals},
+	} {
+		i := strings.Index(requirement, op.token)
+		if i < 0 {
+			continue
+		}
+
+		key := strings.TrimSpace(requirement[:i])
+		value := strings.TrimSpace(requirement[i+len(op.token):])
+
+		if !validSelectorToken(key) {
+			return SelectorRequirement{}, fmt.Errorf("has an invalid key in %q", requirement)
+		}
+
+		if value != "" && !validSelectorToken(value) {
+			return SelectorRequirement{}, fmt.Errorf("has an invalid value in %q", requirement)
+		}
+
+		return SelectorRequirement{Key: key, Operator: op.operator, Values: []string{value}}, nil
+	}
+
+	fields := strings.Fields(requirement)
+	if len(fields) == 1 {
+		if !validSelectorToken(fields[0]) {
+			return SelectorRequirement{}, fmt.Errorf("has an invalid key in %q", requirement)
+		}
+
+		return SelectorRequirement{Key: fields[0], Operator: SelectorExists}, nil
+	}
+
+	if len(fields) < 3 || (fields[1] != string(SelectorIn) && fields[1] != string(SelectorNotIn)) {
+		return SelectorRequirement{}, fmt.Errorf("has an invalid requirement %q", requirement)
+	}
+
+	key := fields[0]
+	if !validSelectorToken(key) {
+		return SelectorRequirement{}, fmt.Errorf("has an invalid key in %q", requirement)
+	}
+
+	set := strings.Join(fields[2:], "")
+	if !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+		return SelectorRequirement{}, fmt.Errorf("must give the values of %q in parentheses", requirement)
+	}
+
+	var values []string
+	for _, value := range strings.Split(set[1:len(set)-1], ",") {
+		if !validSelectorToken(value) {
+			return SelectorRequirement{}, fmt.Errorf("has an invalid value in %q", requirement)
+		}
+
+		values = append(values, value)
+	}
+
+	return SelectorRequirement{Key: key, Operator: SelectorOperator(fields[1]), Values: values}, nil
+}
+
+func validSelectorToken(token string) bool {
+	return token != "" && !strings.ContainsAny(token, " \t\n,()=!^")
+}
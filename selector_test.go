@@ -0,0 +1,89 @@
+package garden_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+var _ = Describe("Selector", func() {
+	parse := func(expr string) garden.Selector {
+		selector, err := garden.ParseSelector(expr)
+		Ω(err).ShouldNot(HaveOccurred())
+		return selector
+	}
+
+	Describe("ParseSelector", func() {
+		It("parses each operator", func() {
+			Ω(parse("a,!b,c=d,e==f,g!=h,i^=j,k in (l, m),n notin (o)")).Should(Equal(garden.Selector{
+				{Key: "a", Operator: garden.SelectorExists},
+				{Key: "b", Operator: garden.SelectorDoesNotExist},
+				{Key: "c", Operator: garden.SelectorEquals, Values: []string{"d"}},
+				{Key: "e", Operator: garden.SelectorEquals, Values: []string{"f"}},
+				{Key: "g", Operator: garden.SelectorNotEquals, Values: []string{"h"}},
+				{Key: "i", Operator: garden.SelectorPrefix, Values: []string{"j"}},
+				{Key: "k", Operator: garden.SelectorIn, Values: []string{"l", "m"}},
+				{Key: "n", Operator: garden.SelectorNotIn, Values: []string{"o"}},
+			}))
+		})
+
+		It("ignores whitespace around requirements and operators", func() {
+			Ω(parse(" foo = bar , baz ")).Should(Equal(garden.Selector{
+				{Key: "foo", Operator: garden.SelectorEquals, Values: []string{"bar"}},
+				{Key: "baz", Operator: garden.SelectorExists},
+			}))
+		})
+
+		It("accepts an empty value", func() {
+			Ω(parse("foo=")).Should(Equal(garden.Selector{
+				{Key: "foo", Operator: garden.SelectorEquals, Values: []string{""}},
+			}))
+		})
+
+		It("parses an empty selector as selecting everything", func() {
+			Ω(parse("")).Should(BeEmpty())
+		})
+
+		It("rejects malformed requirements with an InvalidRequestError", func() {
+			for _, expr := range []string{"=bar", "foo=b^r", "foo bar", "foo like (a)", "foo in a", "foo in (a,,b)"} {
+				_, err := garden.ParseSelector(expr)
+				Ω(err).Should(BeAssignableToTypeOf(garden.InvalidRequestError{}), expr)
+				Ω(err.(garden.InvalidRequestError).Fields[0].Field).Should(Equal("selector"))
+			}
+		})
+	})
+
+	Describe("Matches", func() {
+		properties := garden.Properties{"foo": "bar", "env": "prod"}
+
+		It("matches properties that meet every requirement", func() {
+			for _, expr := range []string{
+				"foo", "!baz", "foo=bar", "foo!=baz", "baz!=bar", "env^=pr",
+				"env in (dev,prod)", "env notin (dev)", "baz notin (dev)", "foo=bar,env in (prod)",
+			} {
+				Ω(parse(expr).Matches(properties)).Should(BeTrue(), expr)
+			}
+		})
+
+		It("does not match properties that fail a requirement", func() {
+			for _, expr := range []string{
+				"baz", "!foo", "foo=baz", "foo!=bar", "env^=de",
+				"env in (dev)", "baz in (dev)", "env notin (prod)", "foo=bar,env in (dev)",
+			} {
+				Ω(parse(expr).Matches(properties)).Should(BeFalse(), expr)
+			}
+		})
+	})
+
+	It("writes selectors that parse back to themselves", func() {
+		selector := parse("a,!b,c=d,e!=f,g^=h,i in (j,k),l notin (m)")
+		Ω(parse(selector.String())).Should(Equal(selector))
+	})
+
+	It("builds a selector of equalities from properties", func() {
+		selector := garden.SelectorFromProperties(garden.Properties{"b": "2", "a": "1"})
+		Ω(selector.String()).Should(Equal("a=1,b=2"))
+		Ω(selector.Equalities()).Should(Equal(garden.Properties{"a": "1", "b": "2"}))
+	})
+})
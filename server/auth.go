@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/pivotal-golang/lager"
+)
+
+// Credentials describes the caller of a request, as far as the transport
+// allows it to be identified.
+type Credentials struct {
+	// Token is the bearer token from the request's Authorization header, if
+	// any.
+	Token string
+
+	// Certificate is the client certificate presented when serving TLS. It has
+	// only been verified if the server's tls.Config requires verification.
+	Certificate *x509.Certificate
+
+	// PeerUID is the user ID of the connecting process when serving on a unix
+	// socket on Linux. HasPeerUID is false when it could not be determined.
+	PeerUID    uint32
+	HasPeerUID bool
+}
+
+// Authorizer is consulted before any request is dispatched to its handler.
+//
+// It is given the name of the matched route in routes.Routes, the :handle
+// parameter of the route (empty for routes that do not address a single
+// container) and the caller's credentials. A nil error allows the request.
+// garden.UnauthorizedError and garden.ForbiddenError are reported to the
+// client as 401 and 403 respectively; any other error is reported as a
+// garden.ForbiddenError.
+//
+// Bulk routes that act on containers, such as BulkDestroy, are authorized
+// with an empty handle, and then each container they address is authorized as
+// though it were addressed alone, e.g. by Destroy.
+//
+// List is authorized with an empty handle, and then the info of each
+// container it returns is authorized as though it were got by Info;
+// containers whose info the caller may not get are listed without it.
+// PendingReaps likewise only describes the containers whose info the caller
+// may get.
+//
+// Events is authorized with an empty handle when the stream is opened, and
+// then again with the handle of each event before it is streamed; events the
+// caller may not see are skipped.
+type Authorizer interface {
+	Authorize(route string, handle string, credentials Credentials) error
+}
+
+// AuthorizerFunc adapts a function to an Authorizer.
+type AuthorizerFunc func(route string, handle string, credentials Credentials) error
+
+func (f AuthorizerFunc) Authorize(route string, handle string, credentials Credentials) error {
+	return f(route, handle, credentials)
+}
+
+// SetAuthorizer installs an Authorizer. It must be called before Start. By
+// default every request is allowed.
+func (s *GardenServer) SetAuthorizer(authorizer Authorizer) {
+	s.authorizer = authorizer
+}
+
+type peerUIDKey struct{}
+
+type peerUID struct {
+	uid uint32
+	ok  bool
+}
+
+// connContext records the peer credentials of unix socket connections so
+// that they are available to every request served on the connection.
+func connContext(ctx context.Context, conn net.Conn) context.Context {
+	uid, ok := peerUIDOf(conn)
+	return context.WithValue(ctx, peerUIDKey{}, peerUID{uid: uid, ok: ok})
+}
+
+func credentialsOf(r *http.Request) Credentials {
+	var credentials Credentials
+
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		credentials.Token = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		credentials.Certificate = r.TLS.PeerCertificates[0]
+	}
+
+	if peer, ok := r.Context().Value(peerUIDKey{}).(peerUID); ok {
+		credentials.PeerUID = peer.uid
+		credentials.HasPeerUID = peer.ok
+	}
+
+	return credentials
+}
+
+func (s *GardenServer) authorizing(route string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authorizer == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		handle := r.FormValue(":handle")
+
+		hLog := s.logger.Session("authorize", lager.Data{
+			"route":  route,
+			"handle": handle,
+		})
+
+		err := s.authorize(route, handle, r)
+		if err != nil {
+			s.writeError(w, err, hLog)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// authorize consults the Authorizer, if any, about the request, returning a
+// garden.UnauthorizedError or garden.ForbiddenError if it is not allowed.
+func (s *GardenServer) authorize(route string, handle string, r *http.Request) error {
+	if s.authorizer == nil {
+		return nil
+	}
+
+	err := s.authorizer.Authorize(route, handle, credentialsOf(r))
+	if err != nil {
+		switch err.(type) {
+		case garden.UnauthorizedError, garden.ForbiddenError:
+		default:
+			err = garden.NewForbiddenError(err.Error())
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,295 @@
+package server_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	"github.com/cloudfoundry-incubator/garden/client/connection"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+	"github.com/cloudfoundry-incubator/garden/routes"
+	"github.com/cloudfoundry-incubator/garden/server"
+)
+
+var _ = Describe("Authorization", func() {
+	var (
+		logger      *lagertest.TestLogger
+		fakeBackend *fakes.FakeBackend
+		apiServer   *server.GardenServer
+
+		authorizeErr    error
+		authorizedL     *sync.Mutex
+		authorized      []authorizeCall
+		forbiddenHandle string
+		forbiddenRoute  string
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeBackend = new(fakes.FakeBackend)
+		apiServer = nil
+
+		authorizeErr = nil
+		authorizedL = new(sync.Mutex)
+		authorized = nil
+		forbiddenHandle = ""
+		forbiddenRoute = ""
+	})
+
+	authorizer := server.AuthorizerFunc(func(route string, handle string, credentials server.Credentials) error {
+		authorizedL.Lock()
+		authorized = append(authorized, authorizeCall{route, handle, credentials})
+		authorizedL.Unlock()
+
+		if handle != "" && handle == forbiddenHandle && (forbiddenRoute == "" || route == forbiddenRoute) {
+			return garden.NewForbiddenError("not yours")
+		}
+
+		return authorizeErr
+	})
+
+	AfterEach(func() {
+		if apiServer != nil {
+			apiServer.Stop()
+		}
+	})
+
+	Context("when serving on tcp", func() {
+		var apiClient garden.Client
+
+		BeforeEach(func() {
+			apiServer = server.New("tcp", "127.0.0.1:60125", 0, fakeBackend, logger)
+			apiServer.SetAuthorizer(authorizer)
+			Ω(apiServer.Start()).Should(Succeed())
+
+			header := http.Header{}
+			header.Set("Authorization", "Bearer some-token")
+
+			apiClient = client.New(connection.NewWithHijacker(
+				connection.NewHijackStreamerWithDialerAndHeader(func(string, string) (net.Conn, error) {
+					return net.DialTimeout("tcp", "127.0.0.1:60125", 2*time.Second)
+				}, header),
+				logger,
+			))
+		})
+
+		It("passes the route, handle and bearer token to the authorizer", func() {
+			Ω(apiClient.Destroy("some-handle")).Should(Succeed())
+
+			Ω(authorized).Should(HaveLen(1))
+			Ω(authorized[0].route).Should(Equal(routes.Destroy))
+			Ω(authorized[0].handle).Should(Equal("some-handle"))
+			Ω(authorized[0].credentials.Token).Should(Equal("some-token"))
+			Ω(authorized[0].credentials.Certificate).Should(BeNil())
+			Ω(authorized[0].credentials.HasPeerUID).Should(BeFalse())
+
+			Ω(fakeBackend.DestroyCallCount()).Should(Equal(1))
+		})
+
+		It("passes an empty handle for routes that do not address a container", func() {
+			Ω(apiClient.Ping()).Should(Succeed())
+
+			Ω(authorized).Should(HaveLen(1))
+			Ω(authorized[0].route).Should(Equal(routes.Ping))
+			Ω(authorized[0].handle).Should(BeEmpty())
+		})
+
+		It("authorizes each container addressed by a bulk route as though it were addressed alone", func() {
+			forbiddenHandle = "their-handle"
+
+			results, err := apiClient.(client.Client).BulkDestroy([]string{"some-handle", "their-handle"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(authorized).Should(HaveLen(3))
+			Ω(authorized[0].route).Should(Equal(routes.BulkDestroy))
+			Ω(authorized[0].handle).Should(BeEmpty())
+			Ω(authorized[1].route).Should(Equal(routes.Destroy))
+			Ω(authorized[1].handle).Should(Equal("some-handle"))
+			Ω(authorized[2].route).Should(Equal(routes.Destroy))
+			Ω(authorized[2].handle).Should(Equal("their-handle"))
+
+			Ω(results["some-handle"].Err).Should(BeNil())
+			Ω(results["their-handle"].Err).Should(Equal(&garden.Error{Err: garden.NewForbiddenError("not yours")}))
+
+			Ω(fakeBackend.DestroyCallCount()).Should(Equal(1))
+			Ω(fakeBackend.DestroyArgsForCall(0)).Should(Equal("some-handle"))
+		})
+
+		It("only streams events about containers the caller may see", func() {
+			forbiddenHandle = "their-handle"
+			forbiddenRoute = routes.Events
+
+			stream, err := apiClient.(client.Client).Events(garden.EventFilter{})
+			Ω(err).ShouldNot(HaveOccurred())
+			defer stream.Close()
+
+			Ω(apiClient.Destroy("their-handle")).Should(Succeed())
+			Ω(apiClient.Destroy("some-handle")).Should(Succeed())
+
+			event, err := stream.Next()
+			Ω(err).ShouldNot(HaveOccurred())
+			expectEvent(event, garden.EventContainerDestroyed, "some-handle")
+		})
+
+		It("only lists the info of containers the caller may get it for", func() {
+			forbiddenHandle = "their-handle"
+			forbiddenRoute = routes.Info
+
+			someContainer := new(fakes.FakeContainer)
+			someContainer.HandleReturns("some-handle")
+
+			theirContainer := new(fakes.FakeContainer)
+			theirContainer.HandleReturns("their-handle")
+
+			fakeBackend.ContainersReturns([]garden.Container{someContainer, theirContainer}, nil)
+			fakeBackend.BulkInfoReturns(map[string]garden.ContainerInfoEntry{
+				"some-handle": {Info: garden.ContainerInfo{Properties: garden.Properties{"owner": "me"}}},
+			}, nil)
+
+			page, err := apiClient.(client.Client).ContainersPage(garden.ListOptions{
+				Fields: []garden.ContainerInfoField{garden.ContainerInfoFieldProperties},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(page.Containers).Should(HaveLen(2))
+			Ω(page.Infos).Should(Equal(map[string]garden.ContainerInfo{
+				"some-handle": {Properties: garden.Properties{"owner": "me"}},
+			}))
+
+			Ω(fakeBackend.BulkInfoCallCount()).Should(Equal(1))
+			Ω(fakeBackend.BulkInfoArgsForCall(0)).Should(Equal([]string{"some-handle"}))
+		})
+
+		It("only describes the pending reaps of containers the caller may get the info of", func() {
+			forbiddenHandle = "their-handle"
+			forbiddenRoute = routes.Info
+
+			fakeBackend.GraceTimeReturns(time.Hour)
+
+			for _, handle := range []string{"some-handle", "their-handle"} {
+				container := new(fakes.FakeContainer)
+				container.HandleReturns(handle)
+				fakeBackend.CreateReturns(container, nil)
+
+				_, err := apiClient.Create(garden.ContainerSpec{Handle: handle})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			reaps, err := apiClient.(client.Client).PendingReaps()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(reaps).Should(HaveLen(1))
+			Ω(reaps[0].Handle).Should(Equal("some-handle"))
+		})
+
+		Context("when the authorizer returns a ForbiddenError", func() {
+			BeforeEach(func() {
+				authorizeErr = garden.NewForbiddenError("not yours")
+			})
+
+			It("does not dispatch the request and returns the error", func() {
+				err := apiClient.Destroy("some-handle")
+				Ω(err).Should(Equal(garden.NewForbiddenError("not yours")))
+
+				Ω(fakeBackend.DestroyCallCount()).Should(Equal(0))
+			})
+		})
+
+		Context("when the authorizer returns an UnauthorizedError", func() {
+			BeforeEach(func() {
+				authorizeErr = garden.NewUnauthorizedError("who are you")
+			})
+
+			It("does not dispatch the request and returns the error", func() {
+				err := apiClient.Destroy("some-handle")
+				Ω(err).Should(Equal(garden.NewUnauthorizedError("who are you")))
+
+				Ω(fakeBackend.DestroyCallCount()).Should(Equal(0))
+			})
+		})
+
+		Context("when the authorizer returns any other error", func() {
+			BeforeEach(func() {
+				authorizeErr = errors.New("nope")
+			})
+
+			It("returns a ForbiddenError", func() {
+				err := apiClient.Destroy("some-handle")
+				Ω(err).Should(Equal(garden.NewForbiddenError("nope")))
+
+				Ω(fakeBackend.DestroyCallCount()).Should(Equal(0))
+			})
+		})
+	})
+
+	Context("when serving on a unix socket", func() {
+		var unixServer *testServer
+
+		BeforeEach(func() {
+			unixServer = newTestServer(0, fakeBackend)
+			unixServer.SetAuthorizer(authorizer)
+
+			apiClient := unixServer.start()
+			Ω(apiClient.Ping()).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			unixServer.cleanup()
+		})
+
+		It("passes the peer's uid to the authorizer on linux", func() {
+			Ω(authorized).Should(HaveLen(1))
+
+			if runtime.GOOS != "linux" {
+				Ω(authorized[0].credentials.HasPeerUID).Should(BeFalse())
+				return
+			}
+
+			Ω(authorized[0].credentials.HasPeerUID).Should(BeTrue())
+			Ω(authorized[0].credentials.PeerUID).Should(Equal(uint32(os.Getuid())))
+		})
+	})
+
+	Context("when serving mutual TLS", func() {
+		BeforeEach(func() {
+			certs := generateCertificates("some-client")
+
+			apiServer = server.NewWithTLS("tcp", "127.0.0.1:60126", &tls.Config{
+				Certificates: []tls.Certificate{certs.Server},
+				ClientCAs:    certs.CAPool,
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+			}, 0, fakeBackend, logger)
+			apiServer.SetAuthorizer(authorizer)
+			Ω(apiServer.Start()).Should(Succeed())
+
+			apiClient := client.New(connection.NewWithTLS("tcp", "127.0.0.1:60126", &tls.Config{
+				RootCAs:      certs.CAPool,
+				Certificates: []tls.Certificate{certs.Client},
+			}))
+			Ω(apiClient.Ping()).Should(Succeed())
+		})
+
+		It("passes the client certificate to the authorizer", func() {
+			Ω(authorized).Should(HaveLen(1))
+			Ω(authorized[0].credentials.Certificate).ShouldNot(BeNil())
+			Ω(authorized[0].credentials.Certificate.Subject.CommonName).Should(Equal("some-client"))
+		})
+	})
+})
+
+type authorizeCall struct {
+	route       string
+	handle      string
+	credentials server.Credentials
+}
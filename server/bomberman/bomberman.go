@@ -1,6 +1,9 @@
 package bomberman
 
 import (
+	"sort"
+	"time"
+
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/cloudfoundry-incubator/garden/server/timebomb"
 )
@@ -12,10 +15,25 @@ const (
 	defuse
 )
 
+// detonation is sent by a bomb once it has detonated, so that it can be
+// forgotten. Its container may have been strapped with a new bomb by then.
+type detonation struct {
+	Handle string
+	Bomb   *timebomb.TimeBomb
+}
+
 type bomb struct {
-	Action         action
-	StrapContainer garden.Container
-	DefuseHandle   string
+	Action          action
+	StrapContainer  garden.Container
+	StrapReferenced time.Time
+	DefuseHandle    string
+}
+
+// Stats describes the bombs currently managed by a Bomberman.
+type Stats struct {
+	Armed     int
+	Paused    int
+	Detonated uint64
 }
 
 type Bomberman struct {
@@ -25,8 +43,12 @@ type Bomberman struct {
 
 	pause   chan string
 	unpause chan string
-	cleanup chan string
+	cleanup chan detonation
 	bomb    chan bomb
+	stats   chan chan Stats
+
+	referenced chan chan map[string]time.Time
+	pending    chan chan []garden.PendingReap
 }
 
 func New(backend garden.Backend, detonate func(garden.Container)) *Bomberman {
@@ -37,7 +59,11 @@ func New(backend garden.Backend, detonate func(garden.Container)) *Bomberman {
 		bomb:    make(chan bomb),
 		pause:   make(chan string),
 		unpause: make(chan string),
-		cleanup: make(chan string),
+		cleanup: make(chan detonation),
+		stats:   make(chan chan Stats),
+
+		referenced: make(chan chan map[string]time.Time),
+		pending:    make(chan chan []garden.PendingReap),
 	}
 
 	go b.manageBombs()
@@ -49,6 +75,12 @@ func (b *Bomberman) Strap(container garden.Container) {
 	b.bomb <- bomb{Action: strap, StrapContainer: container}
 }
 
+// StrapSince straps a bomb to the container whose countdown started at
+// referenced, e.g. when the container was last referenced before a restart.
+func (b *Bomberman) StrapSince(container garden.Container, referenced time.Time) {
+	b.bomb <- bomb{Action: strap, StrapContainer: container, StrapReferenced: referenced}
+}
+
 func (b *Bomberman) Pause(name string) {
 	b.pause <- name
 }
@@ -61,8 +93,33 @@ func (b *Bomberman) Defuse(name string) {
 	b.bomb <- bomb{Action: defuse, DefuseHandle: name}
 }
 
+// Stats returns the number of bombs that are armed (including paused ones),
+// paused, and that have detonated so far.
+func (b *Bomberman) Stats() Stats {
+	reply := make(chan Stats)
+	b.stats <- reply
+	return <-reply
+}
+
+// Referenced returns when each container with an armed bomb was last
+// referenced: when its countdown last started, or now if it is paused.
+func (b *Bomberman) Referenced() map[string]time.Time {
+	reply := make(chan map[string]time.Time)
+	b.referenced <- reply
+	return <-reply
+}
+
+// PendingReaps describes each container with an armed bomb, soonest to
+// detonate first, followed by those that are paused.
+func (b *Bomberman) PendingReaps() []garden.PendingReap {
+	reply := make(chan []garden.PendingReap)
+	b.pending <- reply
+	return <-reply
+}
+
 func (b *Bomberman) manageBombs() {
 	timeBombs := map[string]*timebomb.TimeBomb{}
+	detonated := uint64(0)
 
 	for {
 		select {
@@ -75,16 +132,22 @@ func (b *Bomberman) manageBombs() {
 					continue
 				}
 
-				bomb := timebomb.New(
+				var bomb *timebomb.TimeBomb
+				bomb = timebomb.New(
 					b.backend.GraceTime(container),
 					func() {
 						b.detonate(container)
-						b.cleanup <- container.Handle()
+						b.cleanup <- detonation{Handle: container.Handle(), Bomb: bomb}
 					},
 				)
 
 				timeBombs[container.Handle()] = bomb
-				bomb.Strap()
+
+				if bombSignal.StrapReferenced.IsZero() {
+					bomb.Strap()
+				} else {
+					bomb.StrapSince(bombSignal.StrapReferenced)
+				}
 
 			case defuse:
 				bomb, found := timeBombs[bombSignal.DefuseHandle]
@@ -112,8 +175,66 @@ func (b *Bomberman) manageBombs() {
 
 			bomb.Unpause()
 
-		case handle := <-b.cleanup:
-			delete(timeBombs, handle)
+		case detonation := <-b.cleanup:
+			if timeBombs[detonation.Handle] == detonation.Bomb {
+				delete(timeBombs, detonation.Handle)
+			}
+
+			detonated++
+
+		case reply := <-b.stats:
+			stats := Stats{
+				Armed:     len(timeBombs),
+				Detonated: detonated,
+			}
+
+			for _, bomb := range timeBombs {
+				if bomb.Paused() {
+					stats.Paused++
+				}
+			}
+
+			reply <- stats
+
+		case reply := <-b.referenced:
+			referenced := make(map[string]time.Time, len(timeBombs))
+			for handle, bomb := range timeBombs {
+				referenced[handle] = bomb.Referenced()
+			}
+
+			reply <- referenced
+
+		case reply := <-b.pending:
+			pending := make([]garden.PendingReap, 0, len(timeBombs))
+			for handle, bomb := range timeBombs {
+				pending = append(pending, garden.PendingReap{
+					Handle:    handle,
+					GraceTime: bomb.Countdown(),
+					Pauses:    bomb.Pauses(),
+					ReapAt:    bomb.Deadline(),
+				})
+			}
+
+			sort.Sort(bySoonest(pending))
+
+			reply <- pending
 		}
 	}
 }
+
+type bySoonest []garden.PendingReap
+
+func (p bySoonest) Len() int      { return len(p) }
+func (p bySoonest) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+func (p bySoonest) Less(i, j int) bool {
+	if p[i].ReapAt.Equal(p[j].ReapAt) {
+		return p[i].Handle < p[j].Handle
+	}
+
+	if p[i].ReapAt.IsZero() || p[j].ReapAt.IsZero() {
+		return p[j].ReapAt.IsZero()
+	}
+
+	return p[i].ReapAt.Before(p[j].ReapAt)
+}
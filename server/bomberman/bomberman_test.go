@@ -166,4 +166,155 @@ var _ = Describe("Bomberman", func() {
 			})
 		})
 	})
+
+	Describe("Stats", func() {
+		It("reports armed, paused and detonated bombs", func() {
+			detonated := make(chan garden.Container, 1)
+
+			backend := new(fakes.FakeBackend)
+			backend.GraceTimeStub = func(container garden.Container) time.Duration {
+				if container.Handle() == "doomed" {
+					return 100 * time.Millisecond
+				}
+
+				return time.Hour
+			}
+
+			b := bomberman.New(backend, func(container garden.Container) {
+				detonated <- container
+			})
+
+			doomed := new(fakes.FakeContainer)
+			doomed.HandleReturns("doomed")
+
+			paused := new(fakes.FakeContainer)
+			paused.HandleReturns("paused")
+
+			b.Strap(doomed)
+			b.Strap(paused)
+			b.Pause("paused")
+
+			Ω(b.Stats()).Should(Equal(bomberman.Stats{Armed: 2, Paused: 1}))
+
+			Eventually(detonated).Should(Receive())
+
+			Eventually(b.Stats).Should(Equal(bomberman.Stats{Armed: 1, Paused: 1, Detonated: 1}))
+		})
+	})
+
+	Describe("strapping a container again as its bomb detonates", func() {
+		It("keeps the new bomb armed", func() {
+			detonated := make(chan garden.Container, 1)
+
+			backend := new(fakes.FakeBackend)
+			backend.GraceTimeReturns(100 * time.Millisecond)
+
+			var b *bomberman.Bomberman
+			b = bomberman.New(backend, func(container garden.Container) {
+				backend.GraceTimeReturns(time.Hour)
+				b.Strap(container)
+				detonated <- container
+			})
+
+			container := new(fakes.FakeContainer)
+			container.HandleReturns("doomed")
+
+			b.Strap(container)
+
+			Eventually(detonated).Should(Receive())
+			Eventually(b.Stats).Should(Equal(bomberman.Stats{Armed: 1, Detonated: 1}))
+
+			b.Defuse("doomed")
+		})
+	})
+
+	Describe("strapping since an earlier reference", func() {
+		It("detonates after the rest of the container's grace time", func() {
+			detonated := make(chan garden.Container, 1)
+
+			backend := new(fakes.FakeBackend)
+			backend.GraceTimeReturns(time.Hour)
+
+			b := bomberman.New(backend, func(container garden.Container) {
+				detonated <- container
+			})
+
+			container := new(fakes.FakeContainer)
+			container.HandleReturns("doomed")
+
+			b.StrapSince(container, time.Now().Add(-time.Hour))
+
+			Eventually(detonated).Should(Receive(Equal(container)))
+		})
+	})
+
+	Describe("Referenced", func() {
+		It("reports when each armed container was last referenced", func() {
+			backend := new(fakes.FakeBackend)
+			backend.GraceTimeReturns(time.Hour)
+
+			b := bomberman.New(backend, func(garden.Container) {})
+
+			idle := new(fakes.FakeContainer)
+			idle.HandleReturns("idle")
+
+			busy := new(fakes.FakeContainer)
+			busy.HandleReturns("busy")
+
+			referenced := time.Now().Add(-time.Minute)
+
+			b.StrapSince(idle, referenced)
+			b.Strap(busy)
+			b.Pause("busy")
+
+			Ω(b.Referenced()).Should(HaveLen(2))
+			Ω(b.Referenced()["idle"]).Should(Equal(referenced))
+			Ω(b.Referenced()["busy"]).Should(BeTemporally("~", time.Now(), time.Second))
+
+			b.Defuse("idle")
+			b.Defuse("busy")
+		})
+	})
+
+	Describe("PendingReaps", func() {
+		It("describes each armed bomb, soonest to detonate first, then those that are paused", func() {
+			backend := new(fakes.FakeBackend)
+			backend.GraceTimeStub = func(container garden.Container) time.Duration {
+				if container.Handle() == "soon" {
+					return time.Minute
+				}
+
+				return time.Hour
+			}
+
+			b := bomberman.New(backend, func(garden.Container) {})
+
+			later := new(fakes.FakeContainer)
+			later.HandleReturns("later")
+
+			paused := new(fakes.FakeContainer)
+			paused.HandleReturns("paused")
+
+			soon := new(fakes.FakeContainer)
+			soon.HandleReturns("soon")
+
+			referenced := time.Now()
+
+			b.StrapSince(later, referenced)
+			b.StrapSince(paused, referenced)
+			b.StrapSince(soon, referenced)
+			b.Pause("paused")
+			b.Pause("paused")
+
+			Ω(b.PendingReaps()).Should(Equal([]garden.PendingReap{
+				{Handle: "soon", GraceTime: time.Minute, ReapAt: referenced.Add(time.Minute)},
+				{Handle: "later", GraceTime: time.Hour, ReapAt: referenced.Add(time.Hour)},
+				{Handle: "paused", GraceTime: time.Hour, Pauses: 2},
+			}))
+
+			b.Defuse("later")
+			b.Defuse("paused")
+			b.Defuse("soon")
+		})
+	})
 })
@@ -0,0 +1,92 @@
+package broadcaster
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+// New creates a Broadcaster. Each subscriber may fall up to bufferSize events
+// behind before it is disconnected.
+func New(bufferSize int) *Broadcaster {
+	return &Broadcaster{
+		bufferSize:  bufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Broadcaster fans events out to any number of subscribers.
+type Broadcaster struct {
+	bufferSize int
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	filter garden.EventFilter
+	events chan garden.Event
+}
+
+// Events returns the channel the subscription's events are delivered on. It
+// is closed once the subscription is cancelled, either by Unsubscribe or
+// because the subscriber fell behind; a subscriber whose channel is closed
+// must assume it missed events.
+func (s *Subscription) Events() <-chan garden.Event {
+	return s.events
+}
+
+// Subscribe registers a subscriber for the events matching filter. The caller
+// must call Unsubscribe to avoid leaking memory.
+func (b *Broadcaster) Subscribe(filter garden.EventFilter) *Subscription {
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan garden.Event, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+// Unsubscribe cancels the subscription. It is safe to call more than once.
+func (b *Broadcaster) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, found := b.subscribers[sub]; found {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// HasSubscribers reports whether anyone is listening, so that publishers can
+// skip work that is only needed to produce events.
+func (b *Broadcaster) HasSubscribers() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers) > 0
+}
+
+// Publish delivers the event to every matching subscriber without blocking.
+// Subscribers whose buffer is full are disconnected.
+func (b *Broadcaster) Publish(event garden.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
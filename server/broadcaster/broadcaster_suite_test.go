@@ -0,0 +1,13 @@
+package broadcaster_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBroadcaster(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Broadcaster Suite")
+}
@@ -0,0 +1,72 @@
+package broadcaster_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/server/broadcaster"
+)
+
+var _ = Describe("Broadcaster", func() {
+	var b *broadcaster.Broadcaster
+
+	BeforeEach(func() {
+		b = broadcaster.New(2)
+	})
+
+	It("delivers published events to every subscriber", func() {
+		sub1 := b.Subscribe(garden.EventFilter{})
+		sub2 := b.Subscribe(garden.EventFilter{})
+
+		event := garden.Event{Kind: garden.EventContainerCreated, Handle: "some-handle"}
+		b.Publish(event)
+
+		Ω(sub1.Events()).Should(Receive(Equal(event)))
+		Ω(sub2.Events()).Should(Receive(Equal(event)))
+	})
+
+	It("only delivers events matching the subscriber's filter", func() {
+		sub := b.Subscribe(garden.EventFilter{
+			Handles: []string{"some-handle"},
+			Kinds:   []garden.EventKind{garden.EventContainerDestroyed},
+		})
+
+		b.Publish(garden.Event{Kind: garden.EventContainerCreated, Handle: "some-handle"})
+		b.Publish(garden.Event{Kind: garden.EventContainerDestroyed, Handle: "other-handle"})
+		b.Publish(garden.Event{Kind: garden.EventContainerDestroyed, Handle: "some-handle"})
+
+		Ω(sub.Events()).Should(Receive(Equal(garden.Event{Kind: garden.EventContainerDestroyed, Handle: "some-handle"})))
+		Ω(sub.Events()).ShouldNot(Receive())
+	})
+
+	It("closes the subscription when unsubscribing", func() {
+		sub := b.Subscribe(garden.EventFilter{})
+		Ω(b.HasSubscribers()).Should(BeTrue())
+
+		b.Unsubscribe(sub)
+		b.Unsubscribe(sub)
+
+		Ω(sub.Events()).Should(BeClosed())
+		Ω(b.HasSubscribers()).Should(BeFalse())
+	})
+
+	Context("when a subscriber falls behind", func() {
+		It("disconnects it without blocking the publisher", func() {
+			slow := b.Subscribe(garden.EventFilter{})
+			fast := b.Subscribe(garden.EventFilter{})
+
+			for i := 0; i < 3; i++ {
+				b.Publish(garden.Event{Kind: garden.EventContainerCreated})
+				Ω(fast.Events()).Should(Receive())
+			}
+
+			Ω(slow.Events()).Should(Receive())
+			Ω(slow.Events()).Should(Receive())
+			Ω(slow.Events()).Should(BeClosed())
+
+			b.Publish(garden.Event{Kind: garden.EventContainerCreated})
+			Ω(fast.Events()).Should(Receive())
+		})
+	})
+})
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/routes"
+	"github.com/pivotal-golang/lager"
+)
+
+// bulkParallelism is the most containers a bulk operation acts on at once, so
+// that draining a host does not ask the backend to destroy every container
+// at the same time.
+const bulkParallelism = 16
+
+func (s *GardenServer) handleBulkDestroy(w http.ResponseWriter, r *http.Request) {
+	handles := splitHandles(r.URL.Query().Get("handles"))
+
+	hLog := s.logger.Session("bulk-destroy", lager.Data{
+		"handles": handles,
+	})
+	hLog.Debug("destroying")
+
+	results := s.bulk(r, routes.Destroy, handles, func(handle string) error {
+		return s.destroyContainer(r, handle, hLog.Session("destroy", lager.Data{"handle": handle}))
+	})
+
+	hLog.Info("destroyed")
+
+	s.writeResponse(w, results)
+}
+
+func (s *GardenServer) handleBulkStop(w http.ResponseWriter, r *http.Request) {
+	handles := splitHandles(r.URL.Query().Get("handles"))
+
+	hLog := s.logger.Session("bulk-stop", lager.Data{
+		"handles": handles,
+	})
+
+	var request struct {
+		Kill bool `json:"kill"`
+	}
+	if !s.readRequest(&request, w, r) {
+		return
+	}
+
+	hLog.Debug("stopping")
+
+	results := s.bulk(r, routes.Stop, handles, func(handle string) error {
+		return s.stopContainer(r, handle, request.Kill, hLog.Session("stop", lager.Data{"handle": handle}))
+	})
+
+	hLog.Info("stopped")
+
+	s.writeResponse(w, results)
+}
+
+// bulk authorizes each handle as though it were addressed alone by the route,
+// and runs op on those allowed, at most bulkParallelism at a time. It returns
+// the outcome for each handle.
+func (s *GardenServer) bulk(r *http.Request, route string, handles []string, op func(handle string) error) map[string]garden.ContainerResultEntry {
+	results := make(map[string]garden.ContainerResultEntry, len(handles))
+	resultsL := new(sync.Mutex)
+
+	sem := make(chan struct{}, bulkParallelism)
+	wg := new(sync.WaitGroup)
+
+	seen := make(map[string]bool, len(handles))
+
+	for _, handle := range handles {
+		// act on each container once, however many times it is given
+		if seen[handle] {
+			continue
+		}
+
+		seen[handle] = true
+
+		err := s.authorize(route, handle, r)
+		if err != nil {
+			s.recordResult(results, resultsL, handle, err)
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(handle string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			s.recordResult(results, resultsL, handle, op(handle))
+		}(handle)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (s *GardenServer) recordResult(results map[string]garden.ContainerResultEntry, resultsL *sync.Mutex, handle string, err error) {
+	entry := garden.ContainerResultEntry{}
+	if err != nil {
+		err = s.explainError(err)
+		s.metrics.observeError(err)
+
+		entry.Err = &garden.Error{Err: err}
+	}
+
+	resultsL.Lock()
+	results[handle] = entry
+	resultsL.Unlock()
+}
@@ -1,19 +1,322 @@
 package server
 
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/server/streamer"
+)
+
+// DefaultOutputSpillLimit is the default number of bytes of each of a
+// process's stdout and stderr that SpillOutput buffers on disk.
+const DefaultOutputSpillLimit = 64 * 1024 * 1024
+
+type OutputBackpressure int
+
+const (
+	// DropOutput discards output that the client is not consuming quickly
+	// enough. This is the default.
+	DropOutput OutputBackpressure = iota
+
+	// BlockOutput blocks the process's writes until a client has consumed
+	// earlier output. Output that no client is streaming is buffered for
+	// clients that attach later, and dropped once the buffer is full, so that
+	// a process is never held up by a client that is not there.
+	BlockOutput
+
+	// SpillOutput buffers output that the client is not consuming quickly
+	// enough in a temporary file, and drops output that does not fit.
+	SpillOutput
+)
+
+// OutputPolicy decides what happens to process output that a client is not
+// consuming quickly enough. Whatever is dropped is counted and reported to the
+// client along with the process's exit status.
+type OutputPolicy struct {
+	Backpressure OutputBackpressure
+
+	// SpillDir is the directory SpillOutput creates its files in. Defaults to
+	// os.TempDir().
+	SpillDir string
+
+	// SpillLimit bounds the bytes SpillOutput buffers on disk for each of a
+	// process's stdout and stderr. Defaults to DefaultOutputSpillLimit.
+	SpillLimit int64
+}
+
+// SetOutputPolicy configures how process output is handled when a client
+// falls behind. It must be called before Start.
+func (s *GardenServer) SetOutputPolicy(policy OutputPolicy) {
+	if policy.SpillDir == "" {
+		policy.SpillDir = os.TempDir()
+	}
+
+	if policy.SpillLimit <= 0 {
+		policy.SpillLimit = DefaultOutputSpillLimit
+	}
+
+	s.outputPolicy = policy
+}
+
+// processOutput carries a process's stdout and stderr to the streamer until
+// the process exits, across any number of Run and Attach requests.
+type processOutput struct {
+	stdout *chanWriter
+	stderr *chanWriter
+
+	streamID streamer.StreamID
+
+	// streaming is closed once streamID is set
+	streaming chan struct{}
+
+	dropped uint64
+	done    chan struct{}
+
+	// attached counts the clients streaming the output; accessed atomically
+	attached int32
+
+	// flushed is closed once the process has exited and all of its output has
+	// been handed to the streamer
+	flushed chan struct{}
+}
+
+type processKey struct {
+	handle string
+	id     string
+}
+
+func (s *GardenServer) newProcessOutput(stdout, stderr chan []byte) *processOutput {
+	o := &processOutput{
+		done:      make(chan struct{}),
+		streaming: make(chan struct{}),
+		flushed:   make(chan struct{}),
+	}
+
+	o.stdout = s.newChanWriter(stdout, o)
+	o.stderr = s.newChanWriter(stderr, o)
+
+	o.stdout.stalled = func() <-chan struct{} {
+		stalled, _ := o.stalled(s.streamer)
+		return stalled
+	}
+
+	o.stderr.stalled = func() <-chan struct{} {
+		_, stalled := o.stalled(s.streamer)
+		return stalled
+	}
+
+	return o
+}
+
+// captureOutput streams the process's output until it exits, and for a while
+// afterwards, so that clients attaching to it later can resume where they
+// left off.
+func (s *GardenServer) captureOutput(handle string, process garden.Process, output *processOutput) {
+	output.streamID = s.streamer.Stream(output.stdout.ch, output.stderr.ch)
+	close(output.streaming)
+
+	key := processKey{handle: handle, id: process.ID()}
+
+	s.outputsL.Lock()
+	s.outputs[key] = output
+	s.outputsL.Unlock()
+
+	go func() {
+		exited := make(chan struct{})
+
+		go func() {
+			process.Wait()
+			close(exited)
+		}()
+
+		select {
+		case <-exited:
+			output.flush(s.stopping)
+		case <-s.stopping:
+		}
+
+		close(output.flushed)
+
+		s.streamer.Stop(output.streamID)
+		output.close()
+
+		time.AfterFunc(streamGraceTime, func() {
+			s.outputsL.Lock()
+			if s.outputs[key] == output {
+				delete(s.outputs, key)
+			}
+			s.outputsL.Unlock()
+		})
+	}()
+}
+
+// capturedOutput returns the output being captured for the process, if any.
+func (s *GardenServer) capturedOutput(handle, processID string) (*processOutput, bool) {
+	s.outputsL.Lock()
+	defer s.outputsL.Unlock()
+
+	output, found := s.outputs[processKey{handle: handle, id: processID}]
+	return output, found
+}
+
+// attachedClients returns the number of clients streaming the output of the
+// given process.
+func (s *GardenServer) attachedClients(handle, processID string) int {
+	output, found := s.capturedOutput(handle, processID)
+	if !found {
+		return 0
+	}
+
+	return int(atomic.LoadInt32(&output.attached))
+}
+
+func (s *GardenServer) newChanWriter(ch chan []byte, o *processOutput) *chanWriter {
+	w := &chanWriter{
+		ch:           ch,
+		done:         o.done,
+		backpressure: s.outputPolicy.Backpressure,
+	}
+
+	w.drop = func(n int) {
+		atomic.AddUint64(&o.dropped, uint64(n))
+		atomic.AddUint64(&s.metrics.droppedChunks, 1)
+		atomic.AddUint64(&s.metrics.droppedBytes, uint64(n))
+	}
+
+	if w.backpressure == SpillOutput {
+		w.spill = &spillBuffer{
+			ch:    ch,
+			done:  o.done,
+			dir:   s.outputPolicy.SpillDir,
+			limit: s.outputPolicy.SpillLimit,
+			drop:  w.drop,
+			wake:  make(chan struct{}, 1),
+		}
+	}
+
+	return w
+}
+
+// keepTail keeps the last size bytes of each of stdout and stderr. It must be
+// called before the output is written to.
+func (o *processOutput) keepTail(size int) {
+	o.stdout.tail = newTailBuffer(size)
+	o.stderr.tail = newTailBuffer(size)
+}
+
+// flush waits for spilled output to be handed to the streamer.
+func (o *processOutput) flush(stopping <-chan bool) {
+	for _, w := range []*chanWriter{o.stdout, o.stderr} {
+		if w.spill == nil {
+			continue
+		}
+
+		drained := w.spill.drainedCh()
+		if drained == nil {
+			continue
+		}
+
+		select {
+		case <-drained:
+		case <-stopping:
+			return
+		}
+	}
+}
+
+// waitFlushed waits for the process to exit and its output to be flushed, or
+// for the client to go away.
+func (o *processOutput) waitFlushed(connClosed <-chan struct{}, stopping <-chan bool) {
+	select {
+	case <-o.flushed:
+	case <-connClosed:
+	case <-stopping:
+	}
+}
+
+// stalled returns channels that are closed while the stdout and stderr,
+// respectively, are held up by no client reading them. They are nil until the
+// output is streamed.
+func (o *processOutput) stalled(streamer *streamer.Streamer) (<-chan struct{}, <-chan struct{}) {
+	select {
+	case <-o.streaming:
+		return streamer.Stalled(o.streamID)
+	default:
+		return nil, nil
+	}
+}
+
+func (o *processOutput) droppedBytes() uint64 {
+	return atomic.LoadUint64(&o.dropped)
+}
+
+// close releases blocked writers and spill files once the output is no longer
+// being streamed.
+func (o *processOutput) close() {
+	close(o.done)
+
+	for _, w := range []*chanWriter{o.stdout, o.stderr} {
+		if w.spill != nil {
+			w.spill.close()
+		}
+	}
+}
+
 type chanWriter struct {
-	ch chan<- []byte
+	ch   chan []byte
+	done <-chan struct{}
+
+	backpressure OutputBackpressure
+	spill        *spillBuffer
+
+	// tail keeps the end of the output, if set
+	tail *tailBuffer
+
+	// stalled returns a channel that is closed while no client is reading
+	// the output and there is no room left to buffer it
+	stalled func() <-chan struct{}
+
+	drop func(int)
 }
 
 func (w *chanWriter) Write(d []byte) (int, error) {
+	if w.tail != nil {
+		w.tail.Write(d)
+	}
+
 	// prevent buffer reuse from clobbering the data
 	data := make([]byte, len(d))
 	copy(data, d)
 
-	select {
-	case w.ch <- data:
+	switch w.backpressure {
+	case BlockOutput:
+		select {
+		case w.ch <- data:
+			return len(d), nil
+		default:
+		}
+
+		select {
+		case w.ch <- data:
+		case <-w.stalled():
+			w.drop(len(data))
+		case <-w.done:
+			w.drop(len(data))
+		}
+
+	case SpillOutput:
+		w.spill.write(data)
+
 	default:
-		// assumption is that writes never block; channel should have buffer to
-		// account for slow consumers
+		select {
+		case w.ch <- data:
+		default:
+			w.drop(len(data))
+		}
 	}
 
 	return len(d), nil
@@ -23,3 +326,180 @@ func (w *chanWriter) Close() error {
 	close(w.ch)
 	return nil
 }
+
+// spillBuffer queues output in a ring buffer on disk once ch is full, and
+// pumps it back into ch in order as the consumer catches up.
+type spillBuffer struct {
+	ch    chan<- []byte
+	done  <-chan struct{}
+	dir   string
+	limit int64
+	drop  func(int)
+	wake  chan struct{}
+
+	mu                sync.Mutex
+	file              *os.File
+	pumping           chan struct{}
+	readOff, writeOff int64
+
+	// drained is non-nil while output is spilled, and closed once the pump has
+	// caught up
+	drained chan struct{}
+}
+
+func (b *spillBuffer) write(data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.drained == nil {
+		select {
+		case b.ch <- data:
+			return
+		default:
+		}
+
+		if err := b.startSpilling(); err != nil {
+			b.drop(len(data))
+			return
+		}
+	}
+
+	if b.writeOff-b.readOff+int64(len(data)) > b.limit {
+		b.drop(len(data))
+		return
+	}
+
+	for written := 0; written < len(data); {
+		pos := b.writeOff % b.limit
+
+		chunk := data[written:]
+		if int64(len(chunk)) > b.limit-pos {
+			chunk = chunk[:b.limit-pos]
+		}
+
+		n, err := b.file.WriteAt(chunk, pos)
+		b.writeOff += int64(n)
+		written += n
+
+		if err != nil {
+			b.drop(len(data) - written)
+			break
+		}
+	}
+
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (b *spillBuffer) startSpilling() error {
+	if b.file == nil {
+		file, err := ioutil.TempFile(b.dir, "garden-output-")
+		if err != nil {
+			return err
+		}
+
+		b.file = file
+		b.pumping = make(chan struct{})
+
+		go b.pump()
+	}
+
+	b.drained = make(chan struct{})
+
+	return nil
+}
+
+func (b *spillBuffer) pump() {
+	defer close(b.pumping)
+
+	buf := make([]byte, 32*1024)
+
+	for {
+		b.mu.Lock()
+
+		if b.readOff == b.writeOff {
+			if b.drained != nil {
+				close(b.drained)
+				b.drained = nil
+			}
+
+			b.mu.Unlock()
+
+			select {
+			case <-b.wake:
+				continue
+			case <-b.done:
+				return
+			}
+		}
+
+		pos := b.readOff % b.limit
+
+		size := int64(len(buf))
+		if size > b.writeOff-b.readOff {
+			size = b.writeOff - b.readOff
+		}
+
+		if size > b.limit-pos {
+			size = b.limit - pos
+		}
+
+		b.mu.Unlock()
+
+		n, err := b.file.ReadAt(buf[:size], pos)
+		if err != nil && n == 0 {
+			b.mu.Lock()
+			b.drop(int(b.writeOff - b.readOff))
+			b.readOff = b.writeOff
+			b.mu.Unlock()
+			continue
+		}
+
+		chunk := make([]byte, n)
+		copy(chunk, buf[:n])
+
+		select {
+		case b.ch <- chunk:
+		case <-b.done:
+			return
+		}
+
+		b.mu.Lock()
+		b.readOff += int64(n)
+		b.mu.Unlock()
+	}
+}
+
+func (b *spillBuffer) drainedCh() chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.drained
+}
+
+// close must only be called once done is closed.
+func (b *spillBuffer) close() {
+	b.mu.Lock()
+	file := b.file
+	pumping := b.pumping
+	b.mu.Unlock()
+
+	if file == nil {
+		return
+	}
+
+	<-pumping
+
+	b.mu.Lock()
+	unsent := b.writeOff - b.readOff
+	b.mu.Unlock()
+
+	if unsent > 0 {
+		b.drop(int(unsent))
+	}
+
+	file.Close()
+	os.Remove(file.Name())
+}
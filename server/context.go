@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+// backendFor returns the backend bound to the request's context if it
+// implements garden.ContextClient, so that it can abandon work for clients
+// that have gone away.
+func (s *GardenServer) backendFor(r *http.Request) garden.Client {
+	backend, ok := s.backend.(garden.ContextClient)
+	if !ok {
+		return s.backend
+	}
+
+	return &contextClient{
+		Client:  s.backend,
+		backend: backend,
+		ctx:     r.Context(),
+	}
+}
+
+// containerFor returns the container bound to the request's context if it
+// implements garden.ContextContainer. The bound container must not be kept
+// beyond the request.
+func containerFor(r *http.Request, container garden.Container) garden.Container {
+	bound, ok := container.(garden.ContextContainer)
+	if !ok {
+		return container
+	}
+
+	return &contextContainer{
+		Container: container,
+		container: bound,
+		ctx:       r.Context(),
+	}
+}
+
+type contextClient struct {
+	garden.Client
+
+	backend garden.ContextClient
+	ctx     context.Context
+}
+
+func (c *contextClient) Ping() error {
+	return c.backend.PingContext(c.ctx)
+}
+
+func (c *contextClient) Capacity() (garden.Capacity, error) {
+	return c.backend.CapacityContext(c.ctx)
+}
+
+func (c *contextClient) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	return c.backend.CreateContext(c.ctx, spec)
+}
+
+func (c *contextClient) Destroy(handle string) error {
+	return c.backend.DestroyContext(c.ctx, handle)
+}
+
+func (c *contextClient) Containers(properties garden.Properties) ([]garden.Container, error) {
+	return c.backend.ContainersContext(c.ctx, properties)
+}
+
+func (c *contextClient) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	return c.backend.BulkInfoContext(c.ctx, handles)
+}
+
+func (c *contextClient) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	return c.backend.BulkMetricsContext(c.ctx, handles)
+}
+
+func (c *contextClient) Lookup(handle string) (garden.Container, error) {
+	return c.backend.LookupContext(c.ctx, handle)
+}
+
+type contextContainer struct {
+	garden.Container
+
+	container garden.ContextContainer
+	ctx       context.Context
+}
+
+func (c *contextContainer) Stop(kill bool) error {
+	return c.container.StopContext(c.ctx, kill)
+}
+
+func (c *contextContainer) Info() (garden.ContainerInfo, error) {
+	return c.container.InfoContext(c.ctx)
+}
+
+func (c *contextContainer) StreamIn(spec garden.StreamInSpec) error {
+	return c.container.StreamInContext(c.ctx, spec)
+}
+
+func (c *contextContainer) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	return c.container.StreamOutContext(c.ctx, spec)
+}
+
+func (c *contextContainer) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	return c.container.CurrentBandwidthLimitsContext(c.ctx)
+}
+
+func (c *contextContainer) CurrentCPULimits() (garden.CPULimits, error) {
+	return c.container.CurrentCPULimitsContext(c.ctx)
+}
+
+func (c *contextContainer) CurrentDiskLimits() (garden.DiskLimits, error) {
+	return c.container.CurrentDiskLimitsContext(c.ctx)
+}
+
+func (c *contextContainer) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	return c.container.CurrentMemoryLimitsContext(c.ctx)
+}
+
+func (c *contextContainer) SetBandwidthLimits(limits garden.BandwidthLimits) error {
+	return c.container.SetBandwidthLimitsContext(c.ctx, limits)
+}
+
+func (c *contextContainer) SetCPULimits(limits garden.CPULimits) error {
+	return c.container.SetCPULimitsContext(c.ctx, limits)
+}
+
+func (c *contextContainer) SetDiskLimits(limits garden.DiskLimits) error {
+	return c.container.SetDiskLimitsContext(c.ctx, limits)
+}
+
+func (c *contextContainer) SetMemoryLimits(limits garden.MemoryLimits) error {
+	return c.container.SetMemoryLimitsContext(c.ctx, limits)
+}
+
+func (c *contextContainer) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	return c.container.NetInContext(c.ctx, hostPort, containerPort)
+}
+
+func (c *contextContainer) NetOut(netOutRule garden.NetOutRule) error {
+	return c.container.NetOutContext(c.ctx, netOutRule)
+}
+
+func (c *contextContainer) Run(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	return c.container.RunContext(c.ctx, spec, io)
+}
+
+func (c *contextContainer) Attach(processID string, io garden.ProcessIO) (garden.Process, error) {
+	return c.container.AttachContext(c.ctx, processID, io)
+}
+
+func (c *contextContainer) Processes() ([]garden.ProcessInfo, error) {
+	return c.container.ProcessesContext(c.ctx)
+}
+
+func (c *contextContainer) Process(processID string) (garden.ProcessInfo, error) {
+	return c.container.ProcessContext(c.ctx, processID)
+}
+
+func (c *contextContainer) Metrics() (garden.Metrics, error) {
+	return c.container.MetricsContext(c.ctx)
+}
+
+func (c *contextContainer) SetGraceTime(graceTime time.Duration) error {
+	return c.container.SetGraceTimeContext(c.ctx, graceTime)
+}
+
+func (c *contextContainer) Properties() (garden.Properties, error) {
+	return c.container.PropertiesContext(c.ctx)
+}
+
+func (c *contextContainer) Property(name string) (string, error) {
+	return c.container.PropertyContext(c.ctx, name)
+}
+
+func (c *contextContainer) SetProperty(name string, value string) error {
+	return c.container.SetPropertyContext(c.ctx, name, value)
+}
+
+func (c *contextContainer) RemoveProperty(name string) error {
+	return c.container.RemovePropertyContext(c.ctx, name)
+}
@@ -0,0 +1,203 @@
+package server_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+)
+
+type contextBackend struct {
+	*fakes.FakeBackend
+	*fakes.FakeContextClient
+}
+
+type contextSelectorBackend struct {
+	*contextBackend
+
+	selected chan context.Context
+}
+
+func (b *contextSelectorBackend) ContainersMatchingContext(ctx context.Context, selector garden.Selector) ([]garden.Container, error) {
+	b.selected <- ctx
+	return []garden.Container{}, nil
+}
+
+type contextContainer struct {
+	*fakes.FakeContainer
+	*fakes.FakeContextContainer
+}
+
+var _ = Describe("Context-aware backends", func() {
+	var (
+		serverBackend   *contextBackend
+		backend         garden.Backend
+		serverContainer *contextContainer
+
+		apiServer *testServer
+		apiClient client.Client
+	)
+
+	BeforeEach(func() {
+		serverContainer = &contextContainer{
+			FakeContainer:        new(fakes.FakeContainer),
+			FakeContextContainer: new(fakes.FakeContextContainer),
+		}
+		serverContainer.HandleReturns("some-handle")
+
+		serverBackend = &contextBackend{
+			FakeBackend:       new(fakes.FakeBackend),
+			FakeContextClient: new(fakes.FakeContextClient),
+		}
+		serverBackend.LookupContextReturns(serverContainer, nil)
+		serverBackend.ContainersContextReturns([]garden.Container{serverContainer}, nil)
+
+		backend = serverBackend
+	})
+
+	JustBeforeEach(func() {
+		apiServer = newTestServer(0, backend)
+		apiClient = apiServer.start()
+	})
+
+	AfterEach(func() {
+		apiServer.cleanup()
+	})
+
+	Context("when the client gives up on a request", func() {
+		var cancelled chan error
+
+		BeforeEach(func() {
+			cancelled = make(chan error, 1)
+
+			serverBackend.CreateContextStub = func(ctx context.Context, spec garden.ContainerSpec) (garden.Container, error) {
+				<-ctx.Done()
+				cancelled <- ctx.Err()
+				return nil, ctx.Err()
+			}
+		})
+
+		It("cancels the context the backend was called with", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			_, err := apiClient.CreateContext(ctx, garden.ContainerSpec{})
+			Ω(err).Should(HaveOccurred())
+
+			Eventually(cancelled).Should(Receive(Equal(context.Canceled)))
+			Ω(serverBackend.FakeBackend.CreateCallCount()).Should(BeZero())
+		})
+	})
+
+	Context("when the client gives up on a create with an idempotency key", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+
+			release := release
+			serverBackend.FakeBackend.CreateStub = func(garden.ContainerSpec) (garden.Container, error) {
+				<-release
+				return serverContainer, nil
+			}
+		})
+
+		It("carries on creating the container for a retry to find", func() {
+			spec := garden.ContainerSpec{IdempotencyKey: "some-key"}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			_, err := apiClient.CreateContext(ctx, spec)
+			Ω(err).Should(HaveOccurred())
+
+			close(release)
+
+			container, err := apiClient.Create(spec)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(container.Handle()).Should(Equal("some-handle"))
+
+			Ω(serverBackend.FakeBackend.CreateCallCount()).Should(Equal(1))
+			Ω(serverBackend.CreateContextCallCount()).Should(BeZero())
+		})
+	})
+
+	Context("when the client gives up on a destroy", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+
+			release := release
+			serverBackend.FakeBackend.DestroyStub = func(string) error {
+				<-release
+				return nil
+			}
+		})
+
+		It("carries on destroying the container for other clients", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			Ω(apiClient.DestroyContext(ctx, "some-handle")).ShouldNot(Succeed())
+
+			waited := make(chan error, 1)
+			go func() {
+				waited <- apiClient.WaitForDestroy("some-handle")
+			}()
+
+			Consistently(waited).ShouldNot(Receive())
+			close(release)
+
+			Eventually(waited).Should(Receive(BeNil()))
+			Ω(serverBackend.DestroyContextCallCount()).Should(BeZero())
+		})
+	})
+
+	It("calls the container with the request's context", func() {
+		serverContainer.InfoContextReturns(garden.ContainerInfo{State: "active"}, nil)
+
+		container, err := apiClient.Lookup("some-handle")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		info, err := container.Info()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(info.State).Should(Equal("active"))
+
+		Ω(serverContainer.InfoContextCallCount()).Should(Equal(1))
+		Ω(serverContainer.InfoContextArgsForCall(0)).ShouldNot(BeNil())
+		Ω(serverContainer.FakeContainer.InfoCallCount()).Should(BeZero())
+	})
+
+	Context("when the backend selects containers itself", func() {
+		var selectorBackend *contextSelectorBackend
+
+		BeforeEach(func() {
+			selectorBackend = &contextSelectorBackend{
+				contextBackend: serverBackend,
+				selected:       make(chan context.Context, 1),
+			}
+
+			backend = selectorBackend
+		})
+
+		It("calls it with the request's context", func() {
+			selector, err := garden.ParseSelector("foo!=bar")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = apiClient.ContainersMatching(selector)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var ctx context.Context
+			Ω(selectorBackend.selected).Should(Receive(&ctx))
+
+			// the request's context ends with the request
+			Eventually(ctx.Done()).Should(BeClosed())
+		})
+	})
+})
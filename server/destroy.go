@@ -0,0 +1,187 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/pivotal-golang/lager"
+)
+
+// destroyOutcomeRetention is how long the outcome of a destroy is kept after
+// it finishes, for clients waiting on an asynchronous destroy.
+const destroyOutcomeRetention = 10 * time.Minute
+
+// destroyingState is the ContainerInfo.State of a container while it is
+// being destroyed.
+const destroyingState = "destroying"
+
+type destroyOperation struct {
+	done chan struct{}
+	err  error
+}
+
+func (op *destroyOperation) finished() bool {
+	select {
+	case <-op.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// destroyContainer destroys the container with the handle, joining the
+// destroy already in flight for it, if any, and returns its outcome. If the
+// request ends first it stops waiting, but the destroy carries on for the
+// other requests waiting on it.
+func (s *GardenServer) destroyContainer(r *http.Request, handle string, hLog lager.Logger) error {
+	op := s.destroy(handle, hLog)
+
+	select {
+	case <-op.done:
+		return op.err
+	case <-r.Context().Done():
+		return r.Context().Err()
+	}
+}
+
+// destroy returns the destroy in flight for the container with the handle, or
+// starts destroying it. The destroy is shared by every request for it, so it
+// is not bound to any of their contexts. The container's grace time is held
+// off while it is destroyed, and its bomb defused once it is gone.
+func (s *GardenServer) destroy(handle string, hLog lager.Logger) *destroyOperation {
+	op, _ := s.startDestroy(handle, true, hLog)
+	return op
+}
+
+// startDestroy is like destroy, but reports whether it started the destroy
+// rather than joining the one in flight. A destroy it starts publishes
+// EventContainerDestroyed once the container is gone only if publish is true,
+// so that reaps can be published as such instead.
+func (s *GardenServer) startDestroy(handle string, publish bool, hLog lager.Logger) (*destroyOperation, bool) {
+	s.destroysL.Lock()
+
+	op, found := s.destroys[handle]
+	if found && !op.finished() {
+		s.destroysL.Unlock()
+
+		hLog.Debug("joining-destroy")
+		return op, false
+	}
+
+	op = &destroyOperation{done: make(chan struct{})}
+	s.destroys[handle] = op
+
+	s.destroysL.Unlock()
+
+	// pause before returning, so that the container cannot be reaped once
+	// its destroy has been accepted
+	s.bomberman.Pause(handle)
+
+	go func() {
+		hLog.Debug("destroying")
+
+		op.err = s.backend.Destroy(handle)
+		if op.err == nil {
+			hLog.Info("destroyed")
+
+			s.bomberman.Defuse(handle)
+
+			s.forgetContainerEvents(handle)
+			s.forgetPropertiesVersion(handle)
+			s.forgetCreatesOf(handle)
+
+			if publish {
+				s.publish(garden.Event{
+					Kind:   garden.EventContainerDestroyed,
+					Handle: handle,
+				})
+			}
+		} else {
+			s.bomberman.Unpause(handle)
+		}
+
+		close(op.done)
+
+		time.AfterFunc(destroyOutcomeRetention, func() {
+			s.destroysL.Lock()
+			if s.destroys[handle] == op {
+				delete(s.destroys, handle)
+			}
+			s.destroysL.Unlock()
+		})
+	}()
+
+	return op, true
+}
+
+// destroying reports whether the container with the handle is being
+// destroyed.
+func (s *GardenServer) destroying(handle string) bool {
+	s.destroysL.Lock()
+	defer s.destroysL.Unlock()
+
+	op, found := s.destroys[handle]
+	return found && !op.finished()
+}
+
+// markDestroying sets the state of each of the containers being destroyed to
+// destroying, even if the backend could no longer describe them.
+func (s *GardenServer) markDestroying(bulkInfo map[string]garden.ContainerInfoEntry) {
+	for handle, entry := range bulkInfo {
+		if s.destroying(handle) {
+			bulkInfo[handle] = garden.ContainerInfoEntry{
+				Info: destroyingInfo(entry.Info, entry.Err != nil),
+			}
+		}
+	}
+}
+
+// destroyingInfo returns the info of a container being destroyed, which is
+// empty but for its state if the backend could not describe it.
+func destroyingInfo(info garden.ContainerInfo, failed bool) garden.ContainerInfo {
+	if failed {
+		info = garden.ContainerInfo{}
+	}
+
+	info.State = destroyingState
+
+	return info
+}
+
+func (s *GardenServer) handleWaitForDestroy(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("wait-for-destroy", lager.Data{
+		"handle": handle,
+	})
+
+	s.destroysL.Lock()
+	op, found := s.destroys[handle]
+	s.destroysL.Unlock()
+
+	if !found {
+		_, err := s.backendFor(r).Lookup(handle)
+		if err == nil {
+			err = garden.NewConflictError("container is not being destroyed")
+		}
+
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	hLog.Debug("waiting")
+
+	select {
+	case <-op.done:
+	case <-r.Context().Done():
+		return
+	}
+
+	if op.err != nil {
+		s.writeError(w, op.err, hLog)
+		return
+	}
+
+	s.writeSuccess(w)
+}
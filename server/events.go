@@ -0,0 +1,157 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/routes"
+	"github.com/cloudfoundry-incubator/garden/transport"
+	"github.com/pivotal-golang/lager"
+)
+
+// EventBufferSize is the number of events a subscriber may fall behind by
+// before its stream is ended.
+const EventBufferSize = 1024
+
+var ErrStreamingUnsupported = errors.New("streaming is not supported by the connection")
+
+func (s *GardenServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := garden.EventFilter{
+		Handles: splitHandles(query.Get("handles")),
+		Kinds:   splitKinds(query.Get("kinds")),
+	}
+
+	hLog := s.logger.Session("events", lager.Data{
+		"filter": filter,
+	})
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeError(w, ErrStreamingUnsupported, hLog)
+		return
+	}
+
+	sub := s.events.Subscribe(filter)
+	defer s.events.Unsubscribe(sub)
+
+	hLog.Debug("subscribed")
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				hLog.Info("subscriber-fell-behind")
+				return
+			}
+
+			// only stream events about containers the caller may see
+			if s.authorize(routes.Events, event.Handle, r) != nil {
+				continue
+			}
+
+			err := transport.WriteEvent(w, event)
+			if err != nil {
+				hLog.Error("failed-to-write", err)
+				return
+			}
+
+			flusher.Flush()
+
+		case <-r.Context().Done():
+			hLog.Debug("unsubscribed")
+			return
+
+		case <-s.stopping:
+			return
+		}
+	}
+}
+
+func splitKinds(queryKinds string) []garden.EventKind {
+	kinds := []garden.EventKind{}
+	if queryKinds != "" {
+		for _, kind := range strings.Split(queryKinds, ",") {
+			kinds = append(kinds, garden.EventKind(kind))
+		}
+	}
+	return kinds
+}
+
+func (s *GardenServer) publish(event garden.Event) {
+	event.Time = time.Now()
+	s.events.Publish(event)
+}
+
+func (s *GardenServer) publishPropertyChanged(handle string, key string, value *string) {
+	s.publish(garden.Event{
+		Kind:     garden.EventPropertyChanged,
+		Handle:   handle,
+		Property: key,
+		Value:    value,
+	})
+}
+
+// processExited publishes the exit of a process spawned by handleRun, along
+// with any OOM the exit revealed.
+func (s *GardenServer) processExited(container garden.Container, processID string, status int, err error) {
+	event := garden.Event{
+		Kind:      garden.EventProcessExited,
+		Handle:    container.Handle(),
+		ProcessID: processID,
+	}
+
+	if err == nil {
+		event.ExitStatus = &status
+	}
+
+	s.publish(event)
+
+	if !s.events.HasSubscribers() {
+		return
+	}
+
+	info, err := container.Info()
+	if err != nil {
+		return
+	}
+
+	s.observeContainerEvents(container.Handle(), info.Events)
+}
+
+// observeContainerEvents publishes container events reported by the backend
+// in ContainerInfo.Events that have not been published before.
+func (s *GardenServer) observeContainerEvents(handle string, events []string) {
+	for _, e := range events {
+		if e != "oom" {
+			continue
+		}
+
+		s.oomsL.Lock()
+		_, seen := s.ooms[handle]
+		s.ooms[handle] = struct{}{}
+		s.oomsL.Unlock()
+
+		if !seen {
+			s.publish(garden.Event{
+				Kind:   garden.EventContainerOOM,
+				Handle: handle,
+			})
+		}
+	}
+}
+
+func (s *GardenServer) forgetContainerEvents(handle string) {
+	s.oomsL.Lock()
+	delete(s.ooms, handle)
+	s.oomsL.Unlock()
+}
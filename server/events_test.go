@@ -0,0 +1,206 @@
+package server_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+)
+
+var _ = Describe("Events", func() {
+	var (
+		serverBackend   *fakes.FakeBackend
+		serverContainer *fakes.FakeContainer
+
+		apiServer *testServer
+		apiClient client.Client
+
+		events chan garden.Event
+		stream garden.EventStream
+	)
+
+	BeforeEach(func() {
+		serverBackend = new(fakes.FakeBackend)
+
+		serverContainer = new(fakes.FakeContainer)
+		serverContainer.HandleReturns("some-handle")
+
+		serverBackend.CreateReturns(serverContainer, nil)
+		serverBackend.LookupReturns(serverContainer, nil)
+		serverBackend.ContainersReturns([]garden.Container{serverContainer}, nil)
+	})
+
+	JustBeforeEach(func() {
+		apiServer = newTestServer(time.Hour, serverBackend)
+		apiClient = apiServer.start()
+
+		var err error
+		stream, err = apiClient.Events(garden.EventFilter{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		events = make(chan garden.Event, 100)
+		go func(stream garden.EventStream, events chan<- garden.Event) {
+			defer GinkgoRecover()
+			defer close(events)
+
+			for {
+				event, err := stream.Next()
+				if err != nil {
+					return
+				}
+
+				events <- event
+			}
+		}(stream, events)
+	})
+
+	AfterEach(func() {
+		stream.Close()
+		apiServer.cleanup()
+	})
+
+	nextEvent := func() garden.Event {
+		var event garden.Event
+		Eventually(events).Should(Receive(&event))
+		return event
+	}
+
+	It("streams container creation", func() {
+		_, err := apiClient.Create(garden.ContainerSpec{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		event := nextEvent()
+		Ω(event.Kind).Should(Equal(garden.EventContainerCreated))
+		Ω(event.Handle).Should(Equal("some-handle"))
+		Ω(event.Time).ShouldNot(BeZero())
+	})
+
+	It("streams stopping and destroying a container", func() {
+		container, err := apiClient.Lookup("some-handle")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(container.Stop(false)).Should(Succeed())
+		Ω(apiClient.Destroy("some-handle")).Should(Succeed())
+
+		expectEvent(nextEvent(), garden.EventContainerStopped, "some-handle")
+		expectEvent(nextEvent(), garden.EventContainerDestroyed, "some-handle")
+	})
+
+	It("streams property changes", func() {
+		container, err := apiClient.Lookup("some-handle")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(container.SetProperty("some-key", "some-value")).Should(Succeed())
+		Ω(container.RemoveProperty("some-key")).Should(Succeed())
+
+		event := nextEvent()
+		expectEvent(event, garden.EventPropertyChanged, "some-handle")
+		Ω(event.Property).Should(Equal("some-key"))
+		Ω(*event.Value).Should(Equal("some-value"))
+
+		event = nextEvent()
+		expectEvent(event, garden.EventPropertyChanged, "some-handle")
+		Ω(event.Property).Should(Equal("some-key"))
+		Ω(event.Value).Should(BeNil())
+	})
+
+	It("streams processes spawning and exiting", func() {
+		serverContainer.RunStub = func(garden.ProcessSpec, garden.ProcessIO) (garden.Process, error) {
+			process := new(fakes.FakeProcess)
+			process.IDReturns("some-process")
+			process.WaitReturns(42, nil)
+			return process, nil
+		}
+
+		container, err := apiClient.Lookup("some-handle")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		process, err := container.Run(garden.ProcessSpec{Path: "true"}, garden.ProcessIO{})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(process.Wait()).Should(Equal(42))
+
+		event := nextEvent()
+		expectEvent(event, garden.EventProcessSpawned, "some-handle")
+		Ω(event.ProcessID).Should(Equal("some-process"))
+
+		event = nextEvent()
+		expectEvent(event, garden.EventProcessExited, "some-handle")
+		Ω(event.ProcessID).Should(Equal("some-process"))
+		Ω(*event.ExitStatus).Should(Equal(42))
+	})
+
+	Context("when the backend reports an OOM", func() {
+		BeforeEach(func() {
+			serverContainer.InfoReturns(garden.ContainerInfo{Events: []string{"oom"}}, nil)
+		})
+
+		It("streams it once", func() {
+			container, err := apiClient.Lookup("some-handle")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = container.Info()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = container.Info()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expectEvent(nextEvent(), garden.EventContainerOOM, "some-handle")
+			Consistently(events).ShouldNot(Receive())
+		})
+	})
+
+	Context("when a container's grace time elapses", func() {
+		BeforeEach(func() {
+			serverBackend.GraceTimeReturns(50 * time.Millisecond)
+			serverBackend.ContainersReturns(nil, nil)
+		})
+
+		It("streams it being reaped", func() {
+			_, err := apiClient.Create(garden.ContainerSpec{GraceTime: 50 * time.Millisecond})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expectEvent(nextEvent(), garden.EventContainerCreated, "some-handle")
+			expectEvent(nextEvent(), garden.EventContainerReaped, "some-handle")
+		})
+	})
+
+	Context("when subscribing with a filter", func() {
+		JustBeforeEach(func() {
+			stream.Close()
+
+			var err error
+			stream, err = apiClient.Events(garden.EventFilter{
+				Kinds: []garden.EventKind{garden.EventContainerDestroyed},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("only streams matching events", func() {
+			_, err := apiClient.Create(garden.ContainerSpec{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(apiClient.Destroy("some-handle")).Should(Succeed())
+
+			event, err := stream.Next()
+			Ω(err).ShouldNot(HaveOccurred())
+			expectEvent(event, garden.EventContainerDestroyed, "some-handle")
+		})
+	})
+
+	Context("when the server stops", func() {
+		It("ends the stream", func() {
+			apiServer.Stop()
+
+			Eventually(events).Should(BeClosed())
+		})
+	})
+})
+
+func expectEvent(event garden.Event, kind garden.EventKind, handle string) {
+	ExpectWithOffset(1, event.Kind).Should(Equal(kind))
+	ExpectWithOffset(1, event.Handle).Should(Equal(handle))
+}
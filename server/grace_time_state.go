@@ -0,0 +1,233 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/pivotal-golang/lager"
+)
+
+// graceTimeStateFlushInterval is how often the grace time state file is
+// written while the server is running. It is also written on reaps and when
+// the server stops.
+const graceTimeStateFlushInterval = 10 * time.Second
+
+// reapRecordRetention is how long the server remembers why it reaped a
+// container, to explain later requests for its handle.
+const reapRecordRetention = 24 * time.Hour
+
+// SetGraceTimeStateFile configures a file in which the server keeps when each
+// container was last referenced, and which containers it has reaped and why,
+// so that grace times count down across restarts rather than starting afresh,
+// and reaps interrupted by a restart are completed. It must be called before
+// Start.
+func (s *GardenServer) SetGraceTimeStateFile(path string) {
+	s.graceTimeStatePath = path
+}
+
+type graceTimeState struct {
+	// when each container was last referenced
+	Referenced map[string]time.Time `json:"referenced"`
+
+	// the containers that have been reaped, or are being reaped
+	Reaped map[string]reapRecord `json:"reaped"`
+}
+
+type reapRecord struct {
+	At        time.Time `json:"at"`
+	Reason    string    `json:"reason"`
+	Destroyed bool      `json:"destroyed"`
+}
+
+func newGraceTimeState() *graceTimeState {
+	return &graceTimeState{
+		Referenced: make(map[string]time.Time),
+		Reaped:     make(map[string]reapRecord),
+	}
+}
+
+// restoreGraceTimes straps a bomb to each container, resuming the countdowns
+// recorded in the grace time state file, and reaps containers that were
+// being reaped when the server stopped.
+func (s *GardenServer) restoreGraceTimes(containers []garden.Container) {
+	state := s.loadGraceTimeState()
+
+	present := make(map[string]bool, len(containers))
+	for _, container := range containers {
+		present[container.Handle()] = true
+	}
+
+	s.graceTimeStateL.Lock()
+	for handle, record := range state.Reaped {
+		// containers that are gone were destroyed before the server stopped
+		if !present[handle] && time.Since(record.At) < reapRecordRetention {
+			record.Destroyed = true
+			s.graceTimeState.Reaped[handle] = record
+		}
+	}
+
+	for _, container := range containers {
+		handle := container.Handle()
+
+		if record, found := state.Reaped[handle]; found && !record.Destroyed {
+			s.logger.Info("resuming-reap", lager.Data{
+				"handle":    handle,
+				"reaped-at": record.At,
+			})
+
+			s.graceTimeState.Reaped[handle] = record
+			go s.reapContainer(container)
+
+			continue
+		}
+
+		if referenced, found := state.Referenced[handle]; found {
+			s.bomberman.StrapSince(container, referenced)
+		} else {
+			s.bomberman.Strap(container)
+		}
+	}
+	s.graceTimeStateL.Unlock()
+
+	if s.graceTimeStatePath != "" {
+		go s.flushGraceTimeState()
+	}
+}
+
+func (s *GardenServer) loadGraceTimeState() *graceTimeState {
+	state := newGraceTimeState()
+
+	if s.graceTimeStatePath == "" {
+		return state
+	}
+
+	content, err := ioutil.ReadFile(s.graceTimeStatePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Error("failed-to-read-grace-time-state", err)
+		}
+
+		return state
+	}
+
+	err = json.Unmarshal(content, state)
+	if err != nil {
+		s.logger.Error("failed-to-parse-grace-time-state", err)
+		return newGraceTimeState()
+	}
+
+	return state
+}
+
+// recordReap records that the container is being reaped and why, so that the
+// reap is completed if the server restarts first, and is forgotten once the
+// retention window has passed.
+func (s *GardenServer) recordReap(handle, reason string) {
+	now := time.Now()
+
+	s.graceTimeStateL.Lock()
+	for reaped, record := range s.graceTimeState.Reaped {
+		if record.Destroyed && now.Sub(record.At) >= reapRecordRetention {
+			delete(s.graceTimeState.Reaped, reaped)
+		}
+	}
+
+	s.graceTimeState.Reaped[handle] = reapRecord{
+		At:     now,
+		Reason: reason,
+	}
+	s.graceTimeStateL.Unlock()
+
+	s.saveGraceTimeState()
+}
+
+// recordReapDestroyed records that a reaped container has been destroyed.
+func (s *GardenServer) recordReapDestroyed(handle string) {
+	s.graceTimeStateL.Lock()
+	if record, found := s.graceTimeState.Reaped[handle]; found {
+		record.Destroyed = true
+		s.graceTimeState.Reaped[handle] = record
+	}
+	s.graceTimeStateL.Unlock()
+}
+
+// forgetReap forgets that a container was reaped, e.g. once another has been
+// created with its handle.
+func (s *GardenServer) forgetReap(handle string) {
+	s.graceTimeStateL.Lock()
+	delete(s.graceTimeState.Reaped, handle)
+	s.graceTimeStateL.Unlock()
+}
+
+// reapReason returns why the container with the handle was reaped, or an
+// empty string if it was not.
+func (s *GardenServer) reapReason(handle string) string {
+	s.graceTimeStateL.Lock()
+	defer s.graceTimeStateL.Unlock()
+
+	record, found := s.graceTimeState.Reaped[handle]
+	if !found || !record.Destroyed {
+		return ""
+	}
+
+	return record.Reason
+}
+
+func (s *GardenServer) flushGraceTimeState() {
+	ticker := time.NewTicker(graceTimeStateFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.saveGraceTimeState()
+		case <-s.stopping:
+			return
+		}
+	}
+}
+
+// saveGraceTimeState writes when each container was last referenced, and
+// which have been reaped, to the grace time state file. The file is replaced
+// atomically so that a crash cannot leave it partially written. Nothing is
+// written if the server failed to start before restoring the state.
+func (s *GardenServer) saveGraceTimeState() {
+	if s.graceTimeStatePath == "" || s.bomberman == nil {
+		return
+	}
+
+	s.graceTimeStateL.Lock()
+	defer s.graceTimeStateL.Unlock()
+
+	s.graceTimeState.Referenced = s.bomberman.Referenced()
+
+	content, err := json.Marshal(s.graceTimeState)
+	if err != nil {
+		s.logger.Error("failed-to-encode-grace-time-state", err)
+		return
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.graceTimeStatePath), filepath.Base(s.graceTimeStatePath))
+	if err != nil {
+		s.logger.Error("failed-to-write-grace-time-state", err)
+		return
+	}
+
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.graceTimeStatePath)
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		s.logger.Error("failed-to-write-grace-time-state", err)
+	}
+}
@@ -1,5 +1,142 @@
 package server_test
 
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	"github.com/cloudfoundry-incubator/garden/client/connection"
+	"github.com/cloudfoundry-incubator/garden/server"
+)
+
 func uint64ptr(n uint64) *uint64 {
 	return &n
 }
+
+// testServer is a server listening on a unix socket in a temporary directory.
+type testServer struct {
+	*server.GardenServer
+
+	tmpdir     string
+	socketPath string
+	stopped    bool
+}
+
+// newTestServer makes a server for the backend. It is not started, so that it
+// can be configured first.
+func newTestServer(graceTime time.Duration, backend garden.Backend) *testServer {
+	tmpdir, err := ioutil.TempDir(os.TempDir(), "api-server-test")
+	Ω(err).ShouldNot(HaveOccurred())
+
+	socketPath := path.Join(tmpdir, "api.sock")
+
+	return &testServer{
+		GardenServer: server.New("unix", socketPath, graceTime, backend, lagertest.NewTestLogger("test")),
+
+		tmpdir:     tmpdir,
+		socketPath: socketPath,
+	}
+}
+
+// start starts the server and returns a client for it.
+func (s *testServer) start() client.Client {
+	Ω(s.Start()).Should(Succeed())
+	return client.New(connection.New("unix", s.socketPath))
+}
+
+// lookup starts the server and looks up a container with a client for it.
+func (s *testServer) lookup(handle string) client.Container {
+	container, err := s.start().Lookup(handle)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	return container.(client.Container)
+}
+
+// Stop stops the server, unless it has been stopped already.
+func (s *testServer) Stop() {
+	if !s.stopped {
+		s.stopped = true
+		s.GardenServer.Stop()
+	}
+}
+
+// cleanup stops the server and removes its temporary directory.
+func (s *testServer) cleanup() {
+	s.Stop()
+	os.RemoveAll(s.tmpdir)
+}
+
+type testCertificates struct {
+	CAPool *x509.CertPool
+	Server tls.Certificate
+	Client tls.Certificate
+}
+
+// generateCertificates creates a CA along with a server certificate for
+// 127.0.0.1 and a client certificate with the given common name, both signed
+// by the CA.
+func generateCertificates(clientCommonName string) testCertificates {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "garden-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ca, err := x509.ParseCertificate(caDER)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	issue := func(serial int64, commonName string, usage x509.ExtKeyUsage) tls.Certificate {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: commonName},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return tls.Certificate{
+			Certificate: [][]byte{der},
+			PrivateKey:  key,
+		}
+	}
+
+	return testCertificates{
+		CAPool: pool,
+		Server: issue(2, "127.0.0.1", x509.ExtKeyUsageServerAuth),
+		Client: issue(3, clientCommonName, x509.ExtKeyUsageClientAuth),
+	}
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/pivotal-golang/lager"
+)
+
+// DefaultIdempotencyWindow is how long the container created for a
+// ContainerSpec.IdempotencyKey is remembered, unless set with
+// SetIdempotencyWindow.
+const DefaultIdempotencyWindow = 10 * time.Minute
+
+// SetIdempotencyWindow configures how long the container created for a
+// ContainerSpec.IdempotencyKey is remembered, and so how long a create may be
+// repeated without creating another container. It must be called before
+// Start.
+func (s *GardenServer) SetIdempotencyWindow(window time.Duration) {
+	s.idempotencyWindow = window
+}
+
+// idempotencyKey is an idempotency key as used by a caller, so that callers
+// cannot get each other's containers by using the same key.
+type idempotencyKey struct {
+	key string
+
+	token       string
+	certificate string
+	peerUID     uint32
+	hasPeerUID  bool
+}
+
+func idempotencyKeyOf(r *http.Request, key string) idempotencyKey {
+	credentials := credentialsOf(r)
+
+	scoped := idempotencyKey{
+		key:        key,
+		token:      credentials.Token,
+		peerUID:    credentials.PeerUID,
+		hasPeerUID: credentials.HasPeerUID,
+	}
+
+	if credentials.Certificate != nil {
+		scoped.certificate = string(credentials.Certificate.Raw)
+	}
+
+	return scoped
+}
+
+type idempotentCreate struct {
+	spec garden.ContainerSpec
+	done chan struct{}
+
+	handle string
+	err    error
+}
+
+// createIdempotently creates a container for the spec, unless the caller has
+// created one, or is creating one, with its idempotency key, in which case it
+// returns the handle of that container instead. If the request ends first it
+// stops waiting, but the create carries on, so that a retry finds its
+// container rather than creating another.
+func (s *GardenServer) createIdempotently(r *http.Request, spec garden.ContainerSpec, hLog lager.Logger) (string, error) {
+	key := idempotencyKeyOf(r, spec.IdempotencyKey)
+
+	s.createsL.Lock()
+
+	create, repeated := s.creates[key]
+	if !repeated {
+		create = &idempotentCreate{
+			spec: spec,
+			done: make(chan struct{}),
+		}
+
+		s.creates[key] = create
+	}
+
+	s.createsL.Unlock()
+
+	if repeated {
+		if !reflect.DeepEqual(create.spec, spec) {
+			return "", garden.NewConflictError("idempotency key was used to create a container with another spec")
+		}
+
+		hLog.Info("repeated", lager.Data{"idempotency-key": spec.IdempotencyKey})
+	} else {
+		go s.create(key, create, hLog)
+	}
+
+	select {
+	case <-create.done:
+	case <-r.Context().Done():
+		return "", r.Context().Err()
+	}
+
+	return create.handle, create.err
+}
+
+// create creates the container for an idempotency key. The create is shared by
+// every request with the key, so it is not bound to any of their contexts.
+func (s *GardenServer) create(key idempotencyKey, create *idempotentCreate, hLog lager.Logger) {
+	create.handle, create.err = s.createContainer(s.backend, create.spec, hLog)
+	close(create.done)
+
+	forget := func() {
+		s.createsL.Lock()
+		if s.creates[key] == create {
+			delete(s.creates, key)
+		}
+		s.createsL.Unlock()
+	}
+
+	if create.err != nil {
+		forget()
+	} else {
+		time.AfterFunc(s.idempotencyWindow, forget)
+	}
+}
+
+// forgetCreatesOf forgets the idempotency keys the container with the handle
+// was created for, once it is gone, so that repeating the create makes another
+// container rather than returning the handle of one that no longer exists.
+func (s *GardenServer) forgetCreatesOf(handle string) {
+	s.createsL.Lock()
+	defer s.createsL.Unlock()
+
+	for key, create := range s.creates {
+		select {
+		case <-create.done:
+			if create.err == nil && create.handle == handle {
+				delete(s.creates, key)
+			}
+		default:
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package server_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	"github.com/cloudfoundry-incubator/garden/client/connection"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+)
+
+var _ = Describe("Idempotent creates", func() {
+	var (
+		serverBackend *fakes.FakeBackend
+		apiServer     *testServer
+		apiClient     garden.Client
+
+		window time.Duration
+	)
+
+	spec := garden.ContainerSpec{
+		IdempotencyKey: "some-key",
+		Properties:     garden.Properties{"foo": "bar"},
+	}
+
+	BeforeEach(func() {
+		serverBackend = new(fakes.FakeBackend)
+
+		created := 0
+		serverBackend.CreateStub = func(garden.ContainerSpec) (garden.Container, error) {
+			created++
+
+			container := new(fakes.FakeContainer)
+			container.HandleReturns([]string{"first-handle", "second-handle"}[created-1])
+			return container, nil
+		}
+
+		window = time.Minute
+	})
+
+	JustBeforeEach(func() {
+		apiServer = newTestServer(0, serverBackend)
+		apiServer.SetIdempotencyWindow(window)
+
+		apiClient = apiServer.start()
+	})
+
+	AfterEach(func() {
+		apiServer.cleanup()
+	})
+
+	It("returns the container created for the key rather than creating another", func() {
+		first, err := apiClient.Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		repeated, err := apiClient.Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(repeated.Handle()).Should(Equal(first.Handle()))
+		Ω(serverBackend.CreateCallCount()).Should(Equal(1))
+	})
+
+	It("creates a container for each key", func() {
+		_, err := apiClient.Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		another := spec
+		another.IdempotencyKey = "another-key"
+
+		container, err := apiClient.Create(another)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(container.Handle()).Should(Equal("second-handle"))
+		Ω(serverBackend.CreateCallCount()).Should(Equal(2))
+	})
+
+	It("creates a container for each caller using the key", func() {
+		clientWithToken := func(token string) garden.Client {
+			header := http.Header{}
+			header.Set("Authorization", "Bearer "+token)
+
+			return client.New(connection.NewWithHijacker(
+				connection.NewHijackStreamerWithDialerAndHeader(func(string, string) (net.Conn, error) {
+					return net.Dial("unix", apiServer.socketPath)
+				}, header),
+				lagertest.NewTestLogger("test"),
+			))
+		}
+
+		first, err := clientWithToken("some-token").Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		second, err := clientWithToken("another-token").Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(first.Handle()).Should(Equal("first-handle"))
+		Ω(second.Handle()).Should(Equal("second-handle"))
+	})
+
+	It("forgets the key once its container is destroyed", func() {
+		first, err := apiClient.Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(apiClient.Destroy(first.Handle())).Should(Succeed())
+
+		repeated, err := apiClient.Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(repeated.Handle()).Should(Equal("second-handle"))
+	})
+
+	It("returns a ConflictError if the key is repeated with another spec", func() {
+		_, err := apiClient.Create(spec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		another := spec
+		another.Properties = garden.Properties{"foo": "baz"}
+
+		_, err = apiClient.Create(another)
+		Ω(err).Should(BeAssignableToTypeOf(garden.ConflictError{}))
+		Ω(serverBackend.CreateCallCount()).Should(Equal(1))
+	})
+
+	Context("when the create is repeated while it is in flight", func() {
+		var creating chan struct{}
+		var finish chan struct{}
+
+		BeforeEach(func() {
+			creating = make(chan struct{})
+			finish = make(chan struct{})
+
+			creating, finish := creating, finish
+			serverBackend.CreateStub = func(garden.ContainerSpec) (garden.Container, error) {
+				close(creating)
+				<-finish
+
+				container := new(fakes.FakeContainer)
+				container.HandleReturns("first-handle")
+				return container, nil
+			}
+		})
+
+		It("waits for it and returns the container it created", func() {
+			go apiClient.Create(spec)
+			<-creating
+
+			repeated := make(chan string, 1)
+			go func() {
+				defer GinkgoRecover()
+
+				container, err := apiClient.Create(spec)
+				Ω(err).ShouldNot(HaveOccurred())
+				repeated <- container.Handle()
+			}()
+
+			Consistently(repeated).ShouldNot(Receive())
+
+			close(finish)
+			Eventually(repeated).Should(Receive(Equal("first-handle")))
+
+			Ω(serverBackend.CreateCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when the create fails", func() {
+		BeforeEach(func() {
+			serverBackend.CreateStub = nil
+			serverBackend.CreateReturns(nil, errors.New("oh no!"))
+		})
+
+		It("forgets the key, so that the create can be tried again", func() {
+			_, err := apiClient.Create(spec)
+			Ω(err).Should(MatchError("oh no!"))
+
+			container := new(fakes.FakeContainer)
+			container.HandleReturns("some-handle")
+			serverBackend.CreateReturns(container, nil)
+
+			created, err := apiClient.Create(spec)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(created.Handle()).Should(Equal("some-handle"))
+
+			Ω(serverBackend.CreateCallCount()).Should(Equal(2))
+		})
+	})
+
+	Context("once the window has passed", func() {
+		BeforeEach(func() {
+			window = 100 * time.Millisecond
+		})
+
+		It("forgets the key", func() {
+			_, err := apiClient.Create(spec)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Eventually(func() string {
+				container, err := apiClient.Create(spec)
+				Ω(err).ShouldNot(HaveOccurred())
+				return container.Handle()
+			}).Should(Equal("second-handle"))
+		})
+	})
+})
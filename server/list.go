@@ -0,0 +1,202 @@
+package server
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/routes"
+)
+
+// The query parameters of the List route that carry garden.ListOptions. Its
+// other parameters are properties the containers must have.
+const (
+	selectorParam = "garden.selector"
+	limitParam    = "garden.limit"
+	continueParam = "garden.continue"
+	fieldsParam   = "garden.fields"
+)
+
+var listParams = map[string]bool{
+	selectorParam: true,
+	limitParam:    true,
+	continueParam: true,
+	fieldsParam:   true,
+}
+
+func listOptions(query url.Values) (garden.ListOptions, error) {
+	options := garden.ListOptions{
+		Properties: garden.Properties{},
+		Continue:   query.Get(continueParam),
+	}
+
+	for name, vals := range query {
+		if !listParams[name] && len(vals) > 0 {
+			options.Properties[name] = vals[0]
+		}
+	}
+
+	var err error
+
+	if expr := query.Get(selectorParam); expr != "" {
+		options.Selector, err = garden.ParseSelector(expr)
+		if err != nil {
+			return options, err
+		}
+	}
+
+	if limit := query.Get(limitParam); limit != "" {
+		options.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return options, garden.InvalidRequestError{
+				Fields: []garden.FieldError{{Field: "limit", Message: "must be an integer"}},
+			}
+		}
+	}
+
+	if fields := query.Get(fieldsParam); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			options.Fields = append(options.Fields, garden.ContainerInfoField(field))
+		}
+	}
+
+	if _, err := continueAfter(options.Continue); err != nil {
+		return options, err
+	}
+
+	return options, options.Validate()
+}
+
+// continueAfter returns the handle after which the page with the continue
+// token starts. Tokens are opaque to clients, so that how pages are ordered
+// can change.
+func continueAfter(token string) (string, error) {
+	after, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", garden.InvalidRequestError{
+			Fields: []garden.FieldError{{Field: "continue", Message: "must be the token of an earlier page"}},
+		}
+	}
+
+	return string(after), nil
+}
+
+func continueToken(after string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(after))
+}
+
+// listPage orders the containers by handle and returns the page of them the
+// options select. Containers destroyed before their info could be got are left
+// out of the page, and the info of containers the caller may not get it for
+// by Info is left out of page.Infos.
+func (s *GardenServer) listPage(r *http.Request, containers []garden.Container, options garden.ListOptions) (garden.ListPage, error) {
+	after, err := continueAfter(options.Continue)
+	if err != nil {
+		return garden.ListPage{}, err
+	}
+
+	handles := []string{}
+	for _, container := range containers {
+		if handle := container.Handle(); options.Continue == "" || handle > after {
+			handles = append(handles, handle)
+		}
+	}
+
+	sort.Strings(handles)
+
+	page := garden.ListPage{Handles: handles}
+
+	if options.Limit > 0 && len(handles) > options.Limit {
+		page.Handles = handles[:options.Limit]
+		page.Continue = continueToken(page.Handles[len(page.Handles)-1])
+	}
+
+	if len(options.Fields) == 0 || len(page.Handles) == 0 {
+		return page, nil
+	}
+
+	allowed := []string{}
+	forbidden := map[string]bool{}
+
+	for _, handle := range page.Handles {
+		if s.authorize(routes.Info, handle, r) != nil {
+			forbidden[handle] = true
+			continue
+		}
+
+		allowed = append(allowed, handle)
+	}
+
+	bulkInfo := map[string]garden.ContainerInfoEntry{}
+	if len(allowed) > 0 {
+		bulkInfo, err = s.backendFor(r).BulkInfo(allowed)
+		if err != nil {
+			return garden.ListPage{}, err
+		}
+	}
+
+	s.markDestroying(bulkInfo)
+
+	found := []string{}
+	page.Infos = make(map[string]garden.ContainerInfo, len(allowed))
+
+	for _, handle := range page.Handles {
+		if forbidden[handle] {
+			found = append(found, handle)
+			continue
+		}
+
+		entry, ok := bulkInfo[handle]
+		if !ok {
+			continue
+		}
+
+		if entry.Err != nil {
+			if _, ok := entry.Err.Err.(garden.ContainerNotFoundError); ok {
+				continue
+			}
+
+			return garden.ListPage{}, entry.Err
+		}
+
+		s.observeContainerEvents(handle, entry.Info.Events)
+
+		found = append(found, handle)
+		page.Infos[handle] = projectInfo(entry.Info, options.Fields)
+	}
+
+	page.Handles = found
+
+	return page, nil
+}
+
+func projectInfo(info garden.ContainerInfo, fields []garden.ContainerInfoField) garden.ContainerInfo {
+	var projected garden.ContainerInfo
+
+	for _, field := range fields {
+		switch field {
+		case garden.ContainerInfoFieldState:
+			projected.State = info.State
+		case garden.ContainerInfoFieldEvents:
+			projected.Events = info.Events
+		case garden.ContainerInfoFieldIPs:
+			projected.HostIP = info.HostIP
+			projected.ContainerIP = info.ContainerIP
+			projected.ExternalIP = info.ExternalIP
+		case garden.ContainerInfoFieldContainerPath:
+			projected.ContainerPath = info.ContainerPath
+		case garden.ContainerInfoFieldProcessIDs:
+			projected.ProcessIDs = info.ProcessIDs
+		case garden.ContainerInfoFieldProperties:
+			projected.Properties = info.Properties
+		case garden.ContainerInfoFieldMappedPorts:
+			projected.MappedPorts = info.MappedPorts
+		}
+	}
+
+	return projected
+}
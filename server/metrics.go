@@ -0,0 +1,256 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/routes"
+)
+
+// MetricsRoute is the route name the Authorizer is given for requests to the
+// metrics endpoint.
+const MetricsRoute = "ServerMetrics"
+
+// requestDurationBuckets are the upper bounds, in seconds, of the request
+// duration histogram buckets.
+var requestDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type routeMetrics struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+type serverMetrics struct {
+	// accessed atomically; kept first for 64-bit alignment
+	openConnections     int64
+	hijackedConnections int64
+	droppedChunks       uint64
+	droppedBytes        uint64
+
+	mu       sync.Mutex
+	requests map[string]*routeMetrics
+	errors   map[string]uint64
+}
+
+func newServerMetrics() *serverMetrics {
+	requests := make(map[string]*routeMetrics)
+	for _, route := range routes.Routes {
+		requests[route.Name] = &routeMetrics{
+			buckets: make([]uint64, len(requestDurationBuckets)),
+		}
+	}
+
+	return &serverMetrics{
+		requests: requests,
+		errors:   make(map[string]uint64),
+	}
+}
+
+func (m *serverMetrics) observeRequest(route string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rm, found := m.requests[route]
+	if !found {
+		rm = &routeMetrics{buckets: make([]uint64, len(requestDurationBuckets))}
+		m.requests[route] = rm
+	}
+
+	seconds := duration.Seconds()
+
+	rm.count++
+	rm.sum += seconds
+
+	for i, bound := range requestDurationBuckets {
+		if seconds <= bound {
+			rm.buckets[i]++
+		}
+	}
+}
+
+func (m *serverMetrics) observeError(err error) {
+	errType := garden.Error{Err: err}.Type()
+	if errType == "" {
+		errType = "Error"
+	}
+
+	m.mu.Lock()
+	m.errors[errType]++
+	m.mu.Unlock()
+}
+
+// snapshot copies the request and error counters, so that they can be written
+// out without holding up the requests that update them.
+func (m *serverMetrics) snapshot() (map[string]routeMetrics, map[string]uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make(map[string]routeMetrics, len(m.requests))
+	for name, rm := range m.requests {
+		requests[name] = routeMetrics{
+			count:   rm.count,
+			sum:     rm.sum,
+			buckets: append([]uint64(nil), rm.buckets...),
+		}
+	}
+
+	errors := make(map[string]uint64, len(m.errors))
+	for errType, count := range m.errors {
+		errors[errType] = count
+	}
+
+	return requests, errors
+}
+
+// EnableMetrics serves the server's own metrics in the Prometheus text
+// exposition format on GET /metrics. It must be called before Start.
+func (s *GardenServer) EnableMetrics() {
+	s.metricsEnabled = true
+}
+
+// MetricsHandler serves the server's own metrics in the Prometheus text
+// exposition format, e.g. for serving them on a separate listener.
+func (s *GardenServer) MetricsHandler() http.Handler {
+	return http.HandlerFunc(s.handleMetricsScrape)
+}
+
+func (s *GardenServer) handleMetricsScrape(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.writeMetrics(w)
+}
+
+func (s *GardenServer) writeMetrics(w io.Writer) {
+	m := s.metrics
+
+	requests, errors := m.snapshot()
+
+	routeNames := []string{}
+	for name := range requests {
+		routeNames = append(routeNames, name)
+	}
+	sort.Strings(routeNames)
+
+	fmt.Fprintln(w, "# HELP garden_requests_total Requests handled, by route.")
+	fmt.Fprintln(w, "# TYPE garden_requests_total counter")
+	for _, name := range routeNames {
+		fmt.Fprintf(w, "garden_requests_total{route=%q} %d\n", name, requests[name].count)
+	}
+
+	fmt.Fprintln(w, "# HELP garden_request_duration_seconds Time taken to handle requests, by route. Process and event streams count until they end.")
+	fmt.Fprintln(w, "# TYPE garden_request_duration_seconds histogram")
+	for _, name := range routeNames {
+		rm := requests[name]
+
+		for i, bound := range requestDurationBuckets {
+			fmt.Fprintf(w, "garden_request_duration_seconds_bucket{route=%q,le=\"%g\"} %d\n", name, bound, rm.buckets[i])
+		}
+
+		fmt.Fprintf(w, "garden_request_duration_seconds_bucket{route=%q,le=\"+Inf\"} %d\n", name, rm.count)
+		fmt.Fprintf(w, "garden_request_duration_seconds_sum{route=%q} %g\n", name, rm.sum)
+		fmt.Fprintf(w, "garden_request_duration_seconds_count{route=%q} %d\n", name, rm.count)
+	}
+
+	errTypes := []string{}
+	for errType := range errors {
+		errTypes = append(errTypes, errType)
+	}
+	sort.Strings(errTypes)
+
+	fmt.Fprintln(w, "# HELP garden_errors_total Errors returned to clients, by garden.Error type.")
+	fmt.Fprintln(w, "# TYPE garden_errors_total counter")
+	for _, errType := range errTypes {
+		fmt.Fprintf(w, "garden_errors_total{type=%q} %d\n", errType, errors[errType])
+	}
+
+	writeGauge(w, "garden_open_connections", "Connections currently open, including hijacked ones.", atomic.LoadInt64(&m.openConnections))
+	writeGauge(w, "garden_hijacked_connections", "Connections currently hijacked for process streams.", atomic.LoadInt64(&m.hijackedConnections))
+	writeGauge(w, "garden_active_streams", "Process output streams that have not been stopped.", int64(s.streamer.ActiveStreams()))
+
+	if s.bomberman != nil {
+		stats := s.bomberman.Stats()
+
+		writeGauge(w, "garden_bombs_armed", "Containers whose grace time is counting down or paused.", int64(stats.Armed))
+		writeGauge(w, "garden_bombs_paused", "Containers whose grace time is paused by a request in flight.", int64(stats.Paused))
+
+		fmt.Fprintln(w, "# HELP garden_bombs_detonated_total Grace times that elapsed, including those whose reap was then delayed or vetoed.")
+		fmt.Fprintln(w, "# TYPE garden_bombs_detonated_total counter")
+		fmt.Fprintf(w, "garden_bombs_detonated_total %d\n", stats.Detonated)
+	}
+
+	fmt.Fprintln(w, "# HELP garden_dropped_output_chunks_total Chunks of process output dropped because the client fell behind.")
+	fmt.Fprintln(w, "# TYPE garden_dropped_output_chunks_total counter")
+	fmt.Fprintf(w, "garden_dropped_output_chunks_total %d\n", atomic.LoadUint64(&m.droppedChunks))
+
+	fmt.Fprintln(w, "# HELP garden_dropped_output_bytes_total Bytes of process output dropped because the client fell behind.")
+	fmt.Fprintln(w, "# TYPE garden_dropped_output_bytes_total counter")
+	fmt.Fprintf(w, "garden_dropped_output_bytes_total %d\n", atomic.LoadUint64(&m.droppedBytes))
+}
+
+func writeGauge(w io.Writer, name, help string, value int64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(w, "%s %d\n", name, value)
+}
+
+func (s *GardenServer) instrumenting(route string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+
+		handler.ServeHTTP(&instrumentedResponseWriter{ResponseWriter: w, metrics: s.metrics}, r)
+
+		s.metrics.observeRequest(route, time.Since(started))
+	})
+}
+
+// instrumentedResponseWriter counts hijacked connections until they are
+// closed.
+type instrumentedResponseWriter struct {
+	http.ResponseWriter
+	metrics *serverMetrics
+}
+
+func (w *instrumentedResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *instrumentedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T cannot be hijacked", w.ResponseWriter)
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	atomic.AddInt64(&w.metrics.hijackedConnections, 1)
+
+	return &hijackedConn{Conn: conn, metrics: w.metrics}, rw, nil
+}
+
+type hijackedConn struct {
+	net.Conn
+	metrics *serverMetrics
+	closed  sync.Once
+}
+
+func (c *hijackedConn) Close() error {
+	c.closed.Do(func() {
+		atomic.AddInt64(&c.metrics.hijackedConnections, -1)
+		atomic.AddInt64(&c.metrics.openConnections, -1)
+	})
+
+	return c.Conn.Close()
+}
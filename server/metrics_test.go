@@ -0,0 +1,158 @@
+package server_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	"github.com/cloudfoundry-incubator/garden/client/connection"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+	"github.com/cloudfoundry-incubator/garden/server"
+)
+
+// stalledResponseWriter blocks writes until it is released, like the
+// connection to a scraper that has stopped reading.
+type stalledResponseWriter struct {
+	*httptest.ResponseRecorder
+	released chan struct{}
+}
+
+func (w *stalledResponseWriter) Write(b []byte) (int, error) {
+	<-w.released
+	return len(b), nil
+}
+
+var _ = Describe("Metrics", func() {
+	var (
+		fakeBackend *fakes.FakeBackend
+		apiServer   *server.GardenServer
+		apiClient   garden.Client
+	)
+
+	BeforeEach(func() {
+		fakeBackend = new(fakes.FakeBackend)
+
+		apiServer = server.New("tcp", "127.0.0.1:60127", 0, fakeBackend, lagertest.NewTestLogger("test"))
+	})
+
+	JustBeforeEach(func() {
+		Ω(apiServer.Start()).Should(Succeed())
+
+		apiClient = client.New(connection.New("tcp", "127.0.0.1:60127"))
+	})
+
+	AfterEach(func() {
+		apiServer.Stop()
+	})
+
+	scrape := func() string {
+		resp, err := http.Get("http://127.0.0.1:60127/metrics")
+		Ω(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return string(body)
+	}
+
+	Context("when enabled", func() {
+		BeforeEach(func() {
+			apiServer.EnableMetrics()
+		})
+
+		It("counts requests per route", func() {
+			Ω(apiClient.Ping()).Should(Succeed())
+			Ω(apiClient.Ping()).Should(Succeed())
+
+			metrics := scrape()
+			Ω(metrics).Should(ContainSubstring(`garden_requests_total{route="Ping"} 2`))
+			Ω(metrics).Should(ContainSubstring(`garden_requests_total{route="Destroy"} 0`))
+			Ω(metrics).Should(ContainSubstring(`garden_request_duration_seconds_count{route="Ping"} 2`))
+			Ω(metrics).Should(ContainSubstring(`garden_request_duration_seconds_bucket{route="Ping",le="+Inf"} 2`))
+		})
+
+		It("counts errors per type", func() {
+			fakeBackend.DestroyReturns(garden.ContainerNotFoundError{Handle: "bogus"})
+			fakeBackend.PingReturns(errors.New("oh no"))
+
+			Ω(apiClient.Destroy("bogus")).ShouldNot(Succeed())
+			Ω(apiClient.Ping()).ShouldNot(Succeed())
+
+			metrics := scrape()
+			Ω(metrics).Should(ContainSubstring(`garden_errors_total{type="ContainerNotFoundError"} 1`))
+			Ω(metrics).Should(ContainSubstring(`garden_errors_total{type="Error"} 1`))
+		})
+
+		It("reports connections, streams and grace time bombs", func() {
+			metrics := scrape()
+			Ω(metrics).Should(ContainSubstring("garden_open_connections 1\n"))
+			Ω(metrics).Should(ContainSubstring("garden_hijacked_connections 0\n"))
+			Ω(metrics).Should(ContainSubstring("garden_active_streams 0\n"))
+			Ω(metrics).Should(ContainSubstring("garden_bombs_armed 0\n"))
+			Ω(metrics).Should(ContainSubstring("garden_bombs_paused 0\n"))
+			Ω(metrics).Should(ContainSubstring("garden_bombs_detonated_total 0\n"))
+			Ω(metrics).Should(ContainSubstring("garden_dropped_output_chunks_total 0\n"))
+		})
+
+		It("handles requests while a scrape is stalled", func() {
+			stalled := &stalledResponseWriter{
+				ResponseRecorder: httptest.NewRecorder(),
+				released:         make(chan struct{}),
+			}
+			defer close(stalled.released)
+
+			go apiServer.MetricsHandler().ServeHTTP(stalled, new(http.Request))
+
+			fakeBackend.PingReturns(errors.New("oh no"))
+
+			pinged := make(chan error, 2)
+			go func() {
+				pinged <- apiClient.Ping()
+				pinged <- apiClient.Ping()
+			}()
+
+			Eventually(pinged).Should(Receive(HaveOccurred()))
+			Eventually(pinged).Should(Receive(HaveOccurred()))
+		})
+
+		Context("when an authorizer is set", func() {
+			BeforeEach(func() {
+				apiServer.SetAuthorizer(server.AuthorizerFunc(func(route string, handle string, credentials server.Credentials) error {
+					if route == server.MetricsRoute {
+						return garden.NewForbiddenError("no metrics for you")
+					}
+
+					return nil
+				}))
+			})
+
+			It("authorizes scrapes", func() {
+				resp, err := http.Get("http://127.0.0.1:60127/metrics")
+				Ω(err).ShouldNot(HaveOccurred())
+				resp.Body.Close()
+
+				Ω(resp.StatusCode).Should(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Context("when not enabled", func() {
+		It("does not serve metrics", func() {
+			resp, err := http.Get("http://127.0.0.1:60127/metrics")
+			Ω(err).ShouldNot(HaveOccurred())
+			resp.Body.Close()
+
+			Ω(resp.StatusCode).Should(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,342 @@
+package server_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	"github.com/cloudfoundry-incubator/garden/client/connection"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+	"github.com/cloudfoundry-incubator/garden/server"
+	"github.com/cloudfoundry-incubator/garden/server/streamer"
+)
+
+var _ = Describe("Process output backpressure", func() {
+	const chunk = "0123456789"
+
+	var (
+		spillDir string
+
+		policy          *server.OutputPolicy
+		serverContainer *fakes.FakeContainer
+
+		apiServer *testServer
+		container garden.Container
+	)
+
+	// writeChunks writes count chunks of output to stdout, returning a channel
+	// that is closed once they have all been written
+	writeChunks := func(stdout interface {
+		Write([]byte) (int, error)
+	}, count int) chan struct{} {
+		written := make(chan struct{})
+
+		go func() {
+			defer close(written)
+
+			for i := 0; i < count; i++ {
+				stdout.Write([]byte(chunk))
+			}
+		}()
+
+		return written
+	}
+
+	BeforeEach(func() {
+		var err error
+		spillDir, err = ioutil.TempDir(os.TempDir(), "output-spill-test")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		policy = nil
+
+		serverContainer = new(fakes.FakeContainer)
+		serverContainer.HandleReturns("some-handle")
+	})
+
+	JustBeforeEach(func() {
+		serverBackend := new(fakes.FakeBackend)
+		serverBackend.LookupReturns(serverContainer, nil)
+		serverBackend.ContainersReturns([]garden.Container{serverContainer}, nil)
+
+		apiServer = newTestServer(0, serverBackend)
+		if policy != nil {
+			apiServer.SetOutputPolicy(*policy)
+		}
+
+		container = apiServer.lookup("some-handle")
+	})
+
+	AfterEach(func() {
+		apiServer.cleanup()
+		os.RemoveAll(spillDir)
+	})
+
+	// runWithOutput runs a process that writes count chunks to stdout. If
+	// beforeResponding is true they are all written before the server responds
+	// to the Run request, i.e. before the client could possibly consume them.
+	runWithOutput := func(count int, beforeResponding bool) (string, uint64) {
+		serverContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+			written := writeChunks(io.Stdout, count)
+			if beforeResponding {
+				<-written
+			}
+
+			process := new(fakes.FakeProcess)
+			process.IDReturns("some-process")
+			process.WaitStub = func() (int, error) {
+				<-written
+				return 0, nil
+			}
+
+			return process, nil
+		}
+
+		stdout := gbytes.NewBuffer()
+
+		process, err := container.Run(garden.ProcessSpec{Path: "noisy"}, garden.ProcessIO{
+			Stdout: stdout,
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(process.Wait()).Should(Equal(0))
+
+		reporter, ok := process.(connection.DroppedOutputReporter)
+		Ω(ok).Should(BeTrue())
+
+		return string(stdout.Contents()), reporter.DroppedOutputBytes()
+	}
+
+	Context("by default", func() {
+		It("drops output the client cannot keep up with and reports how much", func() {
+			output, dropped := runWithOutput(1500, true)
+
+			Ω(output).Should(Equal(strings.Repeat(chunk, 1000)))
+			Ω(dropped).Should(Equal(uint64(500 * len(chunk))))
+		})
+
+		It("reports nothing dropped when the client keeps up", func() {
+			_, dropped := runWithOutput(10, false)
+			Ω(dropped).Should(BeZero())
+		})
+	})
+
+	Context("when blocking", func() {
+		BeforeEach(func() {
+			policy = &server.OutputPolicy{Backpressure: server.BlockOutput}
+		})
+
+		It("delivers all output", func() {
+			output, dropped := runWithOutput(3000, false)
+
+			Ω(output).Should(Equal(strings.Repeat(chunk, 3000)))
+			Ω(dropped).Should(BeZero())
+		})
+
+		It("does not block a process whose output no client is streaming", func() {
+			// more than fits in the stream's buffer and channel together
+			count := 2 * streamer.DefaultBufferSize / len(chunk)
+
+			serverContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+				written := writeChunks(io.Stdout, count)
+
+				process := new(fakes.FakeProcess)
+				process.IDReturns("some-process")
+				process.WaitStub = func() (int, error) {
+					<-written
+					return 0, nil
+				}
+
+				return process, nil
+			}
+
+			process, err := container.Run(garden.ProcessSpec{Path: "noisy"}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			exited := make(chan int, 1)
+			go func() {
+				status, _ := process.Wait()
+				exited <- status
+			}()
+
+			Eventually(exited, 10*time.Second).Should(Receive(Equal(0)))
+
+			reporter, ok := process.(connection.DroppedOutputReporter)
+			Ω(ok).Should(BeTrue())
+			Ω(reporter.DroppedOutputBytes()).ShouldNot(BeZero())
+		})
+	})
+
+	Context("when spilling to disk", func() {
+		BeforeEach(func() {
+			policy = &server.OutputPolicy{
+				Backpressure: server.SpillOutput,
+				SpillDir:     spillDir,
+			}
+		})
+
+		It("delivers all output in order", func() {
+			output, dropped := runWithOutput(3000, true)
+
+			Ω(output).Should(Equal(strings.Repeat(chunk, 3000)))
+			Ω(dropped).Should(BeZero())
+		})
+
+		It("removes its spill files afterwards", func() {
+			runWithOutput(3000, true)
+
+			Eventually(func() []string {
+				names, err := filepath.Glob(path.Join(spillDir, "garden-output-*"))
+				Ω(err).ShouldNot(HaveOccurred())
+				return names
+			}).Should(BeEmpty())
+		})
+
+		Context("when the spill limit is exceeded", func() {
+			BeforeEach(func() {
+				policy.SpillLimit = int64(100 * len(chunk))
+			})
+
+			It("drops what does not fit and reports how much", func() {
+				output, dropped := runWithOutput(1500, true)
+
+				Ω(output).Should(Equal(strings.Repeat(chunk, 1100)))
+				Ω(dropped).Should(Equal(uint64(400 * len(chunk))))
+			})
+		})
+	})
+})
+
+var _ = Describe("Resuming process output", func() {
+	var (
+		serverContainer *fakes.FakeContainer
+		processIO       chan garden.ProcessIO
+		exit            chan struct{}
+
+		apiServer *testServer
+		container client.Container
+	)
+
+	BeforeEach(func() {
+		processIO = make(chan garden.ProcessIO, 1)
+		exit = make(chan struct{})
+
+		newProcess := func() garden.Process {
+			process := new(fakes.FakeProcess)
+			process.IDReturns("some-process")
+			process.WaitStub = func() (int, error) {
+				<-exit
+				return 0, nil
+			}
+
+			return process
+		}
+
+		serverContainer = new(fakes.FakeContainer)
+		serverContainer.HandleReturns("some-handle")
+		serverContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+			processIO <- io
+			return newProcess(), nil
+		}
+		serverContainer.AttachStub = func(processID string, io garden.ProcessIO) (garden.Process, error) {
+			return newProcess(), nil
+		}
+
+		serverBackend := new(fakes.FakeBackend)
+		serverBackend.LookupReturns(serverContainer, nil)
+		serverBackend.ContainersReturns([]garden.Container{serverContainer}, nil)
+
+		apiServer = newTestServer(0, serverBackend)
+		container = apiServer.lookup("some-handle")
+	})
+
+	AfterEach(func() {
+		apiServer.cleanup()
+	})
+
+	var (
+		stdout io.Writer
+		run    garden.Process
+	)
+
+	JustBeforeEach(func() {
+		firstStdout := gbytes.NewBuffer()
+
+		var err error
+		run, err = container.Run(garden.ProcessSpec{Path: "noisy"}, garden.ProcessIO{
+			Stdout: firstStdout,
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		stdout = (<-processIO).Stdout
+
+		stdout.Write([]byte("hello "))
+		Eventually(firstStdout).Should(gbytes.Say("hello "))
+	})
+
+	It("resumes streaming from the offsets of an earlier attachment", func() {
+		offsets := run.(connection.OutputOffsetReporter).OutputOffsets()
+		Ω(offsets.Stdout).Should(Equal(uint64(len("hello "))))
+
+		resumedStdout := gbytes.NewBuffer()
+
+		process, err := container.AttachFrom("some-process", offsets, garden.ProcessIO{
+			Stdout: resumedStdout,
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		stdout.Write([]byte("world"))
+		close(exit)
+
+		Ω(process.Wait()).Should(Equal(0))
+		Ω(string(resumedStdout.Contents())).Should(Equal("world"))
+	})
+
+	It("replays output from earlier offsets", func() {
+		replayedStdout := gbytes.NewBuffer()
+
+		process, err := container.AttachFrom("some-process", connection.OutputOffsets{}, garden.ProcessIO{
+			Stdout: replayedStdout,
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		close(exit)
+
+		Ω(process.Wait()).Should(Equal(0))
+		Ω(string(replayedStdout.Contents())).Should(Equal("hello "))
+	})
+
+	It("does not attach to the process's output again", func() {
+		_, err := container.Attach("some-process", garden.ProcessIO{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, attachedIO := serverContainer.AttachArgsForCall(0)
+		Ω(attachedIO.Stdout).Should(BeNil())
+		Ω(attachedIO.Stderr).Should(BeNil())
+
+		close(exit)
+	})
+
+	Context("when the offsets are not available", func() {
+		It("fails", func() {
+			process, err := container.AttachFrom("some-process", connection.OutputOffsets{Stdout: 100}, garden.ProcessIO{
+				Stdout: gbytes.NewBuffer(),
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = process.Wait()
+			Ω(err).Should(MatchError(ContainSubstring("offset is not available")))
+
+			close(exit)
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"net"
+	"syscall"
+)
+
+func peerUIDOf(conn net.Conn) (uint32, bool) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, false
+	}
+
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return 0, false
+	}
+
+	var ucred *syscall.Ucred
+	var credErr error
+
+	err = rawConn.Control(func(fd uintptr) {
+		ucred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil || credErr != nil {
+		return 0, false
+	}
+
+	return ucred.Uid, true
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/transport"
+	"github.com/pivotal-golang/lager"
+)
+
+// DefaultProcessStatusRetention is how long the status of a detached process
+// is kept after it exits, unless set with SetProcessStatusRetention.
+const DefaultProcessStatusRetention = 10 * time.Minute
+
+// processStatusTailSize is how many bytes from the end of each of a detached
+// process's stdout and stderr are kept with its status.
+const processStatusTailSize = 64 * 1024
+
+// SetProcessStatusRetention configures how long the status of a detached
+// process is kept after it exits. It must be called before Start.
+func (s *GardenServer) SetProcessStatusRetention(retention time.Duration) {
+	s.statusRetention = retention
+}
+
+type processStatus struct {
+	mu     sync.Mutex
+	status garden.ProcessStatus
+
+	stdout *tailBuffer
+	stderr *tailBuffer
+}
+
+// recordStatus starts recording the status of a detached process, including
+// the end of the output written to output.
+func (s *GardenServer) recordStatus(handle, processID string, output *processOutput) {
+	status := &processStatus{
+		status: garden.ProcessStatus{
+			ID:    processID,
+			State: "running",
+		},
+		stdout: output.stdout.tail,
+		stderr: output.stderr.tail,
+	}
+
+	s.statusesL.Lock()
+	s.statuses[processKey{handle: handle, id: processID}] = status
+	s.statusesL.Unlock()
+}
+
+// recordExit records the exit of a detached process, and forgets it once the
+// retention window has passed.
+func (s *GardenServer) recordExit(handle, processID string, exitStatus int, err error) {
+	key := processKey{handle: handle, id: processID}
+
+	s.statusesL.Lock()
+	status, found := s.statuses[key]
+	s.statusesL.Unlock()
+
+	if !found {
+		return
+	}
+
+	status.mu.Lock()
+	status.status.State = "exited"
+	status.status.ExitedAt = time.Now()
+	if err != nil {
+		status.status.Error = err.Error()
+	} else {
+		status.status.ExitStatus = exitStatus
+	}
+	status.mu.Unlock()
+
+	time.AfterFunc(s.statusRetention, func() {
+		s.statusesL.Lock()
+		if s.statuses[key] == status {
+			delete(s.statuses, key)
+		}
+		s.statusesL.Unlock()
+	})
+}
+
+// processStatus returns the recorded status of a detached process.
+func (s *GardenServer) processStatus(handle, processID string) (garden.ProcessStatus, bool) {
+	s.statusesL.Lock()
+	status, found := s.statuses[processKey{handle: handle, id: processID}]
+	s.statusesL.Unlock()
+
+	if !found {
+		return garden.ProcessStatus{}, false
+	}
+
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	recorded := status.status
+	recorded.Stdout = status.stdout.String()
+	recorded.Stderr = status.stderr.String()
+
+	return recorded, true
+}
+
+func (s *GardenServer) handleProcessStatus(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+	processID := r.FormValue(":pid")
+
+	hLog := s.logger.Session("process-status", lager.Data{
+		"handle": handle,
+		"id":     processID,
+	})
+
+	status, found := s.processStatus(handle, processID)
+	if !found {
+		s.writeError(w, garden.ProcessNotFoundError{Handle: handle, ProcessID: processID}, hLog)
+		return
+	}
+
+	hLog.Debug("got", lager.Data{
+		"state": status.State,
+	})
+
+	s.writeResponse(w, status)
+}
+
+// attachRecorded replies to an attach to a detached process that has exited
+// with its recorded exit, as the process may no longer be known to the
+// backend. No output is streamed.
+func (s *GardenServer) attachRecorded(w http.ResponseWriter, status garden.ProcessStatus, logger lager.Logger) {
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		s.writeError(w, err, logger)
+		return
+	}
+
+	defer conn.Close()
+
+	logger.Info("attached-to-recorded-exit", lager.Data{
+		"id": status.ID,
+	})
+
+	transport.WriteMessage(conn, &transport.ProcessPayload{
+		ProcessID:  status.ID,
+		AllSignals: true,
+	})
+
+	payload := &transport.ProcessPayload{
+		ProcessID: status.ID,
+	}
+
+	if status.Error != "" {
+		payload.Error = &status.Error
+	} else {
+		payload.ExitStatus = &status.ExitStatus
+	}
+
+	transport.WriteMessage(conn, payload)
+}
+
+// tailBuffer keeps the last size bytes written to it.
+type tailBuffer struct {
+	mu   sync.Mutex
+	buf  []byte
+	size int
+}
+
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{size: size}
+}
+
+func (b *tailBuffer) Write(d []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(d) >= b.size {
+		b.buf = append(b.buf[:0], d[len(d)-b.size:]...)
+		return len(d), nil
+	}
+
+	if overflow := len(b.buf) + len(d) - b.size; overflow > 0 {
+		b.buf = append(b.buf[:0], b.buf[overflow:]...)
+	}
+
+	b.buf = append(b.buf, d...)
+
+	return len(d), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return string(b.buf)
+}
@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+)
+
+var _ = Describe("Detached processes", func() {
+	var (
+		retention time.Duration
+		output    string
+		exit      chan struct{}
+
+		serverContainer *fakes.FakeContainer
+
+		apiServer *testServer
+		container client.Container
+	)
+
+	BeforeEach(func() {
+		retention = time.Minute
+		output = "hello"
+		exit = make(chan struct{})
+
+		serverContainer = new(fakes.FakeContainer)
+		serverContainer.HandleReturns("some-handle")
+		exit := exit
+		serverContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+			io.Stdout.Write([]byte(output))
+			io.Stderr.Write([]byte("oops"))
+
+			process := new(fakes.FakeProcess)
+			process.IDReturns("some-process")
+			process.WaitStub = func() (int, error) {
+				<-exit
+				return 42, nil
+			}
+
+			return process, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		serverBackend := new(fakes.FakeBackend)
+		serverBackend.LookupReturns(serverContainer, nil)
+		serverBackend.ContainersReturns([]garden.Container{serverContainer}, nil)
+
+		apiServer = newTestServer(0, serverBackend)
+		apiServer.SetProcessStatusRetention(retention)
+
+		container = apiServer.lookup("some-handle")
+	})
+
+	AfterEach(func() {
+		apiServer.cleanup()
+	})
+
+	It("records their status until they exit", func() {
+		_, err := container.RunDetached(garden.ProcessSpec{Path: "batch"}, garden.ProcessIO{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		status, err := container.ProcessStatus("some-process")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(status.ID).Should(Equal("some-process"))
+		Ω(status.State).Should(Equal("running"))
+
+		close(exit)
+
+		Eventually(func() string {
+			status, err = container.ProcessStatus("some-process")
+			Ω(err).ShouldNot(HaveOccurred())
+			return status.State
+		}).Should(Equal("exited"))
+
+		Ω(status.ExitStatus).Should(Equal(42))
+		Ω(status.ExitedAt).ShouldNot(BeZero())
+		Ω(status.Stdout).Should(Equal("hello"))
+		Ω(status.Stderr).Should(Equal("oops"))
+	})
+
+	Context("when the output is long", func() {
+		BeforeEach(func() {
+			output = strings.Repeat("x", 100*1024) + "the end"
+		})
+
+		It("keeps only the end of it", func() {
+			_, err := container.RunDetached(garden.ProcessSpec{Path: "batch"}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			status, err := container.ProcessStatus("some-process")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(status.Stdout).Should(HaveLen(64 * 1024))
+			Ω(status.Stdout).Should(HaveSuffix("the end"))
+
+			close(exit)
+		})
+	})
+
+	It("returns the recorded exit status to later attaches", func() {
+		process, err := container.RunDetached(garden.ProcessSpec{Path: "batch"}, garden.ProcessIO{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		close(exit)
+		Ω(process.Wait()).Should(Equal(42))
+
+		stdout := gbytes.NewBuffer()
+		attached, err := container.Attach("some-process", garden.ProcessIO{
+			Stdout: stdout,
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(attached.Wait()).Should(Equal(42))
+
+		Ω(serverContainer.AttachCallCount()).Should(BeZero())
+	})
+
+	Context("when the retention window has passed", func() {
+		BeforeEach(func() {
+			retention = 100 * time.Millisecond
+		})
+
+		It("forgets them", func() {
+			process, err := container.RunDetached(garden.ProcessSpec{Path: "batch"}, garden.ProcessIO{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			close(exit)
+			Ω(process.Wait()).Should(Equal(42))
+
+			Eventually(func() error {
+				_, err := container.ProcessStatus("some-process")
+				return err
+			}).Should(Equal(garden.ProcessNotFoundError{Handle: "some-handle", ProcessID: "some-process"}))
+		})
+	})
+
+	It("does not record the status of processes that are not detached", func() {
+		process, err := container.Run(garden.ProcessSpec{Path: "batch"}, garden.ProcessIO{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		close(exit)
+		Ω(process.Wait()).Should(Equal(42))
+
+		_, err = container.ProcessStatus("some-process")
+		Ω(err).Should(Equal(garden.ProcessNotFoundError{Handle: "some-handle", ProcessID: "some-process"}))
+	})
+})
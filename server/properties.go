@@ -0,0 +1,184 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/pivotal-golang/lager"
+)
+
+// propertiesVersion serializes the changes to a container's properties made
+// through the server, and tracks their version.
+type propertiesVersion struct {
+	mu      sync.Mutex
+	version string
+}
+
+// propertiesVersionOf returns the version of the container's properties,
+// giving them a new version if the server has not yet seen them.
+func (s *GardenServer) propertiesVersionOf(handle string) *propertiesVersion {
+	s.propertiesVersionsL.Lock()
+	defer s.propertiesVersionsL.Unlock()
+
+	version, found := s.propertiesVersions[handle]
+	if !found {
+		version = &propertiesVersion{version: s.nextPropertiesVersion()}
+		s.propertiesVersions[handle] = version
+	}
+
+	return version
+}
+
+// nextPropertiesVersion returns a version no properties have had since the
+// server started. The versions of different servers differ by their start
+// time, so that a version from before a restart is never current.
+func (s *GardenServer) nextPropertiesVersion() string {
+	return fmt.Sprintf("%d-%d", s.propertiesEpoch, atomic.AddUint64(&s.propertiesSeq, 1))
+}
+
+// forgetPropertiesVersion forgets the version of a destroyed container's
+// properties, so that a container later created with its handle has new
+// versions.
+func (s *GardenServer) forgetPropertiesVersion(handle string) {
+	s.propertiesVersionsL.Lock()
+	delete(s.propertiesVersions, handle)
+	s.propertiesVersionsL.Unlock()
+}
+
+func (s *GardenServer) handleUpdateProperties(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("update-properties", lager.Data{
+		"handle": handle,
+	})
+
+	var request struct {
+		Set             garden.Properties `json:"set"`
+		Remove          []string          `json:"remove"`
+		ExpectedVersion string            `json:"expected_version"`
+	}
+	if !s.readRequest(&request, w, r) {
+		return
+	}
+
+	for _, key := range request.Remove {
+		if _, found := request.Set[key]; found {
+			s.writeError(w, garden.InvalidRequestError{
+				Fields: []garden.FieldError{{Field: "remove", Message: fmt.Sprintf("must not include %q, which is set", key)}},
+			}, hLog)
+			return
+		}
+	}
+
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	s.bomberman.Pause(container.Handle())
+	defer s.bomberman.Unpause(container.Handle())
+
+	version := s.propertiesVersionOf(container.Handle())
+	version.mu.Lock()
+	defer version.mu.Unlock()
+
+	if request.ExpectedVersion != "" && request.ExpectedVersion != version.version {
+		s.writeError(w, garden.NewConflictError(fmt.Sprintf(
+			"properties are at version %s, not the expected %s", version.version, request.ExpectedVersion,
+		)), hLog)
+		return
+	}
+
+	hLog.Debug("updating")
+
+	err = updateProperties(r, container, request.Set, request.Remove)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	version.version = s.nextPropertiesVersion()
+
+	hLog.Info("updated", lager.Data{"version": version.version})
+
+	for _, key := range sortedKeys(request.Set) {
+		value := request.Set[key]
+		s.publishPropertyChanged(container.Handle(), key, &value)
+	}
+
+	for _, key := range request.Remove {
+		s.publishPropertyChanged(container.Handle(), key, nil)
+	}
+
+	s.writeResponse(w, &struct{ Version string }{version.version})
+}
+
+// updateProperties sets and removes the properties atomically if the
+// container is a garden.PropertiesUpdater. Otherwise it sets and removes them
+// one at a time, restoring the previous values if one fails.
+func updateProperties(r *http.Request, container garden.Container, set garden.Properties, remove []string) error {
+	if updater, ok := container.(garden.PropertiesUpdater); ok {
+		return updater.UpdateProperties(set, remove)
+	}
+
+	container = containerFor(r, container)
+
+	previous, err := container.Properties()
+	if err != nil {
+		return err
+	}
+
+	var changed []string
+
+	for _, key := range sortedKeys(set) {
+		changed = append(changed, key)
+
+		if err := container.SetProperty(key, set[key]); err != nil {
+			restoreProperties(container, previous, changed)
+			return err
+		}
+	}
+
+	for _, key := range remove {
+		if _, found := previous[key]; !found {
+			continue
+		}
+
+		changed = append(changed, key)
+
+		if err := container.RemoveProperty(key); err != nil {
+			restoreProperties(container, previous, changed)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreProperties puts back the previous values of the changed properties,
+// as far as the container allows.
+func restoreProperties(container garden.Container, previous garden.Properties, changed []string) {
+	for _, key := range changed {
+		if value, found := previous[key]; found {
+			container.SetProperty(key, value)
+		} else {
+			container.RemoveProperty(key)
+		}
+	}
+}
+
+func sortedKeys(properties garden.Properties) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,239 @@
+package server_test
+
+import (
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+)
+
+type updatingContainer struct {
+	*fakes.FakeContainer
+
+	updatesL sync.Mutex
+	updates  [][]interface{}
+}
+
+func (c *updatingContainer) UpdateProperties(set garden.Properties, remove []string) error {
+	c.updatesL.Lock()
+	defer c.updatesL.Unlock()
+
+	c.updates = append(c.updates, []interface{}{set, remove})
+	return nil
+}
+
+var _ = Describe("Updating properties", func() {
+	var (
+		serverBackend *fakes.FakeBackend
+		apiServer     *testServer
+
+		fakeContainer *fakes.FakeContainer
+		backing       garden.Container
+		container     client.Container
+
+		propertiesL *sync.Mutex
+		properties  garden.Properties
+	)
+
+	BeforeEach(func() {
+		serverBackend = new(fakes.FakeBackend)
+
+		propertiesL = new(sync.Mutex)
+		properties = garden.Properties{"foo": "bar", "baz": "quux"}
+
+		propertiesL := propertiesL
+		props := properties
+
+		fakeContainer = new(fakes.FakeContainer)
+		fakeContainer.HandleReturns("some-handle")
+		fakeContainer.PropertiesStub = func() (garden.Properties, error) {
+			propertiesL.Lock()
+			defer propertiesL.Unlock()
+
+			copied := garden.Properties{}
+			for name, value := range props {
+				copied[name] = value
+			}
+
+			return copied, nil
+		}
+		fakeContainer.SetPropertyStub = func(name, value string) error {
+			propertiesL.Lock()
+			defer propertiesL.Unlock()
+
+			props[name] = value
+			return nil
+		}
+		fakeContainer.RemovePropertyStub = func(name string) error {
+			propertiesL.Lock()
+			defer propertiesL.Unlock()
+
+			delete(props, name)
+			return nil
+		}
+
+		backing = fakeContainer
+	})
+
+	JustBeforeEach(func() {
+		serverBackend.CreateReturns(backing, nil)
+		serverBackend.LookupReturns(backing, nil)
+
+		apiServer = newTestServer(0, serverBackend)
+		apiClient := apiServer.start()
+
+		created, err := apiClient.Create(garden.ContainerSpec{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		container = created.(client.Container)
+	})
+
+	AfterEach(func() {
+		apiServer.cleanup()
+	})
+
+	It("returns the properties with their version", func() {
+		versioned, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(versioned.Properties).Should(Equal(garden.Properties{"foo": "bar", "baz": "quux"}))
+		Ω(versioned.Version).ShouldNot(BeEmpty())
+
+		again, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(again.Version).Should(Equal(versioned.Version))
+	})
+
+	It("sets and removes the properties, returning their new version", func() {
+		versioned, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		version, err := container.UpdateProperties(garden.Properties{"foo": "new", "a": "b"}, []string{"baz"}, versioned.Version)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(version).ShouldNot(Equal(versioned.Version))
+
+		Ω(fakeContainer.Properties()).Should(Equal(garden.Properties{"foo": "new", "a": "b"}))
+
+		updated, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(updated.Version).Should(Equal(version))
+	})
+
+	It("updates the properties regardless of their version when none is expected", func() {
+		_, err := container.UpdateProperties(garden.Properties{"foo": "new"}, nil, "")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(fakeContainer.Properties()).Should(Equal(garden.Properties{"foo": "new", "baz": "quux"}))
+	})
+
+	It("returns a ConflictError, changing nothing, if the version is stale", func() {
+		versioned, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = container.UpdateProperties(garden.Properties{"foo": "first"}, nil, versioned.Version)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = container.UpdateProperties(garden.Properties{"foo": "second"}, []string{"baz"}, versioned.Version)
+		Ω(err).Should(BeAssignableToTypeOf(garden.ConflictError{}))
+
+		Ω(fakeContainer.Properties()).Should(Equal(garden.Properties{"foo": "first", "baz": "quux"}))
+	})
+
+	It("changes the version when a single property is set or removed", func() {
+		versioned, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(container.SetProperty("foo", "new")).Should(Succeed())
+
+		_, err = container.UpdateProperties(garden.Properties{"foo": "newer"}, nil, versioned.Version)
+		Ω(err).Should(BeAssignableToTypeOf(garden.ConflictError{}))
+
+		afterSet, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(container.RemoveProperty("baz")).Should(Succeed())
+
+		afterRemove, err := container.VersionedProperties()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(afterSet.Version).ShouldNot(Equal(versioned.Version))
+		Ω(afterRemove.Version).ShouldNot(Equal(afterSet.Version))
+	})
+
+	It("returns an InvalidRequestError if a property is both set and removed", func() {
+		_, err := container.UpdateProperties(garden.Properties{"foo": "new"}, []string{"foo"}, "")
+		Ω(err).Should(BeAssignableToTypeOf(garden.InvalidRequestError{}))
+		Ω(err.(garden.InvalidRequestError).Fields[0].Field).Should(Equal("remove"))
+
+		Ω(fakeContainer.SetPropertyCallCount()).Should(BeZero())
+	})
+
+	It("returns an error if the container is not found", func() {
+		serverBackend.LookupReturns(nil, errors.New("not found"))
+
+		_, err := container.UpdateProperties(garden.Properties{"foo": "new"}, nil, "")
+		Ω(err).Should(MatchError("not found"))
+	})
+
+	Context("when a change fails", func() {
+		BeforeEach(func() {
+			propertiesL := propertiesL
+			props := properties
+
+			fakeContainer.SetPropertyStub = func(name, value string) error {
+				if name == "b" {
+					return errors.New("oh no!")
+				}
+
+				propertiesL.Lock()
+				defer propertiesL.Unlock()
+
+				props[name] = value
+				return nil
+			}
+		})
+
+		It("restores the previous values and keeps the version", func() {
+			versioned, err := container.VersionedProperties()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = container.UpdateProperties(garden.Properties{"a": "new", "b": "new", "foo": "new"}, nil, versioned.Version)
+			Ω(err).Should(MatchError("oh no!"))
+
+			Ω(fakeContainer.Properties()).Should(Equal(garden.Properties{"foo": "bar", "baz": "quux"}))
+
+			after, err := container.VersionedProperties()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(after.Version).Should(Equal(versioned.Version))
+		})
+	})
+
+	Context("when the container can update its properties atomically", func() {
+		var updater *updatingContainer
+
+		BeforeEach(func() {
+			updater = &updatingContainer{FakeContainer: fakeContainer}
+			backing = updater
+		})
+
+		It("updates them in one change", func() {
+			_, err := container.UpdateProperties(garden.Properties{"foo": "new"}, []string{"baz"}, "")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			updater.updatesL.Lock()
+			defer updater.updatesL.Unlock()
+
+			Ω(updater.updates).Should(Equal([][]interface{}{
+				{garden.Properties{"foo": "new"}, []string{"baz"}},
+			}))
+
+			Ω(fakeContainer.SetPropertyCallCount()).Should(BeZero())
+			Ω(fakeContainer.RemovePropertyCallCount()).Should(BeZero())
+		})
+	})
+})
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/pivotal-golang/lager"
+)
+
+// ReapHook is consulted before a container whose grace time has passed is
+// reaped, e.g. to hold off reaping while a snapshot of it is in progress.
+//
+// A nil error allows the reap. An error returned by DelayReap puts the reap
+// off for the given delay, after which the hook is consulted again. Any other
+// error vetoes the reap: the container's grace time starts again, as though it
+// had just been referenced.
+type ReapHook interface {
+	BeforeReap(container garden.Container) error
+}
+
+// ReapHookFunc adapts a function to a ReapHook.
+type ReapHookFunc func(container garden.Container) error
+
+func (f ReapHookFunc) BeforeReap(container garden.Container) error {
+	return f(container)
+}
+
+// DelayReap returns the error with which a ReapHook puts off a reap by delay.
+func DelayReap(delay time.Duration) error {
+	return reapDelay(delay)
+}
+
+type reapDelay time.Duration
+
+func (d reapDelay) Error() string {
+	return fmt.Sprintf("reap delayed by %s", time.Duration(d))
+}
+
+// SetReapHook installs a ReapHook. It must be called before Start. By default
+// every reap is allowed.
+func (s *GardenServer) SetReapHook(hook ReapHook) {
+	s.reapHook = hook
+}
+
+func (s *GardenServer) reapContainer(container garden.Container) {
+	handle := container.Handle()
+	graceTime := s.backend.GraceTime(container)
+
+	if s.reapHook != nil {
+		err := s.reapHook.BeforeReap(container)
+
+		if delay, ok := err.(reapDelay); ok {
+			s.logger.Info("reap-delayed", lager.Data{
+				"handle": handle,
+				"delay":  time.Duration(delay).String(),
+			})
+
+			// start the countdown such that it ends after the delay
+			s.bomberman.StrapSince(container, time.Now().Add(time.Duration(delay)-graceTime))
+			return
+		}
+
+		if err != nil {
+			s.logger.Info("reap-vetoed", lager.Data{
+				"handle": handle,
+				"reason": err.Error(),
+			})
+
+			s.bomberman.Strap(container)
+			return
+		}
+	}
+
+	s.logger.Info("reaping", lager.Data{
+		"handle":     handle,
+		"grace-time": graceTime.String(),
+	})
+
+	s.recordReap(handle, fmt.Sprintf("reaped after being idle for its grace time of %s", graceTime))
+
+	op, started := s.startDestroy(handle, false, s.logger.Session("reap", lager.Data{
+		"handle": handle,
+	}))
+
+	<-op.done
+
+	if started && op.err == nil {
+		s.recordReapDestroyed(handle)
+
+		s.publish(garden.Event{
+			Kind:   garden.EventContainerReaped,
+			Handle: handle,
+		})
+
+		return
+	}
+
+	// either a client was destroying the container already, or it is still
+	// there; either way it was not reaped
+	s.forgetReap(handle)
+	s.saveGraceTimeState()
+
+	if op.err != nil {
+		s.logger.Error("failed-to-reap", op.err, lager.Data{
+			"handle": handle,
+		})
+
+		// start its grace time again
+		s.bomberman.Strap(container)
+	}
+}
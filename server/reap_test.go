@@ -0,0 +1,204 @@
+package server_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/client"
+	fakes "github.com/cloudfoundry-incubator/garden/gardenfakes"
+	"github.com/cloudfoundry-incubator/garden/server"
+)
+
+var _ = Describe("Reaping", func() {
+	var (
+		serverBackend *fakes.FakeBackend
+		apiServer     *testServer
+		apiClient     garden.Client
+		container     garden.Container
+
+		hook server.ReapHook
+	)
+
+	graceTime := 100 * time.Millisecond
+
+	BeforeEach(func() {
+		container := new(fakes.FakeContainer)
+		container.HandleReturns("some-handle")
+
+		serverBackend = new(fakes.FakeBackend)
+		serverBackend.CreateReturns(container, nil)
+		serverBackend.GraceTimeReturns(graceTime)
+		serverBackend.LookupReturns(nil, garden.ContainerNotFoundError{Handle: "some-handle"})
+
+		hook = nil
+	})
+
+	JustBeforeEach(func() {
+		apiServer = newTestServer(0, serverBackend)
+		if hook != nil {
+			apiServer.SetReapHook(hook)
+		}
+
+		apiClient = apiServer.start()
+
+		var err error
+		container, err = apiClient.Create(garden.ContainerSpec{})
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		apiServer.cleanup()
+	})
+
+	info := func() error {
+		_, err := container.Info()
+		return err
+	}
+
+	reapedError := garden.ContainerNotFoundError{
+		Handle: "some-handle",
+		Reason: "reaped after being idle for its grace time of 100ms",
+	}
+
+	It("explains that the handle of a reaped container is unknown because it was reaped", func() {
+		Eventually(info).Should(Equal(reapedError))
+	})
+
+	It("forgets the reap once a container is created with the handle again", func() {
+		Eventually(info).Should(Equal(reapedError))
+
+		serverBackend.GraceTimeReturns(0)
+
+		_, err := apiClient.Create(garden.ContainerSpec{Handle: "some-handle"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(info()).Should(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+	})
+
+	Context("when the reap fails", func() {
+		BeforeEach(func() {
+			serverBackend.DestroyReturns(errors.New("oh no"))
+		})
+
+		It("does not give a reason for the handle being unknown", func() {
+			Eventually(serverBackend.DestroyCallCount).Should(Equal(1))
+
+			Ω(info()).Should(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+		})
+
+		It("starts the grace time again", func() {
+			Eventually(serverBackend.DestroyCallCount).Should(Equal(2))
+		})
+	})
+
+	Context("when a client destroys the container while it is being reaped", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+
+			release := release
+			serverBackend.DestroyStub = func(string) error {
+				<-release
+				return nil
+			}
+		})
+
+		It("joins the reap", func() {
+			Eventually(serverBackend.DestroyCallCount).Should(Equal(1))
+
+			destroyed := make(chan error, 1)
+			go func() {
+				destroyed <- apiClient.Destroy("some-handle")
+			}()
+
+			Consistently(destroyed).ShouldNot(Receive())
+
+			close(release)
+
+			Eventually(destroyed).Should(Receive(BeNil()))
+			Ω(serverBackend.DestroyCallCount()).Should(Equal(1))
+
+			Ω(info()).Should(Equal(reapedError))
+		})
+	})
+
+	Context("with a reap hook", func() {
+		var consulted *int32
+
+		BeforeEach(func() {
+			// hooks of earlier servers may still be consulted
+			consulted = new(int32)
+		})
+
+		Context("that allows the reap", func() {
+			BeforeEach(func() {
+				consulted := consulted
+				hook = server.ReapHookFunc(func(garden.Container) error {
+					atomic.AddInt32(consulted, 1)
+					return nil
+				})
+			})
+
+			It("consults it before reaping", func() {
+				Eventually(serverBackend.DestroyCallCount).Should(Equal(1))
+				Ω(atomic.LoadInt32(consulted)).Should(Equal(int32(1)))
+			})
+		})
+
+		Context("that vetoes the reap", func() {
+			BeforeEach(func() {
+				consulted := consulted
+				hook = server.ReapHookFunc(func(garden.Container) error {
+					atomic.AddInt32(consulted, 1)
+					return errors.New("do-not-reap is set")
+				})
+			})
+
+			It("starts the grace time again instead of reaping", func() {
+				Eventually(func() int32 { return atomic.LoadInt32(consulted) }).Should(BeNumerically(">=", 2))
+				Ω(serverBackend.DestroyCallCount()).Should(BeZero())
+
+				reaps, err := apiClient.(client.Client).PendingReaps()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(reaps).Should(HaveLen(1))
+			})
+		})
+
+		Context("that delays the reap", func() {
+			delay := 300 * time.Millisecond
+
+			BeforeEach(func() {
+				consulted := consulted
+				hook = server.ReapHookFunc(func(garden.Container) error {
+					if atomic.AddInt32(consulted, 1) == 1 {
+						return server.DelayReap(delay)
+					}
+
+					return nil
+				})
+			})
+
+			It("reaps the container after the delay", func() {
+				Eventually(func() int32 { return atomic.LoadInt32(consulted) }).Should(Equal(int32(1)))
+				delayed := time.Now()
+
+				Eventually(func() time.Time {
+					reaps, err := apiClient.(client.Client).PendingReaps()
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(reaps).Should(HaveLen(1))
+					return reaps[0].ReapAt
+				}).Should(BeTemporally("~", delayed.Add(delay), 100*time.Millisecond))
+
+				Consistently(serverBackend.DestroyCallCount, delay/2).Should(BeZero())
+				Eventually(serverBackend.DestroyCallCount).Should(Equal(1))
+				Ω(atomic.LoadInt32(consulted)).Should(Equal(int32(2)))
+			})
+		})
+	})
+})
@@ -7,9 +7,11 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
+	"github.com/cloudfoundry-incubator/garden/routes"
 	"github.com/cloudfoundry-incubator/garden/transport"
 	"github.com/pivotal-golang/lager"
 )
@@ -32,13 +34,12 @@ type containerDebugInfo struct {
 	Limits     garden.Limits
 }
 
-var ErrInvalidContentType = errors.New("content-type must be application/json")
-var ErrConcurrentDestroy = errors.New("container already being destroyed")
+var ErrInvalidContentType = garden.NewInvalidRequestError("content-type must be application/json")
 
 func (s *GardenServer) handlePing(w http.ResponseWriter, r *http.Request) {
 	hLog := s.logger.Session("ping")
 
-	err := s.backend.Ping()
+	err := s.backendFor(r).Ping()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -50,7 +51,7 @@ func (s *GardenServer) handlePing(w http.ResponseWriter, r *http.Request) {
 func (s *GardenServer) handleCapacity(w http.ResponseWriter, r *http.Request) {
 	hLog := s.logger.Session("capacity")
 
-	capacity, err := s.backend.Capacity()
+	capacity, err := s.backendFor(r).Capacity()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -77,95 +78,152 @@ func (s *GardenServer) handleCreate(w http.ResponseWriter, r *http.Request) {
 		},
 	})
 
+	if err := spec.Validate(); err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
 	if spec.GraceTime == 0 {
 		spec.GraceTime = s.containerGraceTime
 	}
 
-	hLog.Debug("creating")
+	var handle string
+	var err error
+
+	if spec.IdempotencyKey != "" {
+		handle, err = s.createIdempotently(r, spec, hLog)
+	} else {
+		handle, err = s.createContainer(s.backendFor(r), spec, hLog)
+	}
 
-	container, err := s.backend.Create(spec)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
 	}
 
+	s.writeResponse(w, &struct{ Handle string }{
+		Handle: handle,
+	})
+}
+
+func (s *GardenServer) createContainer(backend garden.Client, spec garden.ContainerSpec, hLog lager.Logger) (string, error) {
+	hLog.Debug("creating")
+
+	container, err := backend.Create(spec)
+	if err != nil {
+		return "", err
+	}
+
 	hLog.Info("created")
 
+	s.forgetReap(container.Handle())
 	s.bomberman.Strap(container)
 
-	s.writeResponse(w, &struct{ Handle string }{
+	s.publish(garden.Event{
+		Kind:   garden.EventContainerCreated,
 		Handle: container.Handle(),
 	})
+
+	return container.Handle(), nil
 }
 
 func (s *GardenServer) handleList(w http.ResponseWriter, r *http.Request) {
-	properties := garden.Properties{}
-	for name, vals := range r.URL.Query() {
-		if len(vals) > 0 {
-			properties[name] = vals[0]
-		}
-	}
-
 	hLog := s.logger.Session("list")
 	hLog.Debug("started")
 
-	containers, err := s.backend.Containers(properties)
+	options, err := listOptions(r.URL.Query())
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
 	}
 
-	handles := []string{}
+	var containers []garden.Container
+	if len(options.Selector) > 0 {
+		containers, err = s.containersMatching(r, append(garden.SelectorFromProperties(options.Properties), options.Selector...))
+	} else {
+		containers, err = s.backendFor(r).Containers(options.Properties)
+	}
 
-	for _, container := range containers {
-		handles = append(handles, container.Handle())
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
 	}
 
-	hLog.Debug("ending", lager.Data{"handles": handles})
+	page, err := s.listPage(r, containers, options)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
 
-	s.writeResponse(w, &struct{ Handles []string }{handles})
+	hLog.Debug("ending", lager.Data{"handles": page.Handles})
+
+	s.writeResponse(w, page)
 }
 
-func (s *GardenServer) handleDestroy(w http.ResponseWriter, r *http.Request) {
-	handle := r.FormValue(":handle")
+// containersMatching selects containers with the backend if it is a
+// garden.SelectorBackend, bound to the request's context if it is a
+// garden.ContextSelectorBackend. Otherwise it lists the containers with the
+// properties the selector requires to equal a value, and filters them by the
+// rest of the selector.
+func (s *GardenServer) containersMatching(r *http.Request, selector garden.Selector) ([]garden.Container, error) {
+	if backend, ok := s.backend.(garden.ContextSelectorBackend); ok {
+		return backend.ContainersMatchingContext(r.Context(), selector)
+	}
 
-	hLog := s.logger.Session("destroy", lager.Data{
-		"handle": handle,
-	})
+	if backend, ok := s.backend.(garden.SelectorBackend); ok {
+		return backend.ContainersMatching(selector)
+	}
 
-	s.destroysL.Lock()
+	equalities := selector.Equalities()
 
-	_, alreadyDestroying := s.destroys[handle]
-	if !alreadyDestroying {
-		s.destroys[handle] = struct{}{}
+	candidates, err := s.backendFor(r).Containers(equalities)
+	if err != nil || len(equalities) == len(selector) {
+		return candidates, err
 	}
 
-	s.destroysL.Unlock()
+	containers := []garden.Container{}
+	for _, container := range candidates {
+		properties, err := containerFor(r, container).Properties()
+		if err != nil {
+			// destroyed since it was listed
+			if _, ok := err.(garden.ContainerNotFoundError); ok {
+				continue
+			}
 
-	if alreadyDestroying {
-		s.writeError(w, ErrConcurrentDestroy, hLog)
-		return
+			return nil, err
+		}
+
+		if selector.Matches(properties) {
+			containers = append(containers, container)
+		}
 	}
 
-	hLog.Debug("destroying")
+	return containers, nil
+}
 
-	err := s.backend.Destroy(handle)
+func (s *GardenServer) handleDestroy(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
 
-	if !alreadyDestroying {
-		s.destroysL.Lock()
-		delete(s.destroys, handle)
-		s.destroysL.Unlock()
+	hLog := s.logger.Session("destroy", lager.Data{
+		"handle": handle,
+	})
+
+	if r.URL.Query().Get("async") == "true" {
+		// the destroy outlives the request, so it cannot be bound to it
+		s.destroy(handle, hLog)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		transport.WriteMessage(w, &struct{}{})
+		return
 	}
 
+	err := s.destroyContainer(r, handle, hLog)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
 	}
 
-	hLog.Info("destroyed")
-
-	s.bomberman.Defuse(handle)
-
 	s.writeSuccess(w)
 }
 
@@ -183,26 +241,41 @@ func (s *GardenServer) handleStop(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	container, err := s.backend.Lookup(handle)
+	err := s.stopContainer(r, handle, request.Kill, hLog)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
 	}
 
+	s.writeSuccess(w)
+}
+
+// stopContainer stops the container with the handle, holding off its grace
+// time while it stops.
+func (s *GardenServer) stopContainer(r *http.Request, handle string, kill bool, hLog lager.Logger) error {
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		return err
+	}
+
 	s.bomberman.Pause(container.Handle())
 	defer s.bomberman.Unpause(container.Handle())
 
 	hLog.Debug("stopping")
 
-	err = container.Stop(request.Kill)
+	err = containerFor(r, container).Stop(kill)
 	if err != nil {
-		s.writeError(w, err, hLog)
-		return
+		return err
 	}
 
 	hLog.Info("stopped")
 
-	s.writeSuccess(w)
+	s.publish(garden.Event{
+		Kind:   garden.EventContainerStopped,
+		Handle: container.Handle(),
+	})
+
+	return nil
 }
 
 func (s *GardenServer) handleStreamIn(w http.ResponseWriter, r *http.Request) {
@@ -217,7 +290,7 @@ func (s *GardenServer) handleStreamIn(w http.ResponseWriter, r *http.Request) {
 		"destination": dstPath,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -228,7 +301,7 @@ func (s *GardenServer) handleStreamIn(w http.ResponseWriter, r *http.Request) {
 
 	hLog.Debug("streaming-in")
 
-	err = container.StreamIn(garden.StreamInSpec{
+	err = containerFor(r, container).StreamIn(garden.StreamInSpec{
 		User:      user,
 		Path:      dstPath,
 		TarStream: r.Body,
@@ -259,7 +332,7 @@ func (s *GardenServer) handleStreamOut(w http.ResponseWriter, r *http.Request) {
 		"source": srcPath,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -270,7 +343,7 @@ func (s *GardenServer) handleStreamOut(w http.ResponseWriter, r *http.Request) {
 
 	hLog.Debug("streaming-out")
 
-	reader, err := container.StreamOut(garden.StreamOutSpec{
+	reader, err := containerFor(r, container).StreamOut(garden.StreamOutSpec{
 		User: user,
 		Path: srcPath,
 	})
@@ -302,7 +375,7 @@ func (s *GardenServer) handleCurrentBandwidthLimits(w http.ResponseWriter, r *ht
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -313,7 +386,7 @@ func (s *GardenServer) handleCurrentBandwidthLimits(w http.ResponseWriter, r *ht
 
 	hLog.Debug("getting")
 
-	limits, err := container.CurrentBandwidthLimits()
+	limits, err := containerFor(r, container).CurrentBandwidthLimits()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -333,7 +406,7 @@ func (s *GardenServer) handleCurrentMemoryLimits(w http.ResponseWriter, r *http.
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -344,7 +417,7 @@ func (s *GardenServer) handleCurrentMemoryLimits(w http.ResponseWriter, r *http.
 
 	hLog.Debug("getting")
 
-	limits, err := container.CurrentMemoryLimits()
+	limits, err := containerFor(r, container).CurrentMemoryLimits()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -364,7 +437,7 @@ func (s *GardenServer) handleCurrentDiskLimits(w http.ResponseWriter, r *http.Re
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -375,7 +448,7 @@ func (s *GardenServer) handleCurrentDiskLimits(w http.ResponseWriter, r *http.Re
 
 	hLog.Debug("getting")
 
-	limits, err := container.CurrentDiskLimits()
+	limits, err := containerFor(r, container).CurrentDiskLimits()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -395,7 +468,7 @@ func (s *GardenServer) handleCurrentCPULimits(w http.ResponseWriter, r *http.Req
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -406,7 +479,7 @@ func (s *GardenServer) handleCurrentCPULimits(w http.ResponseWriter, r *http.Req
 
 	hLog.Debug("getting")
 
-	limits, err := container.CurrentCPULimits()
+	limits, err := containerFor(r, container).CurrentCPULimits()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -419,6 +492,163 @@ func (s *GardenServer) handleCurrentCPULimits(w http.ResponseWriter, r *http.Req
 	s.writeResponse(w, limits)
 }
 
+func (s *GardenServer) handleSetBandwidthLimits(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("set-bandwidth-limits", lager.Data{
+		"handle": handle,
+	})
+
+	var limits garden.BandwidthLimits
+	if !s.readRequest(&limits, w, r) {
+		return
+	}
+
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	s.bomberman.Pause(container.Handle())
+	defer s.bomberman.Unpause(container.Handle())
+
+	hLog.Debug("setting", lager.Data{
+		"limits": limits,
+	})
+
+	err = containerFor(r, container).SetBandwidthLimits(limits)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	hLog.Info("set", lager.Data{
+		"limits": limits,
+	})
+
+	s.writeSuccess(w)
+}
+
+func (s *GardenServer) handleSetCPULimits(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("set-cpu-limits", lager.Data{
+		"handle": handle,
+	})
+
+	var limits garden.CPULimits
+	if !s.readRequest(&limits, w, r) {
+		return
+	}
+
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	s.bomberman.Pause(container.Handle())
+	defer s.bomberman.Unpause(container.Handle())
+
+	hLog.Debug("setting", lager.Data{
+		"limits": limits,
+	})
+
+	err = containerFor(r, container).SetCPULimits(limits)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	hLog.Info("set", lager.Data{
+		"limits": limits,
+	})
+
+	s.writeSuccess(w)
+}
+
+func (s *GardenServer) handleSetDiskLimits(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("set-disk-limits", lager.Data{
+		"handle": handle,
+	})
+
+	var limits garden.DiskLimits
+	if !s.readRequest(&limits, w, r) {
+		return
+	}
+
+	if err := limits.Validate(); err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	s.bomberman.Pause(container.Handle())
+	defer s.bomberman.Unpause(container.Handle())
+
+	hLog.Debug("setting", lager.Data{
+		"limits": limits,
+	})
+
+	err = containerFor(r, container).SetDiskLimits(limits)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	hLog.Info("set", lager.Data{
+		"limits": limits,
+	})
+
+	s.writeSuccess(w)
+}
+
+func (s *GardenServer) handleSetMemoryLimits(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("set-memory-limits", lager.Data{
+		"handle": handle,
+	})
+
+	var limits garden.MemoryLimits
+	if !s.readRequest(&limits, w, r) {
+		return
+	}
+
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	s.bomberman.Pause(container.Handle())
+	defer s.bomberman.Unpause(container.Handle())
+
+	hLog.Debug("setting", lager.Data{
+		"limits": limits,
+	})
+
+	err = containerFor(r, container).SetMemoryLimits(limits)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	hLog.Info("set", lager.Data{
+		"limits": limits,
+	})
+
+	s.writeSuccess(w)
+}
+
 func (s *GardenServer) handleNetIn(w http.ResponseWriter, r *http.Request) {
 	handle := r.FormValue(":handle")
 
@@ -434,7 +664,7 @@ func (s *GardenServer) handleNetIn(w http.ResponseWriter, r *http.Request) {
 	hostPort := request.HostPort
 	containerPort := request.ContainerPort
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -448,7 +678,7 @@ func (s *GardenServer) handleNetIn(w http.ResponseWriter, r *http.Request) {
 		"container-port": containerPort,
 	})
 
-	hostPort, containerPort, err = container.NetIn(hostPort, containerPort)
+	hostPort, containerPort, err = containerFor(r, container).NetIn(hostPort, containerPort)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -477,7 +707,7 @@ func (s *GardenServer) handleNetOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -490,7 +720,7 @@ func (s *GardenServer) handleNetOut(w http.ResponseWriter, r *http.Request) {
 		"rule": rule,
 	})
 
-	err = container.NetOut(rule)
+	err = containerFor(r, container).NetOut(rule)
 
 	if err != nil {
 		s.writeError(w, err, hLog)
@@ -511,7 +741,7 @@ func (s *GardenServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -520,7 +750,7 @@ func (s *GardenServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	s.bomberman.Pause(container.Handle())
 	defer s.bomberman.Unpause(container.Handle())
 
-	metrics, err := container.Metrics()
+	metrics, err := containerFor(r, container).Metrics()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -536,7 +766,7 @@ func (s *GardenServer) handleProperties(w http.ResponseWriter, r *http.Request)
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -545,7 +775,12 @@ func (s *GardenServer) handleProperties(w http.ResponseWriter, r *http.Request)
 	s.bomberman.Pause(container.Handle())
 	defer s.bomberman.Unpause(container.Handle())
 
-	properties, err := container.Properties()
+	// hold off changes, so that the properties are those of the version
+	version := s.propertiesVersionOf(container.Handle())
+	version.mu.Lock()
+	defer version.mu.Unlock()
+
+	properties, err := containerFor(r, container).Properties()
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -553,6 +788,14 @@ func (s *GardenServer) handleProperties(w http.ResponseWriter, r *http.Request)
 
 	hLog.Info("got-properties")
 
+	if r.URL.Query().Get("versioned") == "true" {
+		s.writeResponse(w, garden.VersionedProperties{
+			Properties: properties,
+			Version:    version.version,
+		})
+		return
+	}
+
 	s.writeResponse(w, properties)
 }
 
@@ -564,7 +807,7 @@ func (s *GardenServer) handleProperty(w http.ResponseWriter, r *http.Request) {
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -575,7 +818,7 @@ func (s *GardenServer) handleProperty(w http.ResponseWriter, r *http.Request) {
 
 	hLog.Debug("get-property", lager.Data{})
 
-	value, err := container.Property(key)
+	value, err := containerFor(r, container).Property(key)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -605,7 +848,7 @@ func (s *GardenServer) handleSetProperty(w http.ResponseWriter, r *http.Request)
 
 	value := request.Value
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -614,16 +857,24 @@ func (s *GardenServer) handleSetProperty(w http.ResponseWriter, r *http.Request)
 	s.bomberman.Pause(container.Handle())
 	defer s.bomberman.Unpause(container.Handle())
 
+	version := s.propertiesVersionOf(container.Handle())
+	version.mu.Lock()
+	defer version.mu.Unlock()
+
 	hLog.Debug("set-property", lager.Data{})
 
-	err = container.SetProperty(key, value)
+	err = containerFor(r, container).SetProperty(key, value)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
 	}
 
+	version.version = s.nextPropertiesVersion()
+
 	hLog.Debug("set-property-complete", lager.Data{})
 
+	s.publishPropertyChanged(container.Handle(), key, &value)
+
 	s.writeSuccess(w)
 }
 
@@ -635,7 +886,7 @@ func (s *GardenServer) handleRemoveProperty(w http.ResponseWriter, r *http.Reque
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -644,16 +895,24 @@ func (s *GardenServer) handleRemoveProperty(w http.ResponseWriter, r *http.Reque
 	s.bomberman.Pause(container.Handle())
 	defer s.bomberman.Unpause(container.Handle())
 
+	version := s.propertiesVersionOf(container.Handle())
+	version.mu.Lock()
+	defer version.mu.Unlock()
+
 	hLog.Debug("remove-property", lager.Data{})
 
-	err = container.RemoveProperty(key)
+	err = containerFor(r, container).RemoveProperty(key)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
 	}
 
+	version.version = s.nextPropertiesVersion()
+
 	hLog.Info("removed-property", lager.Data{})
 
+	s.publishPropertyChanged(container.Handle(), key, nil)
+
 	s.writeSuccess(w)
 }
 
@@ -669,13 +928,13 @@ func (s *GardenServer) handleSetGraceTime(w http.ResponseWriter, r *http.Request
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
 	}
 
-	container.SetGraceTime(graceTime)
+	containerFor(r, container).SetGraceTime(graceTime)
 
 	s.bomberman.Defuse(container.Handle())
 	s.bomberman.Strap(container)
@@ -683,6 +942,24 @@ func (s *GardenServer) handleSetGraceTime(w http.ResponseWriter, r *http.Request
 	s.writeSuccess(w)
 }
 
+func (s *GardenServer) handlePendingReaps(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("pending-reaps")
+
+	reaps := []garden.PendingReap{}
+	for _, reap := range s.bomberman.PendingReaps() {
+		// only describe containers whose info the caller may get
+		if s.authorize(routes.Info, reap.Handle, r) == nil {
+			reaps = append(reaps, reap)
+		}
+	}
+
+	hLog.Debug("got", lager.Data{
+		"count": len(reaps),
+	})
+
+	s.writeResponse(w, reaps)
+}
+
 func (s *GardenServer) handleRun(w http.ResponseWriter, r *http.Request) {
 	handle := r.FormValue(":handle")
 
@@ -695,6 +972,11 @@ func (s *GardenServer) handleRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := request.Validate(); err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
 	info := processDebugInfo{
 		Path:   request.Path,
 		Dir:    request.Dir,
@@ -703,7 +985,7 @@ func (s *GardenServer) handleRun(w http.ResponseWriter, r *http.Request) {
 		TTY:    request.TTY,
 	}
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -721,14 +1003,23 @@ func (s *GardenServer) handleRun(w http.ResponseWriter, r *http.Request) {
 
 	stdinR, stdinW := io.Pipe()
 
+	output := s.newProcessOutput(stdout, stderr)
+
+	// the status of detached processes is kept for clients that go away
+	detached := r.FormValue("detached") == "true"
+	if detached {
+		output.keepTail(processStatusTailSize)
+	}
+
 	processIO := garden.ProcessIO{
 		Stdin:  stdinR,
-		Stdout: &chanWriter{stdout},
-		Stderr: &chanWriter{stderr},
+		Stdout: output.stdout,
+		Stderr: output.stderr,
 	}
 
-	process, err := container.Run(request, processIO)
+	process, err := containerFor(r, container).Run(request, processIO)
 	if err != nil {
+		output.close()
 		s.writeError(w, err, hLog)
 		return
 	}
@@ -737,8 +1028,17 @@ func (s *GardenServer) handleRun(w http.ResponseWriter, r *http.Request) {
 		"id":   process.ID(),
 	})
 
-	streamID := s.streamer.Stream(stdout, stderr)
-	defer s.streamer.Stop(streamID)
+	s.publish(garden.Event{
+		Kind:      garden.EventProcessSpawned,
+		Handle:    container.Handle(),
+		ProcessID: process.ID(),
+	})
+
+	if detached {
+		s.recordStatus(container.Handle(), process.ID(), output)
+	}
+
+	s.captureOutput(container.Handle(), process, output)
 
 	w.WriteHeader(http.StatusCreated)
 	w.Header().Set("Content-Type", "application/json")
@@ -752,16 +1052,22 @@ func (s *GardenServer) handleRun(w http.ResponseWriter, r *http.Request) {
 
 	defer conn.Close()
 
-	transport.WriteMessage(conn, &transport.ProcessPayload{
-		ProcessID: process.ID(),
-		StreamID:  string(streamID),
-	})
+	atomic.AddInt32(&output.attached, 1)
+	defer atomic.AddInt32(&output.attached, -1)
+
+	s.writeStreamPayload(conn, process, output)
 
 	connCloseCh := make(chan struct{}, 1)
 
 	go s.streamInput(json.NewDecoder(br), stdinW, process, connCloseCh)
 
-	s.streamProcess(hLog, conn, process, stdinW, connCloseCh)
+	s.streamProcess(hLog, conn, process, output, stdinW, connCloseCh, func(status int, err error) {
+		if detached {
+			s.recordExit(container.Handle(), process.ID(), status, err)
+		}
+
+		s.processExited(container, process.ID(), status, err)
+	})
 }
 
 func (s *GardenServer) handleAttach(w http.ResponseWriter, r *http.Request) {
@@ -773,7 +1079,12 @@ func (s *GardenServer) handleAttach(w http.ResponseWriter, r *http.Request) {
 
 	processID := r.FormValue(":pid")
 
-	container, err := s.backend.Lookup(handle)
+	if status, found := s.processStatus(handle, processID); found && status.State == "exited" {
+		s.attachRecorded(w, status, hLog)
+		return
+	}
+
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -782,23 +1093,32 @@ func (s *GardenServer) handleAttach(w http.ResponseWriter, r *http.Request) {
 	s.bomberman.Pause(container.Handle())
 	defer s.bomberman.Unpause(container.Handle())
 
-	stdout := make(chan []byte, 1000)
-	stderr := make(chan []byte, 1000)
-
 	stdinR, stdinW := io.Pipe()
 
 	processIO := garden.ProcessIO{
-		Stdin:  stdinR,
-		Stdout: &chanWriter{stdout},
-		Stderr: &chanWriter{stderr},
+		Stdin: stdinR,
+	}
+
+	// the output of processes already streaming is attached to as it is
+	output, capturing := s.capturedOutput(container.Handle(), processID)
+	if !capturing {
+		output = s.newProcessOutput(make(chan []byte, 1000), make(chan []byte, 1000))
+
+		processIO.Stdout = output.stdout
+		processIO.Stderr = output.stderr
 	}
 
 	hLog.Debug("attaching", lager.Data{
-		"id": processID,
+		"id":        processID,
+		"capturing": capturing,
 	})
 
-	process, err := container.Attach(processID, processIO)
+	process, err := containerFor(r, container).Attach(processID, processIO)
 	if err != nil {
+		if !capturing {
+			output.close()
+		}
+
 		s.writeError(w, err, hLog)
 		stdinW.Close()
 		return
@@ -808,8 +1128,9 @@ func (s *GardenServer) handleAttach(w http.ResponseWriter, r *http.Request) {
 		"id": process.ID(),
 	})
 
-	streamID := s.streamer.Stream(stdout, stderr)
-	defer s.streamer.Stop(streamID)
+	if !capturing {
+		s.captureOutput(container.Handle(), process, output)
+	}
 
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
@@ -823,26 +1144,26 @@ func (s *GardenServer) handleAttach(w http.ResponseWriter, r *http.Request) {
 
 	defer conn.Close()
 
-	transport.WriteMessage(conn, &transport.ProcessPayload{
-		ProcessID: process.ID(),
-		StreamID:  string(streamID),
-	})
+	atomic.AddInt32(&output.attached, 1)
+	defer atomic.AddInt32(&output.attached, -1)
+
+	s.writeStreamPayload(conn, process, output)
 
 	connCloseCh := make(chan struct{}, 1)
 
 	go s.streamInput(json.NewDecoder(br), stdinW, process, connCloseCh)
 
-	s.streamProcess(hLog, conn, process, stdinW, connCloseCh)
+	s.streamProcess(hLog, conn, process, output, stdinW, connCloseCh, nil)
 }
 
-func (s *GardenServer) handleInfo(w http.ResponseWriter, r *http.Request) {
+func (s *GardenServer) handleProcesses(w http.ResponseWriter, r *http.Request) {
 	handle := r.FormValue(":handle")
 
-	hLog := s.logger.Session("info", lager.Data{
+	hLog := s.logger.Session("processes", lager.Data{
 		"handle": handle,
 	})
 
-	container, err := s.backend.Lookup(handle)
+	container, err := s.backendFor(r).Lookup(handle)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -851,9 +1172,73 @@ func (s *GardenServer) handleInfo(w http.ResponseWriter, r *http.Request) {
 	s.bomberman.Pause(container.Handle())
 	defer s.bomberman.Unpause(container.Handle())
 
+	hLog.Debug("getting")
+
+	processes, err := containerFor(r, container).Processes()
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	for i := range processes {
+		processes[i].AttachedClients = s.attachedClients(container.Handle(), processes[i].ID)
+	}
+
+	hLog.Info("got", lager.Data{
+		"count": len(processes),
+	})
+
+	s.writeResponse(w, processes)
+}
+
+func (s *GardenServer) handleProcess(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+	processID := r.FormValue(":pid")
+
+	hLog := s.logger.Session("process", lager.Data{
+		"handle": handle,
+		"id":     processID,
+	})
+
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	s.bomberman.Pause(container.Handle())
+	defer s.bomberman.Unpause(container.Handle())
+
+	hLog.Debug("getting")
+
+	process, err := containerFor(r, container).Process(processID)
+	if err != nil {
+		s.writeError(w, err, hLog)
+		return
+	}
+
+	process.AttachedClients = s.attachedClients(container.Handle(), process.ID)
+
+	hLog.Info("got")
+
+	s.writeResponse(w, process)
+}
+
+func (s *GardenServer) handleInfo(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("info", lager.Data{
+		"handle": handle,
+	})
+
 	hLog.Debug("getting-info")
 
-	info, err := container.Info()
+	info, err := s.containerInfo(r, handle)
+	if s.destroying(handle) {
+		// the backend may no longer describe a container it is tearing down
+		info, err = destroyingInfo(info, err != nil), nil
+	}
+
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -861,9 +1246,23 @@ func (s *GardenServer) handleInfo(w http.ResponseWriter, r *http.Request) {
 
 	hLog.Info("got-info")
 
+	s.observeContainerEvents(handle, info.Events)
+
 	s.writeResponse(w, info)
 }
 
+func (s *GardenServer) containerInfo(r *http.Request, handle string) (garden.ContainerInfo, error) {
+	container, err := s.backendFor(r).Lookup(handle)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	s.bomberman.Pause(container.Handle())
+	defer s.bomberman.Unpause(container.Handle())
+
+	return containerFor(r, container).Info()
+}
+
 func (s *GardenServer) handleBulkInfo(w http.ResponseWriter, r *http.Request) {
 	handles := splitHandles(r.URL.Query()["handles"][0])
 
@@ -872,7 +1271,7 @@ func (s *GardenServer) handleBulkInfo(w http.ResponseWriter, r *http.Request) {
 	})
 	hLog.Debug("getting-bulkinfo")
 
-	bulkInfo, err := s.backend.BulkInfo(handles)
+	bulkInfo, err := s.backendFor(r).BulkInfo(handles)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -880,6 +1279,14 @@ func (s *GardenServer) handleBulkInfo(w http.ResponseWriter, r *http.Request) {
 
 	hLog.Info("got-bulkinfo")
 
+	s.markDestroying(bulkInfo)
+
+	for handle, entry := range bulkInfo {
+		if entry.Err == nil {
+			s.observeContainerEvents(handle, entry.Info.Events)
+		}
+	}
+
 	s.writeResponse(w, bulkInfo)
 }
 
@@ -891,7 +1298,7 @@ func (s *GardenServer) handleBulkMetrics(w http.ResponseWriter, r *http.Request)
 	})
 	hLog.Debug("getting-bulkmetrics")
 
-	bulkMetrics, err := s.backend.BulkMetrics(handles)
+	bulkMetrics, err := s.backendFor(r).BulkMetrics(handles)
 	if err != nil {
 		s.writeError(w, err, hLog)
 		return
@@ -903,8 +1310,12 @@ func (s *GardenServer) handleBulkMetrics(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *GardenServer) writeError(w http.ResponseWriter, err error, logger lager.Logger) {
+	err = s.explainError(err)
+
 	logger.Error("failed", err)
 
+	s.metrics.observeError(err)
+
 	w.Header().Set("Content-Type", "application/json")
 	merr := &garden.Error{Err: err}
 
@@ -912,6 +1323,17 @@ func (s *GardenServer) writeError(w http.ResponseWriter, err error, logger lager
 	json.NewEncoder(w).Encode(merr)
 }
 
+// explainError adds why a container's handle is unknown to a
+// garden.ContainerNotFoundError, if the server reaped it.
+func (s *GardenServer) explainError(err error) error {
+	if notFound, ok := err.(garden.ContainerNotFoundError); ok && notFound.Reason == "" {
+		notFound.Reason = s.reapReason(notFound.Handle)
+		return notFound
+	}
+
+	return err
+}
+
 func (s *GardenServer) writeResponse(w http.ResponseWriter, msg interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	transport.WriteMessage(w, msg)
@@ -925,7 +1347,7 @@ func (s *GardenServer) readRequest(msg interface{}, w http.ResponseWriter, r *ht
 
 	err := json.NewDecoder(r.Body).Decode(msg)
 	if err != nil {
-		s.writeError(w, err, s.logger)
+		s.writeError(w, garden.NewInvalidRequestError("malformed request body: "+err.Error()), s.logger)
 		return false
 	}
 
@@ -960,23 +1382,17 @@ func (s *GardenServer) streamInput(decoder *json.Decoder, in *io.PipeWriter, pro
 		case payload.Signal != nil:
 			s.logger.Info("stream-input-process-signal", lager.Data{"payload": payload})
 
-			switch *payload.Signal {
-			case garden.SignalKill:
-				err = process.Signal(garden.SignalKill)
-				if err != nil {
-					s.logger.Error("stream-input-process-signal-kill-failed", err, lager.Data{"payload": payload})
-				}
-			case garden.SignalTerminate:
-				err = process.Signal(garden.SignalTerminate)
-				if err != nil {
-					s.logger.Error("stream-input-process-signal-terminate-failed", err, lager.Data{"payload": payload})
-				}
-			default:
+			if !payload.Signal.Valid() {
 				s.logger.Error("stream-input-unknown-process-payload-signal", nil, lager.Data{"payload": payload})
 				in.Close()
 				return
 			}
 
+			err = process.Signal(*payload.Signal)
+			if err != nil {
+				s.logger.Error("stream-input-process-signal-failed", err, lager.Data{"payload": payload})
+			}
+
 		default:
 			s.logger.Error("stream-input-unknown-process-payload", nil, lager.Data{"payload": payload})
 			in.Close()
@@ -985,7 +1401,10 @@ func (s *GardenServer) streamInput(decoder *json.Decoder, in *io.PipeWriter, pro
 	}
 }
 
-func (s *GardenServer) streamProcess(logger lager.Logger, conn net.Conn, process garden.Process, stdinPipe *io.PipeWriter, connCloseCh chan struct{}) {
+// streamProcess reports the exit of the process to the connection. If exited
+// is not nil it is called once the process exits, even if the connection is
+// closed first.
+func (s *GardenServer) streamProcess(logger lager.Logger, conn net.Conn, process garden.Process, output *processOutput, stdinPipe *io.PipeWriter, connCloseCh chan struct{}, exited func(int, error)) {
 	statusCh := make(chan int, 1)
 	errCh := make(chan error, 1)
 
@@ -1005,25 +1424,35 @@ func (s *GardenServer) streamProcess(logger lager.Logger, conn net.Conn, process
 
 			statusCh <- status
 		}
+
+		if exited != nil {
+			exited(status, err)
+		}
 	}()
 
 	for {
 		select {
 
 		case status := <-statusCh:
+			output.waitFlushed(connCloseCh, s.stopping)
+
 			transport.WriteMessage(conn, &transport.ProcessPayload{
-				ProcessID:  process.ID(),
-				ExitStatus: &status,
+				ProcessID:    process.ID(),
+				ExitStatus:   &status,
+				DroppedBytes: output.droppedBytes(),
 			})
 
 			stdinPipe.Close()
 			return
 
 		case err := <-errCh:
+			output.waitFlushed(connCloseCh, s.stopping)
+
 			e := err.Error()
 			transport.WriteMessage(conn, &transport.ProcessPayload{
-				ProcessID: process.ID(),
-				Error:     &e,
+				ProcessID:    process.ID(),
+				Error:        &e,
+				DroppedBytes: output.droppedBytes(),
 			})
 
 			stdinPipe.Close()
@@ -1043,6 +1472,20 @@ func (s *GardenServer) streamProcess(logger lager.Logger, conn net.Conn, process
 	}
 }
 
+// writeStreamPayload tells the client where to stream the process's output
+// from.
+func (s *GardenServer) writeStreamPayload(conn net.Conn, process garden.Process, output *processOutput) {
+	stdoutOffset, stderrOffset := s.streamer.Offsets(output.streamID)
+
+	transport.WriteMessage(conn, &transport.ProcessPayload{
+		ProcessID:    process.ID(),
+		StreamID:     string(output.streamID),
+		StdoutOffset: uint64(stdoutOffset),
+		StderrOffset: uint64(stderrOffset),
+		AllSignals:   true,
+	})
+}
+
 func splitHandles(queryHandles string) []string {
 	handles := []string{}
 	if queryHandles != "" {
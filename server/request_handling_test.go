@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -195,7 +196,7 @@ var _ = Describe("When a client connects", func() {
 			_, err := apiClient.Create(garden.ContainerSpec{
 				Handle:     "some-handle",
 				GraceTime:  42 * time.Second,
-				Network:    "some-network",
+				Network:    "10.0.0.0/30",
 				RootFSPath: "/path/to/rootfs",
 				BindMounts: []garden.BindMount{
 					{
@@ -235,7 +236,7 @@ var _ = Describe("When a client connects", func() {
 			Ω(serverBackend.CreateArgsForCall(0)).Should(Equal(garden.ContainerSpec{
 				Handle:     "some-handle",
 				GraceTime:  time.Duration(42 * time.Second),
-				Network:    "some-network",
+				Network:    "10.0.0.0/30",
 				RootFSPath: "/path/to/rootfs",
 				BindMounts: []garden.BindMount{
 					{
@@ -362,6 +363,19 @@ var _ = Describe("When a client connects", func() {
 			})
 		})
 
+		Context("when the spec is invalid", func() {
+			It("rejects it without creating the container", func() {
+				_, err := apiClient.Create(garden.ContainerSpec{
+					Handle:  "some-handle",
+					Network: "not-a-network",
+				})
+				Ω(err).Should(BeAssignableToTypeOf(garden.InvalidRequestError{}))
+				Ω(err.(garden.InvalidRequestError).Fields[0].Field).Should(Equal("network"))
+
+				Ω(serverBackend.CreateCallCount()).Should(BeZero())
+			})
+		})
+
 		Context("when creating the container fails with a ServiceUnavailableError", func() {
 			var err error
 
@@ -394,29 +408,124 @@ var _ = Describe("When a client connects", func() {
 
 		Context("concurrent with other destroy requests", func() {
 			var destroying chan struct{}
+			var finish chan error
 
 			BeforeEach(func() {
 				destroying = make(chan struct{})
+				finish = make(chan error, 1)
 
+				destroying, finish := destroying, finish
 				serverBackend.DestroyStub = func(string) error {
 					close(destroying)
-					time.Sleep(time.Second)
-					return nil
+					return <-finish
 				}
 			})
 
-			It("only destroys once", func() {
+			It("joins the destroy in flight, only destroying once", func() {
 				go apiClient.Destroy("some-handle")
 
 				<-destroying
 
-				err := apiClient.Destroy("some-handle")
-				Ω(err).Should(HaveOccurred())
+				joined := make(chan error, 1)
+				go func() {
+					joined <- apiClient.Destroy("some-handle")
+				}()
+
+				Consistently(joined).ShouldNot(Receive())
+
+				finish <- errors.New("o no")
+				Eventually(joined).Should(Receive(MatchError("o no")))
 
 				Ω(serverBackend.DestroyCallCount()).Should(Equal(1))
 			})
 		})
 
+		Context("asynchronously", func() {
+			var destroying chan struct{}
+			var finish chan error
+
+			BeforeEach(func() {
+				destroying = make(chan struct{})
+				finish = make(chan error, 1)
+
+				destroying, finish := destroying, finish
+				serverBackend.DestroyStub = func(string) error {
+					close(destroying)
+					return <-finish
+				}
+
+				listed := new(fakes.FakeContainer)
+				listed.HandleReturns("some-handle")
+
+				serverBackend.ContainersReturns([]garden.Container{listed}, nil)
+				serverBackend.LookupReturns(nil, garden.ContainerNotFoundError{Handle: "some-handle"})
+				serverBackend.BulkInfoReturns(map[string]garden.ContainerInfoEntry{
+					"some-handle": {Err: &garden.Error{Err: garden.ContainerNotFoundError{Handle: "some-handle"}}},
+				}, nil)
+			})
+
+			It("returns before the container is destroyed, which is then in the destroying state", func() {
+				Ω(apiClient.(client.Client).DestroyAsync("some-handle")).Should(Succeed())
+				<-destroying
+
+				container, err := apiClient.Lookup("some-handle")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				info, err := container.Info()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(info.State).Should(Equal("destroying"))
+
+				bulkInfo, err := apiClient.BulkInfo([]string{"some-handle"})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(bulkInfo["some-handle"]).Should(Equal(garden.ContainerInfoEntry{
+					Info: garden.ContainerInfo{State: "destroying"},
+				}))
+
+				finish <- nil
+			})
+
+			It("lets destroyers join the destroy in flight", func() {
+				Ω(apiClient.(client.Client).DestroyAsync("some-handle")).Should(Succeed())
+				<-destroying
+
+				Ω(apiClient.(client.Client).DestroyAsync("some-handle")).Should(Succeed())
+
+				finish <- nil
+				Ω(apiClient.(client.Client).WaitForDestroy("some-handle")).Should(Succeed())
+
+				Ω(serverBackend.DestroyCallCount()).Should(Equal(1))
+			})
+
+			It("reports the outcome to clients waiting for the destroy", func() {
+				Ω(apiClient.(client.Client).DestroyAsync("some-handle")).Should(Succeed())
+				<-destroying
+
+				waited := make(chan error, 2)
+				go func() {
+					waited <- apiClient.(client.Client).WaitForDestroy("some-handle")
+				}()
+
+				Consistently(waited).ShouldNot(Receive())
+
+				finish <- garden.ContainerNotFoundError{Handle: "some-handle"}
+				Eventually(waited).Should(Receive(Equal(garden.ContainerNotFoundError{Handle: "some-handle"})))
+
+				// the outcome is kept for later waiters
+				Ω(apiClient.(client.Client).WaitForDestroy("some-handle")).Should(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+			})
+
+			Context("when the container is not being destroyed", func() {
+				BeforeEach(func() {
+					serverBackend.LookupReturns(new(fakes.FakeContainer), nil)
+				})
+
+				It("returns a ConflictError to waiters", func() {
+					err := apiClient.(client.Client).WaitForDestroy("some-handle")
+					Ω(err).Should(BeAssignableToTypeOf(garden.ConflictError{}))
+				})
+			})
+		})
+
 		Context("when the container cannot be found", func() {
 			var theError = garden.ContainerNotFoundError{Handle: "some-handle"}
 
@@ -464,12 +573,15 @@ var _ = Describe("When a client connects", func() {
 		BeforeEach(func() {
 			c1 := new(fakes.FakeContainer)
 			c1.HandleReturns("some-handle")
+			c1.PropertiesReturns(garden.Properties{"foo": "bar", "env": "prod"}, nil)
 
 			c2 := new(fakes.FakeContainer)
 			c2.HandleReturns("another-handle")
+			c2.PropertiesReturns(garden.Properties{"foo": "bar", "env": "dev"}, nil)
 
 			c3 := new(fakes.FakeContainer)
 			c3.HandleReturns("super-handle")
+			c3.PropertiesReturns(nil, garden.ContainerNotFoundError{Handle: "super-handle"})
 
 			serverBackend.ContainersReturns([]garden.Container{c1, c2, c3}, nil)
 		})
@@ -501,6 +613,130 @@ var _ = Describe("When a client connects", func() {
 			})
 		})
 
+		Context("and the client sends a ListRequest with a selector", func() {
+			selector := func(expr string) garden.Selector {
+				parsed, err := garden.ParseSelector(expr)
+				Ω(err).ShouldNot(HaveOccurred())
+				return parsed
+			}
+
+			It("lists the containers with its equalities and returns those matching the rest", func() {
+				containers, err := apiClient.(client.Client).ContainersMatching(selector("foo=bar,env notin (dev,test)"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(serverBackend.ContainersArgsForCall(serverBackend.ContainersCallCount() - 1)).Should(Equal(
+					garden.Properties{
+						"foo": "bar",
+					},
+				))
+
+				Ω(containers).Should(HaveLen(1))
+				Ω(containers[0].Handle()).Should(Equal("some-handle"))
+			})
+
+			It("returns the containers from the backend when the selector is only equalities", func() {
+				containers, err := apiClient.(client.Client).ContainersMatching(selector("foo=bar"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(containers).Should(HaveLen(3))
+			})
+
+			Context("when the selector is malformed", func() {
+				It("returns an InvalidRequestError", func() {
+					_, err := apiClient.(client.Client).ContainersMatching(garden.Selector{
+						{Key: "foo", Operator: garden.SelectorIn, Values: []string{"a=b"}},
+					})
+					Ω(err).Should(BeAssignableToTypeOf(garden.InvalidRequestError{}))
+				})
+			})
+		})
+
+		Context("and the client sends a ListRequest for a page", func() {
+			containersPage := func(options garden.ListOptions) (garden.ContainerPage, error) {
+				return apiClient.(client.Client).ContainersPage(options)
+			}
+
+			handles := func(page garden.ContainerPage) []string {
+				handles := []string{}
+				for _, c := range page.Containers {
+					handles = append(handles, c.Handle())
+				}
+
+				return handles
+			}
+
+			It("returns pages of containers ordered by handle", func() {
+				page, err := containersPage(garden.ListOptions{Limit: 2})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(handles(page)).Should(Equal([]string{"another-handle", "some-handle"}))
+				Ω(page.Infos).Should(BeNil())
+				Ω(page.Continue).ShouldNot(BeEmpty())
+
+				page, err = containersPage(garden.ListOptions{Limit: 2, Continue: page.Continue})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(handles(page)).Should(Equal([]string{"super-handle"}))
+				Ω(page.Continue).Should(BeEmpty())
+			})
+
+			It("returns every container on one page without a limit", func() {
+				page, err := containersPage(garden.ListOptions{})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(handles(page)).Should(Equal([]string{"another-handle", "some-handle", "super-handle"}))
+				Ω(page.Continue).Should(BeEmpty())
+			})
+
+			Context("with fields of the containers' info", func() {
+				BeforeEach(func() {
+					serverBackend.BulkInfoReturns(map[string]garden.ContainerInfoEntry{
+						"another-handle": {
+							Info: garden.ContainerInfo{
+								State:         "active",
+								ContainerIP:   "10.0.0.2",
+								ContainerPath: "/some/path",
+								Properties:    garden.Properties{"foo": "bar"},
+							},
+						},
+						"some-handle": {
+							Err: &garden.Error{Err: garden.ContainerNotFoundError{Handle: "some-handle"}},
+						},
+					}, nil)
+				})
+
+				It("returns the fields inline, leaving out containers that have gone", func() {
+					page, err := containersPage(garden.ListOptions{
+						Limit:  2,
+						Fields: []garden.ContainerInfoField{garden.ContainerInfoFieldState, garden.ContainerInfoFieldIPs},
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(serverBackend.BulkInfoArgsForCall(0)).Should(Equal([]string{"another-handle", "some-handle"}))
+
+					Ω(handles(page)).Should(Equal([]string{"another-handle"}))
+					Ω(page.Infos).Should(Equal(map[string]garden.ContainerInfo{
+						"another-handle": {State: "active", ContainerIP: "10.0.0.2"},
+					}))
+					Ω(page.Continue).ShouldNot(BeEmpty())
+				})
+			})
+
+			Context("when the options are malformed", func() {
+				It("returns an InvalidRequestError", func() {
+					listed := serverBackend.ContainersCallCount()
+
+					_, err := containersPage(garden.ListOptions{Fields: []garden.ContainerInfoField{"bogus"}})
+					Ω(err).Should(BeAssignableToTypeOf(garden.InvalidRequestError{}))
+
+					_, err = containersPage(garden.ListOptions{Continue: "not a token"})
+					Ω(err).Should(BeAssignableToTypeOf(garden.InvalidRequestError{}))
+
+					Ω(serverBackend.ContainersCallCount()).Should(Equal(listed))
+				})
+			})
+		})
+
 		Context("and the client sends a ListRequest with a property filter", func() {
 			It("forwards the filter to the backend", func() {
 				_, err := apiClient.Containers(garden.Properties{
@@ -1010,6 +1246,144 @@ var _ = Describe("When a client connects", func() {
 			})
 		})
 
+		Describe("listing processes", func() {
+			processes := []garden.ProcessInfo{
+				{
+					ID:        "process-1",
+					Spec:      garden.ProcessSpec{Path: "sleep", Args: []string{"100"}, User: "alice"},
+					StartedAt: time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+					State:     "running",
+				},
+				{
+					ID:         "process-2",
+					Spec:       garden.ProcessSpec{Path: "false", Dir: "/tmp"},
+					StartedAt:  time.Date(2015, 6, 1, 12, 1, 0, 0, time.UTC),
+					State:      "exited",
+					ExitStatus: 1,
+				},
+			}
+
+			It("returns the processes returned by the backend", func() {
+				fakeContainer.ProcessesReturns(processes, nil)
+
+				Ω(container.Processes()).Should(Equal(processes))
+			})
+
+			Context("when clients are attached to a process", func() {
+				BeforeEach(func() {
+					fakeProcess := new(fakes.FakeProcess)
+					fakeProcess.IDReturns("process-1")
+					fakeProcess.WaitStub = func() (int, error) {
+						select {}
+					}
+
+					fakeContainer.RunReturns(fakeProcess, nil)
+					fakeContainer.ProcessesReturns(processes, nil)
+				})
+
+				It("counts them", func() {
+					_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Eventually(func() int {
+						listed, err := container.Processes()
+						Ω(err).ShouldNot(HaveOccurred())
+
+						return listed[0].AttachedClients
+					}).Should(Equal(1))
+				})
+			})
+
+			itFailsWhenTheContainerIsNotFound(func() error {
+				_, err := container.Processes()
+				return err
+			})
+
+			Context("when listing processes fails", func() {
+				BeforeEach(func() {
+					fakeContainer.ProcessesReturns(nil, errors.New("oh no!"))
+				})
+
+				It("fails", func() {
+					_, err := container.Processes()
+					Ω(err).Should(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("getting a process", func() {
+			It("returns the process returned by the backend", func() {
+				process := garden.ProcessInfo{
+					ID:        "process-1",
+					Spec:      garden.ProcessSpec{Path: "sleep", TTY: &garden.TTYSpec{}},
+					StartedAt: time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+					State:     "running",
+					TTY:       true,
+				}
+
+				fakeContainer.ProcessReturns(process, nil)
+
+				Ω(container.Process("process-1")).Should(Equal(process))
+				Ω(fakeContainer.ProcessArgsForCall(0)).Should(Equal("process-1"))
+			})
+
+			Context("when getting the process fails", func() {
+				BeforeEach(func() {
+					fakeContainer.ProcessReturns(garden.ProcessInfo{}, errors.New("oh no!"))
+				})
+
+				It("fails", func() {
+					_, err := container.Process("process-1")
+					Ω(err).Should(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("setting limits", func() {
+			It("sets the memory limits in the backend", func() {
+				limits := garden.MemoryLimits{LimitInBytes: 2048}
+
+				Ω(container.SetMemoryLimits(limits)).Should(Succeed())
+				Ω(fakeContainer.SetMemoryLimitsArgsForCall(0)).Should(Equal(limits))
+			})
+
+			It("sets the cpu limits in the backend", func() {
+				limits := garden.CPULimits{LimitInShares: 456}
+
+				Ω(container.SetCPULimits(limits)).Should(Succeed())
+				Ω(fakeContainer.SetCPULimitsArgsForCall(0)).Should(Equal(limits))
+			})
+
+			It("sets the disk limits in the backend", func() {
+				limits := garden.DiskLimits{InodeSoft: 1, InodeHard: 2, ByteSoft: 3, ByteHard: 4}
+
+				Ω(container.SetDiskLimits(limits)).Should(Succeed())
+				Ω(fakeContainer.SetDiskLimitsArgsForCall(0)).Should(Equal(limits))
+			})
+
+			It("sets the bandwidth limits in the backend", func() {
+				limits := garden.BandwidthLimits{RateInBytesPerSecond: 1230, BurstRateInBytesPerSecond: 4560}
+
+				Ω(container.SetBandwidthLimits(limits)).Should(Succeed())
+				Ω(fakeContainer.SetBandwidthLimitsArgsForCall(0)).Should(Equal(limits))
+			})
+
+			itFailsWhenTheContainerIsNotFound(func() error {
+				return container.SetMemoryLimits(garden.MemoryLimits{LimitInBytes: 2048})
+			})
+
+			Context("when the backend cannot set the limits", func() {
+				BeforeEach(func() {
+					fakeContainer.SetMemoryLimitsReturns(errors.New("memory usage exceeds limit"))
+				})
+
+				It("returns the backend's error", func() {
+					err := container.SetMemoryLimits(garden.MemoryLimits{LimitInBytes: 1})
+					Ω(err).Should(MatchError("memory usage exceeds limit"))
+				})
+			})
+		})
+
 		Describe("getting the current bandwidth limits", func() {
 			It("returns the limits returned by the backend", func() {
 				effectiveLimits := garden.BandwidthLimits{
@@ -1147,6 +1521,60 @@ var _ = Describe("When a client connects", func() {
 			})
 		})
 
+		Describe("listing pending reaps", func() {
+			BeforeEach(func() {
+				serverBackend.GraceTimeReturns(time.Hour)
+			})
+
+			It("describes the container's countdown without reaping it", func() {
+				before := time.Now()
+
+				reaps, err := apiClient.(client.Client).PendingReaps()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(reaps).Should(HaveLen(1))
+				Ω(reaps[0].Handle).Should(Equal("some-handle"))
+				Ω(reaps[0].GraceTime).Should(Equal(time.Hour))
+				Ω(reaps[0].Pauses).Should(BeZero())
+				Ω(reaps[0].ReapAt).Should(BeTemporally("~", before.Add(time.Hour), time.Second))
+
+				Ω(serverBackend.DestroyCallCount()).Should(BeZero())
+			})
+
+			Context("while a request references the container", func() {
+				var release chan struct{}
+
+				BeforeEach(func() {
+					release = make(chan struct{})
+
+					released := release
+					fakeContainer.InfoStub = func() (garden.ContainerInfo, error) {
+						<-released
+						return garden.ContainerInfo{}, nil
+					}
+				})
+
+				AfterEach(func() {
+					close(release)
+				})
+
+				It("reports it as paused", func() {
+					go container.Info()
+
+					Eventually(func() int {
+						reaps, err := apiClient.(client.Client).PendingReaps()
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(reaps).Should(HaveLen(1))
+						return reaps[0].Pauses
+					}).Should(Equal(1))
+
+					reaps, err := apiClient.(client.Client).PendingReaps()
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(reaps[0].ReapAt).Should(BeZero())
+				})
+			})
+		})
+
 		Describe("net in", func() {
 			It("maps the ports and returns them", func() {
 				fakeContainer.NetInReturns(111, 222, nil)
@@ -1569,6 +1997,157 @@ var _ = Describe("When a client connects", func() {
 			})
 		})
 
+		Describe("BulkDestroy", func() {
+			BeforeEach(func() {
+				serverBackend.GraceTimeReturns(time.Minute)
+			})
+
+			It("destroys each container and defuses its bomb", func() {
+				results, err := apiClient.(client.Client).BulkDestroy([]string{"some-handle", "another-handle"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(results).Should(Equal(map[string]garden.ContainerResultEntry{
+					"some-handle":    {},
+					"another-handle": {},
+				}))
+
+				Ω(serverBackend.DestroyCallCount()).Should(Equal(2))
+
+				reaps, err := apiClient.(client.Client).PendingReaps()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(reaps).Should(BeEmpty())
+			})
+
+			It("destroys a container given more than once only once", func() {
+				_, err := apiClient.(client.Client).BulkDestroy([]string{"some-handle", "some-handle"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(serverBackend.DestroyCallCount()).Should(Equal(1))
+			})
+
+			Context("when destroying some of the containers fails", func() {
+				BeforeEach(func() {
+					serverBackend.DestroyStub = func(handle string) error {
+						if handle == "some-handle" {
+							return errors.New("oh no!")
+						}
+
+						return garden.ContainerNotFoundError{Handle: handle}
+					}
+				})
+
+				It("returns the typed error of each, and does not defuse their bombs", func() {
+					results, err := apiClient.(client.Client).BulkDestroy([]string{"some-handle", "bogus-handle"})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(results["some-handle"].Err).Should(MatchError("oh no!"))
+					Ω(results["bogus-handle"].Err).Should(Equal(&garden.Error{
+						Err: garden.ContainerNotFoundError{Handle: "bogus-handle"},
+					}))
+
+					reaps, err := apiClient.(client.Client).PendingReaps()
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(reaps).Should(HaveLen(1))
+				})
+			})
+
+			Context("when given many containers", func() {
+				var inFlight *int32
+				var release chan struct{}
+
+				BeforeEach(func() {
+					inFlight = new(int32)
+					release = make(chan struct{})
+
+					inFlight, release := inFlight, release
+					serverBackend.DestroyStub = func(string) error {
+						atomic.AddInt32(inFlight, 1)
+						<-release
+						atomic.AddInt32(inFlight, -1)
+						return nil
+					}
+				})
+
+				It("destroys a bounded number of them at once", func() {
+					handles := []string{}
+					for i := 0; i < 40; i++ {
+						handles = append(handles, fmt.Sprintf("handle-%d", i))
+					}
+
+					done := make(chan struct{})
+					go func() {
+						defer GinkgoRecover()
+						defer close(done)
+
+						results, err := apiClient.(client.Client).BulkDestroy(handles)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(results).Should(HaveLen(40))
+					}()
+
+					Eventually(func() int32 { return atomic.LoadInt32(inFlight) }).Should(Equal(int32(16)))
+					Consistently(func() int32 { return atomic.LoadInt32(inFlight) }, 100*time.Millisecond).Should(Equal(int32(16)))
+
+					close(release)
+					Eventually(done).Should(BeClosed())
+
+					Ω(serverBackend.DestroyCallCount()).Should(Equal(40))
+				})
+			})
+		})
+
+		Describe("BulkStop", func() {
+			var anotherContainer *fakes.FakeContainer
+
+			BeforeEach(func() {
+				anotherContainer = new(fakes.FakeContainer)
+				anotherContainer.HandleReturns("another-handle")
+
+				anotherContainer := anotherContainer
+				serverBackend.LookupStub = func(handle string) (garden.Container, error) {
+					switch handle {
+					case "some-handle":
+						return fakeContainer, nil
+					case "another-handle":
+						return anotherContainer, nil
+					}
+
+					return nil, garden.ContainerNotFoundError{Handle: handle}
+				}
+			})
+
+			It("stops each container, returning the typed error of those it could not", func() {
+				results, err := apiClient.(client.Client).BulkStop([]string{"some-handle", "another-handle", "bogus-handle"}, true)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(results).Should(Equal(map[string]garden.ContainerResultEntry{
+					"some-handle":    {},
+					"another-handle": {},
+					"bogus-handle": {
+						Err: &garden.Error{Err: garden.ContainerNotFoundError{Handle: "bogus-handle"}},
+					},
+				}))
+
+				Ω(fakeContainer.StopCallCount()).Should(Equal(1))
+				Ω(fakeContainer.StopArgsForCall(0)).Should(BeTrue())
+				Ω(anotherContainer.StopCallCount()).Should(Equal(1))
+				Ω(anotherContainer.StopArgsForCall(0)).Should(BeTrue())
+			})
+
+			Context("when stopping a container fails", func() {
+				BeforeEach(func() {
+					anotherContainer.StopReturns(errors.New("oh no!"))
+				})
+
+				It("returns its error", func() {
+					results, err := apiClient.(client.Client).BulkStop([]string{"some-handle", "another-handle"}, false)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(results["some-handle"].Err).Should(BeNil())
+					Ω(results["another-handle"].Err).Should(MatchError("oh no!"))
+				})
+			})
+		})
+
 		Describe("attaching", func() {
 			Context("when attaching succeeds", func() {
 				BeforeEach(func() {
@@ -1935,6 +2514,32 @@ var _ = Describe("When a client connects", func() {
 				})
 			})
 
+			Context("when the process is sent a POSIX signal", func() {
+				var fakeProcess *fakes.FakeProcess
+
+				BeforeEach(func() {
+					fakeProcess = new(fakes.FakeProcess)
+					fakeProcess.IDReturns("process-handle")
+					fakeProcess.WaitStub = func() (int, error) {
+						select {}
+					}
+
+					fakeContainer.RunReturns(fakeProcess, nil)
+				})
+
+				It("forwards the signal to the backend", func() {
+					process, err := container.Run(processSpec, garden.ProcessIO{})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(process.Signal(garden.SignalHangup)).Should(Succeed())
+					Ω(process.Signal(garden.SignalQuit)).Should(Succeed())
+
+					Eventually(fakeProcess.SignalCallCount).Should(Equal(2))
+					Ω(fakeProcess.SignalArgsForCall(0)).Should(Equal(garden.SignalHangup))
+					Ω(fakeProcess.SignalArgsForCall(1)).Should(Equal(garden.SignalQuit))
+				})
+			})
+
 			Context("when the process's window size is set", func() {
 				var fakeProcess *fakes.FakeProcess
 
@@ -2008,6 +2613,18 @@ var _ = Describe("When a client connects", func() {
 					Ω(err).Should(HaveOccurred())
 				})
 			})
+
+			Context("when the spec is invalid", func() {
+				It("fails without running the process", func() {
+					_, err := container.Run(garden.ProcessSpec{
+						Path: "ls",
+						Env:  []string{"NOT_A_VARIABLE"},
+					}, garden.ProcessIO{})
+					Ω(err).Should(HaveOccurred())
+
+					Ω(fakeContainer.RunCallCount()).Should(BeZero())
+				})
+			})
 		})
 	})
 })
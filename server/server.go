@@ -1,27 +1,38 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/cloudfoundry-incubator/garden/routes"
 	"github.com/cloudfoundry-incubator/garden/server/bomberman"
+	"github.com/cloudfoundry-incubator/garden/server/broadcaster"
 	"github.com/cloudfoundry-incubator/garden/server/streamer"
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/rata"
 )
 
+// streamGraceTime is how long a process's output remains available to clients
+// after the process exits.
+const streamGraceTime = time.Minute
+
 type GardenServer struct {
+	// accessed atomically, so first to keep it 64-bit aligned
+	propertiesSeq uint64
+
 	logger lager.Logger
 
 	server        *http.Server
 	listenNetwork string
 	listenAddr    string
+	tlsConfig     *tls.Config
 
 	containerGraceTime time.Duration
 	backend            garden.Backend
@@ -39,8 +50,39 @@ type GardenServer struct {
 
 	streamer *streamer.Streamer
 
-	destroys  map[string]struct{}
+	destroys  map[string]*destroyOperation
 	destroysL *sync.Mutex
+
+	creates           map[idempotencyKey]*idempotentCreate
+	createsL          *sync.Mutex
+	idempotencyWindow time.Duration
+
+	propertiesVersions  map[string]*propertiesVersion
+	propertiesVersionsL *sync.Mutex
+	propertiesEpoch     int64
+
+	authorizer Authorizer
+
+	events *broadcaster.Broadcaster
+	ooms   map[string]struct{}
+	oomsL  *sync.Mutex
+
+	metrics        *serverMetrics
+	metricsEnabled bool
+
+	outputPolicy OutputPolicy
+	outputs      map[processKey]*processOutput
+	outputsL     *sync.Mutex
+
+	statuses        map[processKey]*processStatus
+	statusesL       *sync.Mutex
+	statusRetention time.Duration
+
+	graceTimeStatePath string
+	graceTimeState     *graceTimeState
+	graceTimeStateL    *sync.Mutex
+
+	reapHook ReapHook
 }
 
 func New(
@@ -48,12 +90,26 @@ func New(
 	containerGraceTime time.Duration,
 	backend garden.Backend,
 	logger lager.Logger,
+) *GardenServer {
+	return NewWithTLS(listenNetwork, listenAddr, nil, containerGraceTime, backend, logger)
+}
+
+// NewWithTLS creates a server that serves TLS on its listener, including the
+// hijacked process streams. If tlsConfig is nil it serves plaintext, as New
+// does. To require mutual TLS, set ClientCAs and ClientAuth on tlsConfig.
+func NewWithTLS(
+	listenNetwork, listenAddr string,
+	tlsConfig *tls.Config,
+	containerGraceTime time.Duration,
+	backend garden.Backend,
+	logger lager.Logger,
 ) *GardenServer {
 	s := &GardenServer{
 		logger: logger.Session("garden-server"),
 
 		listenNetwork: listenNetwork,
 		listenAddr:    listenAddr,
+		tlsConfig:     tlsConfig,
 
 		containerGraceTime: containerGraceTime,
 		backend:            backend,
@@ -63,10 +119,34 @@ func New(
 		handling: new(sync.WaitGroup),
 		conns:    make(map[net.Conn]net.Conn),
 
-		streamer: streamer.New(time.Minute),
+		streamer: streamer.New(streamGraceTime),
 
-		destroys:  make(map[string]struct{}),
+		destroys:  make(map[string]*destroyOperation),
 		destroysL: new(sync.Mutex),
+
+		creates:           make(map[idempotencyKey]*idempotentCreate),
+		createsL:          new(sync.Mutex),
+		idempotencyWindow: DefaultIdempotencyWindow,
+
+		propertiesVersions:  make(map[string]*propertiesVersion),
+		propertiesVersionsL: new(sync.Mutex),
+		propertiesEpoch:     time.Now().UnixNano(),
+
+		events: broadcaster.New(EventBufferSize),
+		ooms:   make(map[string]struct{}),
+		oomsL:  new(sync.Mutex),
+
+		metrics: newServerMetrics(),
+
+		outputs:  make(map[processKey]*processOutput),
+		outputsL: new(sync.Mutex),
+
+		statuses:        make(map[processKey]*processStatus),
+		statusesL:       new(sync.Mutex),
+		statusRetention: DefaultProcessStatusRetention,
+
+		graceTimeState:  newGraceTimeState(),
+		graceTimeStateL: new(sync.Mutex),
 	}
 
 	handlers := map[string]http.Handler{
@@ -74,31 +154,50 @@ func New(
 		routes.Capacity:               http.HandlerFunc(s.handleCapacity),
 		routes.Create:                 http.HandlerFunc(s.handleCreate),
 		routes.Destroy:                http.HandlerFunc(s.handleDestroy),
+		routes.BulkDestroy:            http.HandlerFunc(s.handleBulkDestroy),
+		routes.WaitForDestroy:         http.HandlerFunc(s.handleWaitForDestroy),
 		routes.List:                   http.HandlerFunc(s.handleList),
 		routes.Stop:                   http.HandlerFunc(s.handleStop),
+		routes.BulkStop:               http.HandlerFunc(s.handleBulkStop),
 		routes.StreamIn:               http.HandlerFunc(s.handleStreamIn),
 		routes.StreamOut:              http.HandlerFunc(s.handleStreamOut),
 		routes.CurrentBandwidthLimits: http.HandlerFunc(s.handleCurrentBandwidthLimits),
 		routes.CurrentCPULimits:       http.HandlerFunc(s.handleCurrentCPULimits),
 		routes.CurrentDiskLimits:      http.HandlerFunc(s.handleCurrentDiskLimits),
 		routes.CurrentMemoryLimits:    http.HandlerFunc(s.handleCurrentMemoryLimits),
+		routes.SetBandwidthLimits:     http.HandlerFunc(s.handleSetBandwidthLimits),
+		routes.SetCPULimits:           http.HandlerFunc(s.handleSetCPULimits),
+		routes.SetDiskLimits:          http.HandlerFunc(s.handleSetDiskLimits),
+		routes.SetMemoryLimits:        http.HandlerFunc(s.handleSetMemoryLimits),
 		routes.NetIn:                  http.HandlerFunc(s.handleNetIn),
 		routes.NetOut:                 http.HandlerFunc(s.handleNetOut),
 		routes.Info:                   http.HandlerFunc(s.handleInfo),
 		routes.BulkInfo:               http.HandlerFunc(s.handleBulkInfo),
 		routes.BulkMetrics:            http.HandlerFunc(s.handleBulkMetrics),
 		routes.Run:                    http.HandlerFunc(s.handleRun),
-		routes.Stdout:                 streamer.HandlerFunc(s.streamer.ServeStdout),
-		routes.Stderr:                 streamer.HandlerFunc(s.streamer.ServeStderr),
+		routes.Stdout:                 s.streamer.StdoutHandler(),
+		routes.Stderr:                 s.streamer.StderrHandler(),
 		routes.Attach:                 http.HandlerFunc(s.handleAttach),
+		routes.Processes:              http.HandlerFunc(s.handleProcesses),
+		routes.Process:                http.HandlerFunc(s.handleProcess),
+		routes.ProcessStatus:          http.HandlerFunc(s.handleProcessStatus),
 		routes.Metrics:                http.HandlerFunc(s.handleMetrics),
 		routes.Properties:             http.HandlerFunc(s.handleProperties),
+		routes.UpdateProperties:       http.HandlerFunc(s.handleUpdateProperties),
 		routes.Property:               http.HandlerFunc(s.handleProperty),
 		routes.SetProperty:            http.HandlerFunc(s.handleSetProperty),
 		routes.RemoveProperty:         http.HandlerFunc(s.handleRemoveProperty),
 		routes.SetGraceTime:           http.HandlerFunc(s.handleSetGraceTime),
+		routes.Events:                 http.HandlerFunc(s.handleEvents),
+		routes.PendingReaps:           http.HandlerFunc(s.handlePendingReaps),
 	}
 
+	for name, handler := range handlers {
+		handlers[name] = s.instrumenting(name, s.authorizing(name, handler))
+	}
+
+	metricsHandler := s.authorizing(MetricsRoute, s.MetricsHandler())
+
 	mux, err := rata.NewRouter(routes.Routes, handlers)
 	if err != nil {
 		logger.Fatal("failed-to-initialize-rata", err)
@@ -108,14 +207,22 @@ func New(
 
 	s.server = &http.Server{
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if s.metricsEnabled && r.Method == "GET" && r.URL.Path == "/metrics" {
+				metricsHandler.ServeHTTP(w, r)
+				return
+			}
+
 			mux.ServeHTTP(w, r)
 		}),
 
+		ConnContext: connContext,
+
 		ConnState: func(conn net.Conn, state http.ConnState) {
 			switch state {
 			case http.StateNew:
 				conLogger.Debug("open", lager.Data{"local_addr": conn.LocalAddr(), "remote_addr": conn.RemoteAddr()})
 				s.handling.Add(1)
+				atomic.AddInt64(&s.metrics.openConnections, 1)
 			case http.StateActive:
 				s.mu.Lock()
 				delete(s.conns, conn)
@@ -135,6 +242,11 @@ func New(
 				s.mu.Unlock()
 				conLogger.Debug("closed", lager.Data{"local_addr": conn.LocalAddr(), "remote_addr": conn.RemoteAddr()})
 				s.handling.Done()
+
+				// hijacked connections are counted until the handler closes them
+				if state == http.StateClosed {
+					atomic.AddInt64(&s.metrics.openConnections, -1)
+				}
 			}
 		},
 	}
@@ -160,12 +272,16 @@ func (s *GardenServer) Start() error {
 		return err
 	}
 
-	s.listener = listener
-
 	if s.listenNetwork == "unix" {
 		os.Chmod(s.listenAddr, 0777)
 	}
 
+	if s.tlsConfig != nil {
+		listener = tls.NewListener(listener, s.tlsConfig)
+	}
+
+	s.listener = listener
+
 	containers, err := s.backend.Containers(nil)
 	if err != nil {
 		return err
@@ -173,9 +289,7 @@ func (s *GardenServer) Start() error {
 
 	s.bomberman = bomberman.New(s.backend, s.reapContainer)
 
-	for _, container := range containers {
-		s.bomberman.Strap(container)
-	}
+	s.restoreGraceTimes(containers)
 
 	go s.server.Serve(listener)
 
@@ -207,6 +321,8 @@ func (s *GardenServer) Stop() {
 	s.logger.Info("waiting-for-connections-to-close")
 	s.handling.Wait()
 
+	s.saveGraceTimeState()
+
 	s.logger.Info("stopping-backend")
 	s.backend.Stop()
 
@@ -230,12 +346,3 @@ func (s *GardenServer) removeExistingSocket() error {
 
 	return nil
 }
-
-func (s *GardenServer) reapContainer(container garden.Container) {
-	s.logger.Info("reaping", lager.Data{
-		"handle":     container.Handle(),
-		"grace-time": s.backend.GraceTime(container).String(),
-	})
-
-	s.backend.Destroy(container.Handle())
-}
@@ -1,6 +1,8 @@
 package server_test
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"os"
@@ -87,6 +89,102 @@ var _ = Describe("The Garden server", func() {
 		})
 	})
 
+	Context("when passed a TLS config", func() {
+		var certs testCertificates
+		var serverTLSConfig *tls.Config
+		var apiServer *server.GardenServer
+		var fakeBackend *fakes.FakeBackend
+
+		BeforeEach(func() {
+			certs = generateCertificates("some-client")
+
+			serverTLSConfig = &tls.Config{
+				Certificates: []tls.Certificate{certs.Server},
+			}
+
+			fakeBackend = new(fakes.FakeBackend)
+		})
+
+		JustBeforeEach(func() {
+			apiServer = server.NewWithTLS("tcp", "127.0.0.1:60124", serverTLSConfig, 0, fakeBackend, logger)
+
+			err := apiServer.Start()
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			apiServer.Stop()
+		})
+
+		It("serves TLS", func() {
+			apiClient = client.New(connection.NewWithTLS("tcp", "127.0.0.1:60124", &tls.Config{
+				RootCAs: certs.CAPool,
+			}))
+			Eventually(apiClient.Ping).Should(Succeed())
+		})
+
+		It("rejects plaintext clients", func() {
+			apiClient = client.New(connection.New("tcp", "127.0.0.1:60124"))
+			Consistently(apiClient.Ping).ShouldNot(Succeed())
+		})
+
+		It("serves process streams over TLS", func() {
+			fakeContainer := new(fakes.FakeContainer)
+			fakeContainer.HandleReturns("some-handle")
+			fakeContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+				process := new(fakes.FakeProcess)
+				process.IDReturns("some-process")
+				process.WaitStub = func() (int, error) {
+					io.Stdout.Write([]byte("hello over tls\n"))
+					return 42, nil
+				}
+
+				return process, nil
+			}
+
+			fakeBackend.CreateReturns(fakeContainer, nil)
+			fakeBackend.LookupReturns(fakeContainer, nil)
+
+			apiClient = client.New(connection.NewWithTLS("tcp", "127.0.0.1:60124", &tls.Config{
+				RootCAs: certs.CAPool,
+			}))
+
+			container, err := apiClient.Create(garden.ContainerSpec{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			stdout := gbytes.NewBuffer()
+			process, err := container.Run(garden.ProcessSpec{Path: "some-path"}, garden.ProcessIO{
+				Stdout: stdout,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(process.Wait()).Should(Equal(42))
+			Ω(stdout).Should(gbytes.Say("hello over tls"))
+		})
+
+		Context("when client certificates are required", func() {
+			BeforeEach(func() {
+				serverTLSConfig.ClientCAs = certs.CAPool
+				serverTLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			})
+
+			It("accepts clients presenting a certificate signed by the CA", func() {
+				apiClient = client.New(connection.NewWithTLS("tcp", "127.0.0.1:60124", &tls.Config{
+					RootCAs:      certs.CAPool,
+					Certificates: []tls.Certificate{certs.Client},
+				}))
+				Eventually(apiClient.Ping).Should(Succeed())
+			})
+
+			It("rejects clients without a certificate", func() {
+				apiClient = client.New(connection.NewWithTLS("tcp", "127.0.0.1:60124", &tls.Config{
+					RootCAs: certs.CAPool,
+				}))
+				Consistently(apiClient.Ping).ShouldNot(Succeed())
+			})
+		})
+	})
+
 	It("starts the backend", func() {
 		var err error
 		tmpdir, err = ioutil.TempDir(os.TempDir(), "api-server-test")
@@ -131,6 +229,116 @@ var _ = Describe("The Garden server", func() {
 		Ω(time.Since(before)).Should(BeNumerically(">", 100*time.Millisecond))
 	})
 
+	Describe("with a grace time state file", func() {
+		var (
+			statePath string
+
+			fakeBackend *fakes.FakeBackend
+			container   *fakes.FakeContainer
+
+			apiServer *testServer
+		)
+
+		writeState := func(state string) {
+			Ω(ioutil.WriteFile(statePath, []byte(state), 0600)).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			container = new(fakes.FakeContainer)
+			container.HandleReturns("some-handle")
+
+			fakeBackend = new(fakes.FakeBackend)
+			fakeBackend.ContainersReturns([]garden.Container{container}, nil)
+			fakeBackend.GraceTimeReturns(time.Hour)
+
+			apiServer = newTestServer(0, fakeBackend)
+
+			statePath = path.Join(apiServer.tmpdir, "grace-times.json")
+			apiServer.SetGraceTimeStateFile(statePath)
+		})
+
+		AfterEach(func() {
+			apiServer.cleanup()
+		})
+
+		It("resumes the countdown from when the container was last referenced", func() {
+			writeState(`{"referenced":{"some-handle":"` + time.Now().Add(-time.Hour).Format(time.RFC3339Nano) + `"}}`)
+
+			Ω(apiServer.Start()).Should(Succeed())
+
+			Eventually(fakeBackend.DestroyCallCount).Should(Equal(1))
+			Ω(fakeBackend.DestroyArgsForCall(0)).Should(Equal("some-handle"))
+		})
+
+		It("completes reaps that were interrupted by the restart", func() {
+			fakeBackend.GraceTimeReturns(0)
+			writeState(`{"reaped":{"some-handle":{"at":"` + time.Now().Format(time.RFC3339Nano) + `"}}}`)
+
+			Ω(apiServer.Start()).Should(Succeed())
+
+			Eventually(fakeBackend.DestroyCallCount).Should(Equal(1))
+			Ω(fakeBackend.DestroyArgsForCall(0)).Should(Equal("some-handle"))
+		})
+
+		It("remembers why containers destroyed before the restart were reaped", func() {
+			fakeBackend.ContainersReturns(nil, nil)
+			fakeBackend.LookupReturns(nil, garden.ContainerNotFoundError{Handle: "some-handle"})
+			writeState(`{"reaped":{"some-handle":{"at":"` + time.Now().Format(time.RFC3339Nano) + `","reason":"reaped for a reason"}}}`)
+
+			Ω(apiServer.Start()).Should(Succeed())
+
+			_, err := connection.New("unix", apiServer.socketPath).Info("some-handle")
+			Ω(err).Should(Equal(garden.ContainerNotFoundError{Handle: "some-handle", Reason: "reaped for a reason"}))
+		})
+
+		It("starts a full countdown for containers it has no record of", func() {
+			writeState(`{"referenced":{"other-handle":"` + time.Now().Add(-time.Hour).Format(time.RFC3339Nano) + `"}}`)
+
+			Ω(apiServer.Start()).Should(Succeed())
+
+			Consistently(fakeBackend.DestroyCallCount).Should(BeZero())
+		})
+
+		It("starts a full countdown when the state file is corrupt", func() {
+			writeState("{")
+
+			Ω(apiServer.Start()).Should(Succeed())
+
+			Consistently(fakeBackend.DestroyCallCount).Should(BeZero())
+		})
+
+		It("leaves the state file alone when stopped after failing to start", func() {
+			writeState(`{"referenced":{"some-handle":"` + time.Now().Format(time.RFC3339Nano) + `"}}`)
+			fakeBackend.ContainersReturns(nil, errors.New("oh no!"))
+
+			Ω(apiServer.Start()).ShouldNot(Succeed())
+			Ω(apiServer.Stop).ShouldNot(Panic())
+
+			content, err := ioutil.ReadFile(statePath)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(content)).Should(ContainSubstring("some-handle"))
+		})
+
+		It("records when each container was last referenced on stop", func() {
+			apiClient = apiServer.start()
+			Eventually(apiClient.Ping).Should(Succeed())
+
+			stopped := time.Now()
+			apiServer.Stop()
+
+			content, err := ioutil.ReadFile(statePath)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var state struct {
+				Referenced map[string]time.Time `json:"referenced"`
+			}
+			Ω(json.Unmarshal(content, &state)).Should(Succeed())
+
+			Ω(state.Referenced).Should(HaveKey("some-handle"))
+			Ω(state.Referenced["some-handle"]).Should(BeTemporally("<=", stopped))
+		})
+	})
+
 	Context("when starting the backend fails", func() {
 		disaster := errors.New("oh no!")
 
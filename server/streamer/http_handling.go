@@ -3,6 +3,7 @@ package streamer
 import (
 	"io"
 	"net/http"
+	"strconv"
 )
 
 type HandlerFunc func(StreamID, io.Writer)
@@ -20,3 +21,58 @@ func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defer conn.Close()
 	h(id, conn)
 }
+
+// StdoutHandler serves the standard output of the stream identified by the
+// ":streamid" parameter, from the byte offset given by the "offset" query
+// parameter or from the oldest output not yet served if there is none.
+func (m *Streamer) StdoutHandler() http.Handler {
+	return &handler{streamer: m, chanIndex: stdout}
+}
+
+// StderrHandler is like StdoutHandler, for standard error.
+func (m *Streamer) StderrHandler() http.Handler {
+	return &handler{streamer: m, chanIndex: stderr}
+}
+
+type handler struct {
+	streamer  *Streamer
+	chanIndex stdoutOrErr
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := StreamID(r.FormValue(":streamid"))
+
+	offset := int64(-1)
+	if o := r.FormValue("offset"); o != "" {
+		var err error
+		offset, err = strconv.ParseInt(o, 10, 64)
+		if err != nil || offset < 0 {
+			http.Error(w, "invalid offset: "+o, http.StatusBadRequest)
+			return
+		}
+	}
+
+	reader, err := h.streamer.reader(id, h.chanIndex, offset)
+	switch err {
+	case nil:
+	case ErrStreamNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case ErrOffsetUnavailable:
+		http.Error(w, err.Error(), http.StatusRequestedRangeNotSatisfiable)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+	reader.serve(conn)
+}
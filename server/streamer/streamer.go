@@ -1,20 +1,49 @@
 package streamer
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"sync"
 	"time"
 )
 
+// DefaultBufferSize is the number of bytes of each of a stream's standard
+// output and error that are buffered for late or reconnecting clients.
+const DefaultBufferSize = 1024 * 1024
+
+// minBufferAllocation is the smallest allocation for a buffer holding any
+// output; buffers grow from it up to their size as output accumulates.
+const minBufferAllocation = 4 * 1024
+
+// ErrOffsetUnavailable is returned when output is requested from an offset
+// that has not been produced yet or is no longer buffered.
+var ErrOffsetUnavailable = errors.New("offset is not available")
+
+// ErrStreamNotFound is returned when output is requested from a stream that
+// does not exist, or has been removed after being stopped.
+var ErrStreamNotFound = errors.New("stream not found")
+
 // StreamID identifies a pair of standard output and error channels used for streaming.
 type StreamID string
 
 // New creates a Streamer with the specified grace time which limits the duration of memory consumption by a stopped stream.
 func New(graceTime time.Duration) *Streamer {
+	return NewWithBufferSize(graceTime, DefaultBufferSize)
+}
+
+// NewWithBufferSize creates a Streamer which buffers up to bufferSize bytes of
+// each stream's standard output and error, so that they can be served again
+// from an earlier offset.
+func NewWithBufferSize(graceTime time.Duration, bufferSize int) *Streamer {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
 	return &Streamer{
-		graceTime: graceTime,
-		streams:   make(map[StreamID]*stream),
+		graceTime:  graceTime,
+		bufferSize: bufferSize,
+		streams:    make(map[StreamID]*stream),
 	}
 }
 
@@ -22,11 +51,13 @@ type Streamer struct {
 	mu           sync.RWMutex
 	nextStreamID uint64
 	graceTime    time.Duration
+	bufferSize   int
 	streams      map[StreamID]*stream
+	active       int
 }
 
 type stream struct {
-	ch   [2]chan []byte
+	buf  [2]*outputBuffer
 	done chan struct{}
 }
 
@@ -38,6 +69,7 @@ const (
 )
 
 // Stream sets up streaming for the given pair of channels and returns a StreamID to identify the pair.
+// Output is buffered from the channels as soon as it is sent, whether or not it is being served.
 // The caller must call Stop to avoid leaking memory.
 func (m *Streamer) Stream(stdout, stderr chan []byte) StreamID {
 	m.mu.Lock()
@@ -46,11 +78,22 @@ func (m *Streamer) Stream(stdout, stderr chan []byte) StreamID {
 	var sid StreamID = StreamID(fmt.Sprintf("%d", m.nextStreamID))
 	m.nextStreamID++
 
-	m.streams[sid] = &stream{
-		ch:   [2]chan []byte{stdout, stderr},
+	strm := &stream{
+		buf: [2]*outputBuffer{
+			newOutputBuffer(stdout, m.bufferSize),
+			newOutputBuffer(stderr, m.bufferSize),
+		},
 		done: make(chan struct{}),
 	}
 
+	for _, buf := range strm.buf {
+		go buf.pump(strm.done)
+	}
+
+	m.streams[sid] = strm
+
+	m.active++
+
 	return sid
 }
 
@@ -64,32 +107,45 @@ func (m *Streamer) ServeStderr(streamID StreamID, writer io.Writer) {
 	m.serve(streamID, writer, stderr)
 }
 
+// ServeStdoutFrom streams to the specified writer from the standard output of the specified stream, starting at
+// the specified byte offset.
+func (m *Streamer) ServeStdoutFrom(streamID StreamID, offset int64, writer io.Writer) error {
+	return m.serveFrom(streamID, offset, writer, stdout)
+}
+
+// ServeStderrFrom streams to the specified writer from the standard error of the specified stream, starting at
+// the specified byte offset.
+func (m *Streamer) ServeStderrFrom(streamID StreamID, offset int64, writer io.Writer) error {
+	return m.serveFrom(streamID, offset, writer, stderr)
+}
+
 func (m *Streamer) serve(streamID StreamID, writer io.Writer, chanIndex stdoutOrErr) {
 	strm := m.streamFromID(streamID)
 
-	ch := strm.ch[chanIndex]
-	for {
-		select {
-		case b := <-ch:
-			if _, err := writer.Write(b); err != nil {
-				return
-			}
-		case <-strm.done:
-			drain(ch, writer)
-			return
-		}
+	r, _ := strm.buf[chanIndex].reader(-1)
+	r.serve(writer)
+}
+
+func (m *Streamer) serveFrom(streamID StreamID, offset int64, writer io.Writer, chanIndex stdoutOrErr) error {
+	r, err := m.reader(streamID, chanIndex, offset)
+	if err != nil {
+		return err
 	}
+
+	r.serve(writer)
+
+	return nil
 }
 
-func drain(ch chan []byte, writer io.Writer) {
-	for {
-		select {
-		case b := <-ch:
-			writer.Write(b)
-		default:
-			return
-		}
+// reader positions a reader at offset of the stream's standard output or
+// error, or at the oldest output not yet served if offset is negative.
+func (m *Streamer) reader(streamID StreamID, chanIndex stdoutOrErr, offset int64) (*reader, error) {
+	strm := m.streamFromID(streamID)
+	if strm == nil {
+		return nil, ErrStreamNotFound
 	}
+
+	return strm.buf[chanIndex].reader(offset)
 }
 
 // Stop stops streaming from the specified pair of channels.
@@ -97,6 +153,10 @@ func (m *Streamer) Stop(streamID StreamID) {
 	strm := m.streamFromID(streamID)
 	close(strm.done)
 
+	m.mu.Lock()
+	m.active--
+	m.mu.Unlock()
+
 	go func() {
 		// wait some time to ensure clients have connected, once they've
 		// retrieved the stream from the map it's safe to delete the key
@@ -105,6 +165,10 @@ func (m *Streamer) Stop(streamID StreamID) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 		delete(m.streams, streamID)
+
+		for _, buf := range strm.buf {
+			buf.remove()
+		}
 	}()
 }
 
@@ -113,3 +177,355 @@ func (m *Streamer) streamFromID(streamID StreamID) *stream {
 	defer m.mu.RUnlock()
 	return m.streams[streamID]
 }
+
+// ActiveStreams returns the number of streams that have not been stopped.
+func (m *Streamer) ActiveStreams() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.active
+}
+
+// Offsets returns the offsets of the standard output and error of the specified stream that are served to clients
+// that do not ask for one: the oldest output not yet served.
+func (m *Streamer) Offsets(streamID StreamID) (stdoutOffset, stderrOffset int64) {
+	strm := m.streamFromID(streamID)
+	if strm == nil {
+		return 0, 0
+	}
+
+	return strm.buf[stdout].servedOffset(), strm.buf[stderr].servedOffset()
+}
+
+// Stalled returns channels that are closed while the standard output and
+// error of the specified stream, respectively, cannot be buffered because the
+// buffer is full of output that no client is being served. A new channel is
+// made each time the output resumes, so they must be asked for again to learn
+// when it next stalls.
+func (m *Streamer) Stalled(streamID StreamID) (stdoutStalled, stderrStalled <-chan struct{}) {
+	strm := m.streamFromID(streamID)
+	if strm == nil {
+		stalled := make(chan struct{})
+		close(stalled)
+		return stalled, stalled
+	}
+
+	return strm.buf[stdout].stalledCh(), strm.buf[stderr].stalledCh()
+}
+
+// outputBuffer is a ring buffer of the output sent on ch, addressed by byte
+// offsets from the start of the stream. Output that has not been served yet is
+// never overwritten; once the buffer is full of it, output backs up in ch.
+type outputBuffer struct {
+	ch chan []byte
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	data []byte
+	size int
+
+	// start and end bound the buffered output; served is the furthest offset
+	// any reader has written out
+	start, end, served int64
+
+	pumped  bool
+	removed bool
+
+	// readers counts the readers serving the output, and waiting is set while
+	// the pump waits for room; stalled is closed while both hold up the output
+	readers       int
+	waiting       bool
+	stalled       chan struct{}
+	stalledClosed bool
+}
+
+func newOutputBuffer(ch chan []byte, size int) *outputBuffer {
+	b := &outputBuffer{
+		ch:      ch,
+		size:    size,
+		stalled: make(chan struct{}),
+	}
+
+	b.cond = sync.NewCond(&b.mu)
+
+	return b
+}
+
+// pump buffers output from ch until the stream is stopped and ch is empty.
+func (b *outputBuffer) pump(done <-chan struct{}) {
+	defer func() {
+		b.mu.Lock()
+		b.pumped = true
+		b.cond.Broadcast()
+		b.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case data := <-b.ch:
+			if !b.append(data) {
+				return
+			}
+
+		case <-done:
+			for {
+				select {
+				case data := <-b.ch:
+					if !b.append(data) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// append buffers data, waiting for it to be served if there is no room. It
+// returns false if the buffer is removed first.
+func (b *outputBuffer) append(data []byte) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(data) > 0 {
+		if b.removed {
+			return false
+		}
+
+		n := b.write(data)
+		if n == 0 {
+			b.setWaiting(true)
+			b.cond.Wait()
+			b.setWaiting(false)
+			continue
+		}
+
+		data = data[n:]
+	}
+
+	return true
+}
+
+// setWaiting records whether the pump is waiting for room. It must be called
+// with mu held.
+func (b *outputBuffer) setWaiting(waiting bool) {
+	b.waiting = waiting
+	b.updateStalled()
+}
+
+// addReaders records readers starting or finishing serving the output. It must
+// be called with mu held.
+func (b *outputBuffer) addReaders(delta int) {
+	b.readers += delta
+	b.updateStalled()
+}
+
+// updateStalled closes stalled once no reader could make room for the
+// pump, and replaces it once one could. It must be called with mu held.
+func (b *outputBuffer) updateStalled() {
+	stalled := b.waiting && b.readers == 0
+
+	switch {
+	case stalled && !b.stalledClosed:
+		close(b.stalled)
+		b.stalledClosed = true
+
+	case !stalled && b.stalledClosed:
+		b.stalled = make(chan struct{})
+		b.stalledClosed = false
+	}
+}
+
+func (b *outputBuffer) stalledCh() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.stalled
+}
+
+// write buffers as much of data as fits, discarding served output to make
+// room, and returns how much it buffered. It must be called with mu held.
+func (b *outputBuffer) write(data []byte) int {
+	size := int64(b.size)
+
+	if free := size - (b.end - b.start); free < int64(len(data)) {
+		start := b.end + int64(len(data)) - size
+		if start > b.served {
+			start = b.served
+		}
+
+		if start > b.start {
+			b.start = start
+		}
+	}
+
+	n := int64(len(data))
+	if free := size - (b.end - b.start); free < n {
+		n = free
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	if buffered := b.end - b.start + n; buffered > int64(len(b.data)) {
+		b.grow(buffered)
+	}
+
+	ringCopy(b.data, b.end, data[:n])
+	b.end += n
+
+	b.cond.Broadcast()
+
+	return int(n)
+}
+
+// grow reallocates the ring to hold at least needed bytes, so that streams
+// which produce little output only use a little memory.
+func (b *outputBuffer) grow(needed int64) {
+	length := 2 * int64(len(b.data))
+	if length < needed {
+		length = needed
+	}
+
+	if length < minBufferAllocation {
+		length = minBufferAllocation
+	}
+
+	if length > int64(b.size) {
+		length = int64(b.size)
+	}
+
+	buffered := make([]byte, b.end-b.start)
+	b.read(buffered, b.start)
+
+	b.data = make([]byte, length)
+	ringCopy(b.data, b.start, buffered)
+}
+
+// ringCopy copies src into ring at the position of offset, wrapping around.
+func ringCopy(ring []byte, offset int64, src []byte) {
+	pos := offset % int64(len(ring))
+	n := copy(ring[pos:], src)
+	copy(ring, src[n:])
+}
+
+// read copies buffered output from offset into p. It must be called with mu
+// held.
+func (b *outputBuffer) read(p []byte, offset int64) int {
+	size := int64(len(b.data))
+
+	n := 0
+	for n < len(p) && offset < b.end {
+		pos := offset % size
+
+		limit := size - pos
+		if remaining := b.end - offset; remaining < limit {
+			limit = remaining
+		}
+
+		copied := copy(p[n:], b.data[pos:pos+limit])
+		offset += int64(copied)
+		n += copied
+	}
+
+	return n
+}
+
+func (b *outputBuffer) reader(offset int64) (*reader, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if offset < 0 {
+		offset = b.served
+	}
+
+	if offset < b.start || offset > b.end {
+		return nil, ErrOffsetUnavailable
+	}
+
+	return &reader{buf: b, offset: offset}, nil
+}
+
+func (b *outputBuffer) servedOffset() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.served
+}
+
+func (b *outputBuffer) remove() {
+	b.mu.Lock()
+	b.removed = true
+	b.cond.Broadcast()
+	b.mu.Unlock()
+}
+
+type reader struct {
+	buf    *outputBuffer
+	offset int64
+}
+
+// serve writes buffered output to writer as it arrives, until the stream is
+// stopped and all of its output has been written, or writer fails.
+func (r *reader) serve(writer io.Writer) {
+	b := r.buf
+	p := make([]byte, 32*1024)
+
+	b.mu.Lock()
+	b.addReaders(1)
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.addReaders(-1)
+		b.mu.Unlock()
+	}()
+
+	var pending []byte
+
+	for {
+		b.mu.Lock()
+
+		for r.offset == b.end && !b.pumped && !b.removed {
+			b.cond.Wait()
+		}
+
+		if b.removed || r.offset < b.start {
+			b.mu.Unlock()
+			return
+		}
+
+		if r.offset == b.end {
+			// the stream has been stopped; drain anything sent since
+			if len(pending) == 0 {
+				select {
+				case pending = <-b.ch:
+				default:
+					b.mu.Unlock()
+					return
+				}
+			}
+
+			pending = pending[b.write(pending):]
+		}
+
+		n := b.read(p, r.offset)
+
+		b.mu.Unlock()
+
+		if _, err := writer.Write(p[:n]); err != nil {
+			return
+		}
+
+		b.mu.Lock()
+
+		r.offset += int64(n)
+		if r.offset > b.served {
+			b.served = r.offset
+			b.cond.Broadcast()
+		}
+
+		b.mu.Unlock()
+	}
+}
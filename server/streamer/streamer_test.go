@@ -110,6 +110,138 @@ var _ = Describe("Streamer", func() {
 		})
 	})
 
+	It("should count streams until they are stopped", func() {
+		sid1 := str.Stream(stdoutChan, stderrChan)
+		sid2 := str.Stream(stdoutChan, stderrChan)
+		Expect(str.ActiveStreams()).To(Equal(2))
+
+		str.Stop(sid1)
+		Expect(str.ActiveStreams()).To(Equal(1))
+
+		str.Stop(sid2)
+		Expect(str.ActiveStreams()).To(Equal(0))
+	})
+
+	It("should buffer output before it is served", func() {
+		sid := str.Stream(stdoutChan, stderrChan)
+		stdoutChan <- []byte("a")
+		stdoutChan <- []byte("b")
+		stdoutChan <- []byte("c")
+		str.Stop(sid)
+
+		w := new(bytes.Buffer)
+		str.ServeStdout(sid, w)
+		Expect(w.String()).To(Equal("abc"))
+	})
+
+	It("should serve output again from an earlier offset", func() {
+		sid := str.Stream(stdoutChan, stderrChan)
+		w := &syncBuffer{
+			Buffer: new(bytes.Buffer),
+		}
+		go str.ServeStdout(sid, w)
+		stdoutChan <- []byte("abc")
+		Eventually(w.String).Should(Equal("abc"))
+
+		stdoutOffset, stderrOffset := str.Offsets(sid)
+		Expect(stdoutOffset).To(Equal(int64(3)))
+		Expect(stderrOffset).To(BeZero())
+
+		stdoutChan <- []byte("def")
+		str.Stop(sid)
+
+		replayed := new(bytes.Buffer)
+		Expect(str.ServeStdoutFrom(sid, 1, replayed)).To(Succeed())
+		Expect(replayed.String()).To(Equal("bcdef"))
+	})
+
+	It("should serve standard error from an offset", func() {
+		sid := str.Stream(stdoutChan, stderrChan)
+		stderrChan <- []byte("abc")
+		str.Stop(sid)
+		str.ServeStderr(sid, new(bytes.Buffer))
+
+		w := new(bytes.Buffer)
+		Expect(str.ServeStderrFrom(sid, 2, w)).To(Succeed())
+		Expect(w.String()).To(Equal("c"))
+	})
+
+	It("should fail to serve from an offset that has not been reached", func() {
+		sid := str.Stream(stdoutChan, stderrChan)
+		str.Stop(sid)
+
+		Expect(str.ServeStdoutFrom(sid, 1, new(bytes.Buffer))).To(Equal(streamer.ErrOffsetUnavailable))
+	})
+
+	It("should fail to serve from a stream that does not exist", func() {
+		Expect(str.ServeStdoutFrom("bogus", 0, new(bytes.Buffer))).To(Equal(streamer.ErrStreamNotFound))
+	})
+
+	Context("when the buffer is full", func() {
+		JustBeforeEach(func() {
+			str = streamer.NewWithBufferSize(graceTime, 4)
+		})
+
+		It("should discard output once it has been served", func() {
+			sid := str.Stream(stdoutChan, stderrChan)
+			w := &syncBuffer{
+				Buffer: new(bytes.Buffer),
+			}
+			go str.ServeStdout(sid, w)
+			stdoutChan <- []byte("abcd")
+			stdoutChan <- []byte("ef")
+			Eventually(w.String).Should(Equal("abcdef"))
+			str.Stop(sid)
+
+			Expect(str.ServeStdoutFrom(sid, 1, new(bytes.Buffer))).To(Equal(streamer.ErrOffsetUnavailable))
+
+			replayed := new(bytes.Buffer)
+			Expect(str.ServeStdoutFrom(sid, 2, replayed)).To(Succeed())
+			Expect(replayed.String()).To(Equal("cdef"))
+		})
+
+		It("should report when unserved output fills the buffer", func() {
+			sid := str.Stream(stdoutChan, stderrChan)
+
+			stdoutStalled := func() <-chan struct{} {
+				stalled, _ := str.Stalled(sid)
+				return stalled
+			}
+
+			stdoutChan <- []byte("abcd")
+			Consistently(stdoutStalled).ShouldNot(BeClosed())
+
+			stdoutChan <- []byte("ef")
+			Eventually(stdoutStalled).Should(BeClosed())
+
+			_, stderrStalled := str.Stalled(sid)
+			Expect(stderrStalled).NotTo(BeClosed())
+
+			w := &syncBuffer{
+				Buffer: new(bytes.Buffer),
+			}
+			go str.ServeStdout(sid, w)
+			Eventually(w.String).Should(Equal("abcdef"))
+			Expect(stdoutStalled()).NotTo(BeClosed())
+			str.Stop(sid)
+		})
+
+		It("should not discard output that has not been served", func() {
+			sid := str.Stream(stdoutChan, stderrChan)
+			stdoutChan <- []byte("abcd")
+			stdoutChan <- []byte("ef")
+			stdoutChan <- []byte("gh")
+			Consistently(stdoutChan).Should(HaveLen(1))
+
+			w := &syncBuffer{
+				Buffer: new(bytes.Buffer),
+			}
+			go str.ServeStdout(sid, w)
+			Eventually(w.String).Should(Equal("abcdefgh"))
+			str.Stop(sid)
+		})
+	})
+
 	It("should terminate streaming output after a write error has occurred", func() {
 		sid := str.Stream(stdoutChan, stderrChan)
 		w := &syncBuffer{
@@ -9,10 +9,11 @@ type TimeBomb struct {
 	countdown time.Duration
 	detonate  func()
 
-	pauses  int
-	defused bool
-	timer   *time.Timer
-	lock    *sync.Mutex
+	pauses     int
+	defused    bool
+	referenced time.Time
+	timer      *time.Timer
+	lock       *sync.Mutex
 }
 
 func New(countdown time.Duration, detonate func()) *TimeBomb {
@@ -25,8 +26,16 @@ func New(countdown time.Duration, detonate func()) *TimeBomb {
 }
 
 func (b *TimeBomb) Strap() {
+	b.StrapSince(time.Now())
+}
+
+// StrapSince starts the countdown as though it had started at referenced,
+// e.g. to resume a countdown from before a restart. If the countdown has
+// already elapsed the bomb detonates immediately.
+func (b *TimeBomb) StrapSince(referenced time.Time) {
 	b.lock.Lock()
-	b.timer = time.AfterFunc(b.countdown, b.detonate)
+	b.referenced = referenced
+	b.timer = time.AfterFunc(b.countdown-time.Since(referenced), b.detonate)
 	b.lock.Unlock()
 }
 
@@ -73,6 +82,55 @@ func (b *TimeBomb) Unpause() {
 	b.pauses--
 
 	if !b.defused && b.pauses == 0 {
+		b.referenced = time.Now()
 		b.timer = time.AfterFunc(b.countdown, b.detonate)
 	}
 }
+
+// Referenced returns when the countdown last started, or the current time if
+// it is paused.
+func (b *TimeBomb) Referenced() time.Time {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.pauses > 0 {
+		return time.Now()
+	}
+
+	return b.referenced
+}
+
+// Countdown returns how long the bomb waits, once strapped or unpaused,
+// before detonating.
+func (b *TimeBomb) Countdown() time.Duration {
+	return b.countdown
+}
+
+// Pauses returns how many Pauses are holding the countdown.
+func (b *TimeBomb) Pauses() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.pauses
+}
+
+// Deadline returns when the bomb will detonate, or the zero time if it is
+// paused or defused.
+func (b *TimeBomb) Deadline() time.Time {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.pauses > 0 || b.defused {
+		return time.Time{}
+	}
+
+	return b.referenced.Add(b.countdown)
+}
+
+// Paused reports whether the countdown is held by at least one Pause.
+func (b *TimeBomb) Paused() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.pauses > 0
+}
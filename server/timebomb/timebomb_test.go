@@ -283,4 +283,101 @@ var _ = Describe("THE TIMEBOMB", func() {
 			})
 		})
 	})
+
+	Context("WHEN STRAPPED SINCE AN EARLIER TIME", func() {
+		It("DETONATES AFTER THE REST OF THE COUNTDOWN", func() {
+			detonated := make(chan time.Time)
+
+			countdown := 200 * time.Millisecond
+
+			bomb := timebomb.New(
+				countdown,
+				func() {
+					detonated <- time.Now()
+				},
+			)
+
+			referenced := time.Now().Add(-150 * time.Millisecond)
+
+			bomb.StrapSince(referenced)
+
+			Ω((<-detonated).Sub(referenced)).Should(BeNumerically(">=", countdown))
+			Ω(time.Since(referenced)).Should(BeNumerically("<", countdown+100*time.Millisecond))
+		})
+
+		It("DETONATES IMMEDIATELY IF THE COUNTDOWN HAS ELAPSED", func() {
+			detonated := make(chan time.Time, 1)
+
+			bomb := timebomb.New(time.Hour, func() {
+				detonated <- time.Now()
+			})
+
+			bomb.StrapSince(time.Now().Add(-2 * time.Hour))
+
+			Eventually(detonated).Should(Receive())
+		})
+	})
+
+	Describe("REFERENCED", func() {
+		It("REPORTS WHEN THE COUNTDOWN LAST STARTED", func() {
+			bomb := timebomb.New(time.Hour, func() {})
+
+			referenced := time.Now().Add(-time.Minute)
+			bomb.StrapSince(referenced)
+			Ω(bomb.Referenced()).Should(Equal(referenced))
+
+			bomb.Pause()
+			Ω(bomb.Referenced()).Should(BeTemporally("~", time.Now(), time.Second))
+
+			bomb.Unpause()
+			Ω(bomb.Referenced()).Should(BeTemporally("~", time.Now(), time.Second))
+
+			bomb.Defuse()
+		})
+	})
+
+	Describe("DEADLINE", func() {
+		It("REPORTS WHEN THE BOMB WILL DETONATE UNLESS IT IS PAUSED OR DEFUSED", func() {
+			bomb := timebomb.New(time.Hour, func() {})
+			Ω(bomb.Countdown()).Should(Equal(time.Hour))
+
+			referenced := time.Now()
+			bomb.StrapSince(referenced)
+			Ω(bomb.Deadline()).Should(Equal(referenced.Add(time.Hour)))
+
+			bomb.Pause()
+			bomb.Pause()
+			Ω(bomb.Pauses()).Should(Equal(2))
+			Ω(bomb.Deadline()).Should(BeZero())
+
+			bomb.Unpause()
+			bomb.Unpause()
+			Ω(bomb.Pauses()).Should(BeZero())
+			Ω(bomb.Deadline()).Should(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+
+			bomb.Defuse()
+			Ω(bomb.Deadline()).Should(BeZero())
+		})
+	})
+
+	Describe("PAUSED", func() {
+		It("REPORTS WHETHER ANY PAUSE IS HELD", func() {
+			bomb := timebomb.New(time.Hour, func() {})
+
+			bomb.Strap()
+			Ω(bomb.Paused()).Should(BeFalse())
+
+			bomb.Pause()
+			bomb.Pause()
+			Ω(bomb.Paused()).Should(BeTrue())
+
+			bomb.Unpause()
+			Ω(bomb.Paused()).Should(BeTrue())
+
+			bomb.Unpause()
+			Ω(bomb.Paused()).Should(BeFalse())
+
+			bomb.Defuse()
+		})
+	})
 })
@@ -0,0 +1,56 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+// WriteEvent writes the event in the text/event-stream (server-sent events)
+// format.
+func WriteEvent(writer io.Writer, event garden.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "event: %s\ndata: %s\n\n", event.Kind, data)
+	return err
+}
+
+// ReadEvent reads the next event written by WriteEvent. Fields other than
+// data, as well as comments, are ignored.
+func ReadEvent(reader *bufio.Reader) (garden.Event, error) {
+	var data []byte
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return garden.Event{}, err
+		}
+
+		line = bytes.TrimRight(line, "\r\n")
+
+		if len(line) == 0 {
+			if data == nil {
+				continue
+			}
+
+			var event garden.Event
+			err := json.Unmarshal(data, &event)
+			return event, err
+		}
+
+		if bytes.HasPrefix(line, []byte("data:")) {
+			data = append(data, bytes.TrimPrefix(bytes.TrimPrefix(line, []byte("data:")), []byte(" "))...)
+		}
+	}
+}
@@ -10,6 +10,10 @@ const (
 	Stderr
 )
 
+// ProcessPayload is exchanged over the connection of a run or attach. The
+// server first sends the process and stream IDs; the stream ID is empty when
+// there is no output to stream, as for an attach to a detached process that
+// has exited.
 type ProcessPayload struct {
 	ProcessID  string          `json:"process_id,omitempty"`
 	StreamID   string          `json:"stream_id,omitempty"`
@@ -19,6 +23,21 @@ type ProcessPayload struct {
 	Error      *string         `json:"error,omitempty"`
 	TTY        *garden.TTYSpec `json:"tty,omitempty"`
 	Signal     *garden.Signal  `json:"signal,omitempty"`
+
+	// StdoutOffset and StderrOffset are sent along with the stream ID, giving
+	// the offsets its stdout and stderr are streamed from.
+	StdoutOffset uint64 `json:"stdout_offset,omitempty"`
+	StderrOffset uint64 `json:"stderr_offset,omitempty"`
+
+	// AllSignals is sent along with the stream ID by servers that forward
+	// every garden.Signal to the backend. Older servers only understand
+	// garden.SignalTerminate and garden.SignalKill, and stop reading the
+	// process's input when sent any other signal.
+	AllSignals bool `json:"all_signals,omitempty"`
+
+	// DroppedBytes is sent along with the exit status or error, counting the
+	// output the server discarded because the client fell behind.
+	DroppedBytes uint64 `json:"dropped_bytes,omitempty"`
 }
 
 type NetInRequest struct {
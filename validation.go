@@ -0,0 +1,164 @@
+package garden
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// maxWindowDimension is the largest number of columns or rows a TTY window
+// can have.
+const maxWindowDimension = 1<<16 - 1
+
+// Validate checks the spec for mistakes that would be rejected by any backend,
+// returning an InvalidRequestError describing each of them, or nil. The server
+// validates specs before creating containers; clients may validate them first
+// to fail early.
+func (spec ContainerSpec) Validate() error {
+	var errs fieldErrors
+
+	errs.check(spec.Network == "" || validNetwork(spec.Network), "network",
+		"must be of the form a.b.c.d/n and not a reserved address of the subnet")
+
+	errs.check(validRootFSPath(spec.RootFSPath), "rootfs",
+		`must have an empty or "docker" scheme`)
+
+	for i, mount := range spec.BindMounts {
+		field := fmt.Sprintf("bind_mounts[%d]", i)
+
+		errs.check(path.IsAbs(mount.DstPath), field+".dst_path", "must be an absolute path")
+
+		errs.check(mount.Mode == BindMountModeRO || mount.Mode == BindMountModeRW, field+".mode",
+			"must be RO or RW")
+
+		errs.check(mount.Origin == BindMountOriginHost || mount.Origin == BindMountOriginContainer, field+".origin",
+			"must be Host or Container")
+	}
+
+	errs.checkEnv(spec.Env)
+	errs.checkDiskLimits("limits.disk_limits.", spec.Limits.Disk)
+
+	return errs.err()
+}
+
+// Validate checks the spec for mistakes that would be rejected by any backend,
+// returning an InvalidRequestError describing each of them, or nil.
+func (spec ProcessSpec) Validate() error {
+	var errs fieldErrors
+
+	errs.checkEnv(spec.Env)
+
+	if spec.TTY != nil && spec.TTY.WindowSize != nil {
+		errs.check(validWindowDimension(spec.TTY.WindowSize.Columns), "tty.window_size.columns",
+			fmt.Sprintf("must be between 0 and %d", maxWindowDimension))
+
+		errs.check(validWindowDimension(spec.TTY.WindowSize.Rows), "tty.window_size.rows",
+			fmt.Sprintf("must be between 0 and %d", maxWindowDimension))
+	}
+
+	return errs.err()
+}
+
+// Validate checks that no soft limit exceeds its hard limit, returning an
+// InvalidRequestError describing each that does, or nil.
+func (limits DiskLimits) Validate() error {
+	var errs fieldErrors
+	errs.checkDiskLimits("", limits)
+	return errs.err()
+}
+
+// Validate checks the options for mistakes that would be rejected by any
+// server, returning an InvalidRequestError describing each of them, or nil.
+func (options ListOptions) Validate() error {
+	var errs fieldErrors
+
+	errs.check(options.Limit >= 0, "limit", "must not be negative")
+
+	for i, field := range options.Fields {
+		errs.check(validContainerInfoField(field), fmt.Sprintf("fields[%d]", i),
+			"must be one of state, events, ips, container_path, process_ids, properties or mapped_ports")
+	}
+
+	return errs.err()
+}
+
+type fieldErrors []FieldError
+
+func (errs *fieldErrors) check(ok bool, field, message string) {
+	if !ok {
+		*errs = append(*errs, FieldError{Field: field, Message: message})
+	}
+}
+
+func (errs *fieldErrors) checkEnv(env []string) {
+	for i, e := range env {
+		errs.check(strings.Index(e, "=") > 0, fmt.Sprintf("env[%d]", i), "must be of the form KEY=VALUE")
+	}
+}
+
+func (errs *fieldErrors) checkDiskLimits(prefix string, limits DiskLimits) {
+	// a hard limit of zero is no limit
+	errs.check(limits.InodeHard == 0 || limits.InodeSoft <= limits.InodeHard, prefix+"inode_soft",
+		"must not exceed inode_hard")
+
+	errs.check(limits.ByteHard == 0 || limits.ByteSoft <= limits.ByteHard, prefix+"byte_soft",
+		"must not exceed byte_hard")
+}
+
+func (errs fieldErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return InvalidRequestError{Fields: errs}
+}
+
+// validNetwork checks the network is a CIDR whose address, unless it is the
+// subnet address, is not one of the addresses ContainerSpec.Network reserves.
+func validNetwork(network string) bool {
+	ip, subnet, err := net.ParseCIDR(network)
+	if err != nil || ip.To4() == nil {
+		return false
+	}
+
+	if ip.Equal(subnet.IP) {
+		return true
+	}
+
+	broadcast := make(net.IP, len(subnet.IP))
+	for i := range subnet.IP {
+		broadcast[i] = subnet.IP[i] | ^subnet.Mask[i]
+	}
+
+	reserved := make(net.IP, len(broadcast))
+	copy(reserved, broadcast)
+	reserved[len(reserved)-1]--
+
+	return !ip.Equal(broadcast) && !ip.Equal(reserved)
+}
+
+func validRootFSPath(rootFSPath string) bool {
+	rootFSURL, err := url.Parse(rootFSPath)
+	if err != nil {
+		return false
+	}
+
+	return rootFSURL.Scheme == "" || rootFSURL.Scheme == "docker"
+}
+
+func validWindowDimension(n int) bool {
+	return n >= 0 && n <= maxWindowDimension
+}
+
+func validContainerInfoField(field ContainerInfoField) bool {
+	switch field {
+	case ContainerInfoFieldState, ContainerInfoFieldEvents, ContainerInfoFieldIPs,
+		ContainerInfoFieldContainerPath, ContainerInfoFieldProcessIDs,
+		ContainerInfoFieldProperties, ContainerInfoFieldMappedPorts:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,156 @@
+package garden_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+var _ = Describe("Validation", func() {
+	fields := func(err error) []string {
+		Ω(err).Should(BeAssignableToTypeOf(garden.InvalidRequestError{}))
+
+		names := []string{}
+		for _, field := range err.(garden.InvalidRequestError).Fields {
+			names = append(names, field.Field)
+		}
+
+		return names
+	}
+
+	Describe("ContainerSpec", func() {
+		var spec garden.ContainerSpec
+
+		BeforeEach(func() {
+			spec = garden.ContainerSpec{
+				Network:    "10.0.0.1/30",
+				RootFSPath: "docker:///busybox",
+				BindMounts: []garden.BindMount{
+					{
+						SrcPath: "/src",
+						DstPath: "/dst",
+						Mode:    garden.BindMountModeRW,
+						Origin:  garden.BindMountOriginContainer,
+					},
+				},
+				Env: []string{"A=1", "B="},
+				Limits: garden.Limits{
+					Disk: garden.DiskLimits{ByteSoft: 1, ByteHard: 2, InodeSoft: 3},
+				},
+			}
+		})
+
+		It("accepts a well formed spec", func() {
+			Ω(spec.Validate()).Should(Succeed())
+		})
+
+		It("accepts an empty spec", func() {
+			Ω(garden.ContainerSpec{}.Validate()).Should(Succeed())
+		})
+
+		It("accepts a subnet address", func() {
+			spec.Network = "10.0.0.0/29"
+			Ω(spec.Validate()).Should(Succeed())
+		})
+
+		It("rejects a network that is not a CIDR", func() {
+			spec.Network = "10.0.0.1"
+			Ω(fields(spec.Validate())).Should(Equal([]string{"network"}))
+		})
+
+		It("rejects the broadcast address and the address reserved below it", func() {
+			spec.Network = "10.0.0.3/30"
+			Ω(fields(spec.Validate())).Should(Equal([]string{"network"}))
+
+			spec.Network = "10.0.0.2/30"
+			Ω(fields(spec.Validate())).Should(Equal([]string{"network"}))
+		})
+
+		It("rejects a rootfs with another scheme", func() {
+			spec.RootFSPath = "http://example.com/rootfs"
+			Ω(fields(spec.Validate())).Should(Equal([]string{"rootfs"}))
+		})
+
+		It("rejects bind mounts with unknown modes or origins, or relative destinations", func() {
+			spec.BindMounts = append(spec.BindMounts, garden.BindMount{
+				SrcPath: "/src",
+				DstPath: "dst",
+				Mode:    2,
+				Origin:  2,
+			})
+
+			Ω(fields(spec.Validate())).Should(Equal([]string{
+				"bind_mounts[1].dst_path",
+				"bind_mounts[1].mode",
+				"bind_mounts[1].origin",
+			}))
+		})
+
+		It("rejects env entries that are not of the form KEY=VALUE", func() {
+			spec.Env = []string{"A=1", "B", "=2"}
+			Ω(fields(spec.Validate())).Should(Equal([]string{"env[1]", "env[2]"}))
+		})
+
+		It("rejects soft disk limits that exceed their hard limits", func() {
+			spec.Limits.Disk = garden.DiskLimits{ByteSoft: 2, ByteHard: 1, InodeSoft: 4, InodeHard: 3}
+
+			Ω(fields(spec.Validate())).Should(Equal([]string{
+				"limits.disk_limits.inode_soft",
+				"limits.disk_limits.byte_soft",
+			}))
+		})
+	})
+
+	Describe("ProcessSpec", func() {
+		It("accepts a well formed spec", func() {
+			spec := garden.ProcessSpec{
+				Path: "ls",
+				Env:  []string{"A=1"},
+				TTY: &garden.TTYSpec{
+					WindowSize: &garden.WindowSize{Columns: 80, Rows: 24},
+				},
+			}
+
+			Ω(spec.Validate()).Should(Succeed())
+		})
+
+		It("rejects env entries that are not of the form KEY=VALUE", func() {
+			spec := garden.ProcessSpec{Env: []string{"A"}}
+			Ω(fields(spec.Validate())).Should(Equal([]string{"env[0]"}))
+		})
+
+		It("rejects window sizes out of range", func() {
+			spec := garden.ProcessSpec{
+				TTY: &garden.TTYSpec{
+					WindowSize: &garden.WindowSize{Columns: -1, Rows: 1 << 16},
+				},
+			}
+
+			Ω(fields(spec.Validate())).Should(Equal([]string{
+				"tty.window_size.columns",
+				"tty.window_size.rows",
+			}))
+		})
+	})
+
+	Describe("ListOptions", func() {
+		It("accepts well formed options", func() {
+			options := garden.ListOptions{
+				Limit:  10,
+				Fields: []garden.ContainerInfoField{garden.ContainerInfoFieldState, garden.ContainerInfoFieldIPs},
+			}
+
+			Ω(options.Validate()).Should(Succeed())
+		})
+
+		It("rejects a negative limit and unknown fields", func() {
+			options := garden.ListOptions{
+				Limit:  -1,
+				Fields: []garden.ContainerInfoField{garden.ContainerInfoFieldState, "bogus"},
+			}
+
+			Ω(fields(options.Validate())).Should(Equal([]string{"limit", "fields[1]"}))
+		})
+	})
+})